@@ -12,14 +12,19 @@ type DonNotifier struct {
 	don      capabilities.DON
 	notified bool
 	ch       chan struct{}
+	// changes holds the latest DON the node moved to, if not received yet
+	changes chan capabilities.DON
 }
 
 func NewDonNotifier() *DonNotifier {
 	return &DonNotifier{
-		ch: make(chan struct{}),
+		ch:      make(chan struct{}),
+		changes: make(chan capabilities.DON, 1),
 	}
 }
 
+// NotifyDonSet sets the DON of the node.  Once set, it is only replaced by a DON with another ID, which is then sent
+// on the DonChanges channel.
 func (n *DonNotifier) NotifyDonSet(don capabilities.DON) {
 	n.mu.Lock()
 	defer n.mu.Unlock()
@@ -27,7 +32,23 @@ func (n *DonNotifier) NotifyDonSet(don capabilities.DON) {
 		n.don = don
 		n.notified = true
 		close(n.ch)
+		return
 	}
+	if don.ID == n.don.ID {
+		return
+	}
+	n.don = don
+	// only the latest change matters, so drop a change that was not received yet
+	select {
+	case <-n.changes:
+	default:
+	}
+	n.changes <- don
+}
+
+// DonChanges returns the channel receiving the new DON of the node each time it moves to another DON.
+func (n *DonNotifier) DonChanges() <-chan capabilities.DON {
+	return n.changes
 }
 
 func (n *DonNotifier) WaitForDon(ctx context.Context) (capabilities.DON, error) {
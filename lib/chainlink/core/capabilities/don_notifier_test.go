@@ -47,3 +47,23 @@ func TestDonNotifier_WaitForDon_ContextTimeout(t *testing.T) {
 	require.Error(t, err)
 	assert.Equal(t, context.DeadlineExceeded, err)
 }
+
+func TestDonNotifier_DonChanges(t *testing.T) {
+	notifier := capabilities.NewDonNotifier()
+	notifier.NotifyDonSet(commoncap.DON{ID: 1})
+	// the same DON being set again is not a change
+	notifier.NotifyDonSet(commoncap.DON{ID: 1, ConfigVersion: 2})
+	require.Empty(t, notifier.DonChanges())
+
+	// only the latest change is kept until it is received
+	notifier.NotifyDonSet(commoncap.DON{ID: 2})
+	notifier.NotifyDonSet(commoncap.DON{ID: 3})
+	require.Len(t, notifier.DonChanges(), 1)
+	assert.Equal(t, commoncap.DON{ID: 3}, <-notifier.DonChanges())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	result, err := notifier.WaitForDon(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, commoncap.DON{ID: 3}, result)
+}
@@ -38,15 +38,20 @@ func (l *LocalTargetCapability) Execute(ctx context.Context, req capabilities.Ca
 		return l.TargetCapability.Execute(ctx, req)
 	}
 
-	peerIDToTransmissionDelay, err := GetPeerIDToTransmissionDelay(l.localNode.WorkflowDON.Members, req)
+	details, err := GetPeerIDToTransmissionDelayWithDetails(l.localNode.WorkflowDON.Members, req)
 	if err != nil {
 		return capabilities.CapabilityResponse{}, fmt.Errorf("capability id: %s failed to get peer ID to transmission delay map: %w", l.capabilityID, err)
 	}
 
-	delay, existsForPeerID := peerIDToTransmissionDelay[*l.localNode.PeerID]
+	delay, existsForPeerID := details.Delays[*l.localNode.PeerID]
 	if !existsForPeerID {
+		l.lggr.Debugw("not transmitting, peer is not part of the transmission schedule",
+			"capabilityID", l.capabilityID, "peerID", *l.localNode.PeerID, "transmissionID", details.TransmissionID,
+			"schedule", details.Schedule, "permutation", details.Permutation)
 		return capabilities.CapabilityResponse{}, nil
 	}
+	l.lggr.Debugw("transmitting after delay", "capabilityID", l.capabilityID, "transmissionID", details.TransmissionID,
+		"delay", delay, "stage", details.Stages[*l.localNode.PeerID], "schedule", details.Schedule)
 
 	select {
 	case <-ctx.Done():
@@ -59,50 +59,123 @@ func ExtractTransmissionConfig(config *values.Map) (TransmissionConfig, error) {
 // GetPeerIDToTransmissionDelay returns a map of PeerID to the time.Duration that the node with that PeerID should wait
 // before transmitting the capability request. If a node is not in the map, it should not transmit.
 func GetPeerIDToTransmissionDelay(donPeerIDs []types.PeerID, req capabilities.CapabilityRequest) (map[types.PeerID]time.Duration, error) {
+	details, err := GetPeerIDToTransmissionDelayWithDetails(donPeerIDs, req)
+	if err != nil {
+		return nil, err
+	}
+	return details.Delays, nil
+}
+
+// TransmissionDetails describes how the transmission delays of a request were computed, so that callers can log why a
+// node did or did not transmit.
+type TransmissionDetails struct {
+	TransmissionID string
+	Config         TransmissionConfig
+	// Schedule holds the number of peers transmitting in each stage.
+	Schedule []int
+	// Permutation holds, for each peer in the order of the DON members, its position in the transmission order.
+	Permutation []int
+	// Stages maps each transmitting peer to the index of the stage it transmits in.
+	Stages map[types.PeerID]int
+	// Delays maps each transmitting peer to the time it should wait before transmitting.
+	Delays map[types.PeerID]time.Duration
+}
+
+// GetPeerIDToTransmissionDelayWithDetails is GetPeerIDToTransmissionDelay, additionally returning the schedule,
+// permutation and stage of each peer that produced the delays.
+func GetPeerIDToTransmissionDelayWithDetails(donPeerIDs []types.PeerID, req capabilities.CapabilityRequest) (TransmissionDetails, error) {
 	tc, err := ExtractTransmissionConfig(req.Config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to extract transmission config from request: %w", err)
+		return TransmissionDetails{}, fmt.Errorf("failed to extract transmission config from request: %w", err)
 	}
 
 	workflowExecutionID := req.Metadata.WorkflowExecutionID
 	if err := validation.ValidateWorkflowOrExecutionID(workflowExecutionID); err != nil {
-		return nil, fmt.Errorf("workflow or execution ID is invalid: %w", err)
+		return TransmissionDetails{}, fmt.Errorf("workflow or execution ID is invalid: %w", err)
 	}
 
-	return GetPeerIDToTransmissionDelaysForConfig(donPeerIDs, workflowExecutionID, tc)
+	return getTransmissionDetailsForConfig(donPeerIDs, workflowExecutionID, tc)
 }
 
 func GetPeerIDToTransmissionDelaysForConfig(donPeerIDs []types.PeerID, transmissionID string, tc TransmissionConfig) (map[types.PeerID]time.Duration, error) {
+	details, err := getTransmissionDetailsForConfig(donPeerIDs, transmissionID, tc)
+	if err != nil {
+		return nil, err
+	}
+	return details.Delays, nil
+}
+
+func getTransmissionDetailsForConfig(donPeerIDs []types.PeerID, transmissionID string, tc TransmissionConfig) (TransmissionDetails, error) {
 	donMemberCount := len(donPeerIDs)
-	key := transmissionScheduleSeed(transmissionID)
 	schedule, err := createTransmissionSchedule(tc.Schedule, donMemberCount)
 	if err != nil {
-		return nil, err
+		return TransmissionDetails{}, err
 	}
 
-	picked := permutation.Permutation(donMemberCount, key)
+	details := TransmissionDetails{
+		TransmissionID: transmissionID,
+		Config:         tc,
+		Schedule:       schedule,
+		Stages:         map[types.PeerID]int{},
+		Delays:         map[types.PeerID]time.Duration{},
+	}
+	switch donMemberCount {
+	case 0:
+		// nobody to transmit
+		details.Permutation = []int{}
+		return details, nil
+	case 1:
+		// the only member transmits right away, whatever the schedule
+		details.Permutation = []int{0}
+		details.Stages[donPeerIDs[0]] = 0
+		details.Delays[donPeerIDs[0]] = 0
+		return details, nil
+	}
 
-	peerIDToTransmissionDelay := map[types.PeerID]time.Duration{}
+	picked := permutation.Permutation(donMemberCount, transmissionScheduleSeed(transmissionID))
+	if err := validatePermutation(picked, donMemberCount); err != nil {
+		return TransmissionDetails{}, fmt.Errorf("invalid transmission order for %s: %w", transmissionID, err)
+	}
+	details.Permutation = picked
 	for i, peerID := range donPeerIDs {
-		delay := delayFor(i, schedule, picked, tc.DeltaStage)
-		if delay != nil {
-			peerIDToTransmissionDelay[peerID] = *delay
+		stage, ok := stageFor(i, schedule, picked)
+		if ok {
+			details.Stages[peerID] = stage
+			details.Delays[peerID] = time.Duration(stage) * tc.DeltaStage
+		}
+	}
+	return details, nil
+}
+
+// validatePermutation checks that picked is a bijection over [0, donMemberCount), so that every member gets its own
+// position in the transmission order and no two members share a stage slot.
+func validatePermutation(picked []int, donMemberCount int) error {
+	if len(picked) != donMemberCount {
+		return fmt.Errorf("permutation has %d positions for %d DON members", len(picked), donMemberCount)
+	}
+	seen := make([]bool, donMemberCount)
+	for i, position := range picked {
+		if position < 0 || position >= donMemberCount {
+			return fmt.Errorf("position %d of member %d is out of range for %d DON members", position, i, donMemberCount)
 		}
+		if seen[position] {
+			return fmt.Errorf("position %d is assigned to more than one DON member", position)
+		}
+		seen[position] = true
 	}
-	return peerIDToTransmissionDelay, nil
+	return nil
 }
 
-func delayFor(position int, schedule []int, permutation []int, deltaStage time.Duration) *time.Duration {
+func stageFor(position int, schedule []int, permutation []int) (int, bool) {
 	sum := 0
 	for i, s := range schedule {
 		sum += s
 		if permutation[position] < sum {
-			result := time.Duration(i) * deltaStage
-			return &result
+			return i, true
 		}
 	}
 
-	return nil
+	return 0, false
 }
 
 func createTransmissionSchedule(scheduleType string, N int) ([]int, error) {
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/smartcontractkit/libocr/permutation"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -100,3 +101,108 @@ func Test_GetPeerIDToTransmissionDelay(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetPeerIDToTransmissionDelayWithDetails(t *testing.T) {
+	ids := []p2ptypes.PeerID{
+		[32]byte([]byte(fmt.Sprintf("%-32s", "one"))),
+		[32]byte([]byte(fmt.Sprintf("%-32s", "two"))),
+		[32]byte([]byte(fmt.Sprintf("%-32s", "three"))),
+		[32]byte([]byte(fmt.Sprintf("%-32s", "four"))),
+	}
+	const executionID = "15c631d295ef5e32deb99a10ee6804bc4af13855687559d7ff6552ac6dbb2ce0"
+
+	for _, schedule := range []string{Schedule_OneAtATime, Schedule_AllAtOnce} {
+		t.Run(schedule, func(t *testing.T) {
+			transmissionCfg, err := values.NewMap(map[string]any{
+				"schedule":   schedule,
+				"deltaStage": "100ms",
+			})
+			require.NoError(t, err)
+			req := capabilities.CapabilityRequest{
+				Config: transmissionCfg,
+				Metadata: capabilities.RequestMetadata{
+					WorkflowID:          "17c631d295ef5e32deb99a10ee6804bc4af13855687559d7ff6552ac6dbb2ce0",
+					WorkflowExecutionID: executionID,
+				},
+			}
+
+			details, err := GetPeerIDToTransmissionDelayWithDetails(ids, req)
+			require.NoError(t, err)
+			assert.Equal(t, executionID, details.TransmissionID)
+			assert.Equal(t, TransmissionConfig{Schedule: schedule, DeltaStage: 100 * time.Millisecond}, details.Config)
+			assert.Len(t, details.Permutation, len(ids))
+
+			delays, err := GetPeerIDToTransmissionDelay(ids, req)
+			require.NoError(t, err)
+			assert.Equal(t, delays, details.Delays)
+
+			require.Len(t, details.Stages, len(ids))
+			for i, id := range ids {
+				stage := details.Stages[id]
+				assert.Equal(t, time.Duration(stage)*details.Config.DeltaStage, details.Delays[id])
+				// the stage is the one whose cumulative size first covers the position of the peer in the permutation
+				before := 0
+				for _, size := range details.Schedule[:stage] {
+					before += size
+				}
+				assert.GreaterOrEqual(t, details.Permutation[i], before)
+				assert.Less(t, details.Permutation[i], before+details.Schedule[stage])
+			}
+		})
+	}
+
+	t.Run("invalid execution ID", func(t *testing.T) {
+		_, err := GetPeerIDToTransmissionDelayWithDetails(ids, capabilities.CapabilityRequest{
+			Config:   values.EmptyMap(),
+			Metadata: capabilities.RequestMetadata{WorkflowExecutionID: "invalid"},
+		})
+		require.ErrorContains(t, err, "workflow or execution ID is invalid")
+	})
+}
+
+func Test_GetPeerIDToTransmissionDelaysForConfig_SmallDON(t *testing.T) {
+	const transmissionID = "15c631d295ef5e32deb99a10ee6804bc4af13855687559d7ff6552ac6dbb2ce0"
+	peer := p2ptypes.PeerID([32]byte([]byte(fmt.Sprintf("%-32s", "one"))))
+
+	for _, schedule := range []string{Schedule_OneAtATime, Schedule_AllAtOnce} {
+		tc := TransmissionConfig{Schedule: schedule, DeltaStage: 100 * time.Millisecond}
+		t.Run(schedule, func(t *testing.T) {
+			t.Run("no members", func(t *testing.T) {
+				for _, ids := range [][]p2ptypes.PeerID{nil, {}} {
+					delays, err := GetPeerIDToTransmissionDelaysForConfig(ids, transmissionID, tc)
+					require.NoError(t, err)
+					assert.NotNil(t, delays)
+					assert.Empty(t, delays)
+				}
+			})
+
+			t.Run("one member", func(t *testing.T) {
+				delays, err := GetPeerIDToTransmissionDelaysForConfig([]p2ptypes.PeerID{peer}, transmissionID, tc)
+				require.NoError(t, err)
+				assert.Equal(t, map[p2ptypes.PeerID]time.Duration{peer: 0}, delays)
+			})
+		})
+	}
+
+	t.Run("unknown schedule", func(t *testing.T) {
+		_, err := GetPeerIDToTransmissionDelaysForConfig(nil, transmissionID, TransmissionConfig{Schedule: "sometimes"})
+		require.ErrorContains(t, err, "unknown schedule type sometimes")
+	})
+}
+
+func Test_validatePermutation(t *testing.T) {
+	require.NoError(t, validatePermutation([]int{2, 0, 3, 1}, 4))
+	require.NoError(t, validatePermutation([]int{}, 0))
+	require.EqualError(t, validatePermutation([]int{1, 0}, 3), "permutation has 2 positions for 3 DON members")
+	require.EqualError(t, validatePermutation([]int{0, 1, 2, 3}, 3), "permutation has 4 positions for 3 DON members")
+	require.EqualError(t, validatePermutation([]int{0, 3, 1}, 3), "position 3 of member 1 is out of range for 3 DON members")
+	require.EqualError(t, validatePermutation([]int{1, -1, 0}, 3), "position -1 of member 1 is out of range for 3 DON members")
+	require.EqualError(t, validatePermutation([]int{2, 0, 2}, 3), "position 2 is assigned to more than one DON member")
+
+	// the permutations used for scheduling are always valid
+	for n := 2; n <= 16; n++ {
+		for _, id := range []string{"a", "b", "15c631d295ef5e32deb99a10ee6804bc4af13855687559d7ff6552ac6dbb2ce0"} {
+			require.NoError(t, validatePermutation(permutation.Permutation(n, transmissionScheduleSeed(id)), n))
+		}
+	}
+}
@@ -40,10 +40,22 @@ func (disabled) ReplayAsync(fromBlock int64) {}
 
 func (disabled) RegisterFilter(ctx context.Context, filter Filter) error { return ErrDisabled }
 
+func (disabled) RegisterFilters(ctx context.Context, filters []Filter) error { return ErrDisabled }
+
 func (disabled) UnregisterFilter(ctx context.Context, name string) error { return ErrDisabled }
 
+func (disabled) UnregisterFilters(ctx context.Context, names []string) error { return ErrDisabled }
+
 func (disabled) HasFilter(name string) bool { return false }
 
+func (disabled) HasFilters(names []string) map[string]bool {
+	present := make(map[string]bool, len(names))
+	for _, name := range names {
+		present[name] = false
+	}
+	return present
+}
+
 func (disabled) GetFilters() map[string]Filter { return nil }
 
 func (disabled) LatestBlock(ctx context.Context) (LogPollerBlock, error) {
@@ -41,8 +41,11 @@ type LogPoller interface {
 	Replay(ctx context.Context, fromBlock int64) error
 	ReplayAsync(fromBlock int64)
 	RegisterFilter(ctx context.Context, filter Filter) error
+	RegisterFilters(ctx context.Context, filters []Filter) error
 	UnregisterFilter(ctx context.Context, name string) error
+	UnregisterFilters(ctx context.Context, names []string) error
 	HasFilter(name string) bool
+	HasFilters(names []string) map[string]bool
 	GetFilters() map[string]Filter
 	LatestBlock(ctx context.Context) (LogPollerBlock, error)
 	GetBlocksRange(ctx context.Context, numbers []uint64) ([]LogPollerBlock, error)
@@ -261,22 +264,8 @@ func (filter *Filter) Contains(other *Filter) bool {
 // The filter may be unregistered later by Filter.Name
 // Warnings/debug information is keyed by filter name.
 func (lp *logPoller) RegisterFilter(ctx context.Context, filter Filter) error {
-	if len(filter.Addresses) == 0 {
-		return pkgerrors.Errorf("at least one address must be specified")
-	}
-	if len(filter.EventSigs) == 0 {
-		return pkgerrors.Errorf("at least one event must be specified")
-	}
-
-	for _, eventSig := range filter.EventSigs {
-		if eventSig == [common.HashLength]byte{} {
-			return pkgerrors.Errorf("empty event sig")
-		}
-	}
-	for _, addr := range filter.Addresses {
-		if addr == [common.AddressLength]byte{} {
-			return pkgerrors.Errorf("empty address")
-		}
+	if err := validateFilter(filter); err != nil {
+		return err
 	}
 
 	lp.filterMu.Lock()
@@ -323,6 +312,101 @@ func (lp *logPoller) UnregisterFilter(ctx context.Context, name string) error {
 	return nil
 }
 
+// RegisterFilters registers all the given filters atomically: either all of them are persisted and added to the
+// log poller's log filter query, or none of them are. See RegisterFilter for the semantics of a single filter.
+// Filters already fully contained in a registered filter of the same name are skipped.
+func (lp *logPoller) RegisterFilters(ctx context.Context, filters []Filter) error {
+	names := make(map[string]struct{}, len(filters))
+	for _, filter := range filters {
+		if err := validateFilter(filter); err != nil {
+			return pkgerrors.Wrapf(err, "invalid filter %q", filter.Name)
+		}
+		if _, ok := names[filter.Name]; ok {
+			return pkgerrors.Errorf("duplicate filter name %q", filter.Name)
+		}
+		names[filter.Name] = struct{}{}
+	}
+
+	lp.filterMu.Lock()
+	defer lp.filterMu.Unlock()
+
+	var toInsert []Filter
+	for _, filter := range filters {
+		if existingFilter, ok := lp.filters[filter.Name]; ok {
+			if existingFilter.Contains(&filter) {
+				lp.lggr.Warnw("Filter already present, no-op", "name", filter.Name, "filter", filter)
+				continue
+			}
+			lp.lggr.Warnw("Updating existing filter", "name", filter.Name, "filter", filter)
+		}
+		toInsert = append(toInsert, filter)
+	}
+	if len(toInsert) == 0 {
+		return nil
+	}
+
+	if err := lp.orm.InsertFilters(ctx, toInsert); err != nil {
+		return pkgerrors.Wrap(err, "error inserting filters")
+	}
+	for _, filter := range toInsert {
+		lp.filters[filter.Name] = filter
+		if filter.MaxLogsKept > 0 {
+			lp.countBasedLogPruningActive.Store(true)
+		}
+	}
+	lp.filterDirty = true
+	return nil
+}
+
+// UnregisterFilters removes all the named filters atomically: either all of them are removed, or none of them are.
+// Names which are not registered are logged and skipped, as in UnregisterFilter.
+func (lp *logPoller) UnregisterFilters(ctx context.Context, names []string) error {
+	lp.filterMu.Lock()
+	defer lp.filterMu.Unlock()
+
+	var toDelete []string
+	for _, name := range names {
+		if _, ok := lp.filters[name]; !ok {
+			lp.lggr.Warnw("Filter not found", "name", name)
+			continue
+		}
+		toDelete = append(toDelete, name)
+	}
+	if len(toDelete) == 0 {
+		return nil
+	}
+
+	if err := lp.orm.DeleteFilters(ctx, toDelete); err != nil {
+		return pkgerrors.Wrap(err, "error deleting filters")
+	}
+	for _, name := range toDelete {
+		delete(lp.filters, name)
+	}
+	lp.filterDirty = true
+	return nil
+}
+
+func validateFilter(filter Filter) error {
+	if len(filter.Addresses) == 0 {
+		return pkgerrors.Errorf("at least one address must be specified")
+	}
+	if len(filter.EventSigs) == 0 {
+		return pkgerrors.Errorf("at least one event must be specified")
+	}
+
+	for _, eventSig := range filter.EventSigs {
+		if eventSig == [common.HashLength]byte{} {
+			return pkgerrors.Errorf("empty event sig")
+		}
+	}
+	for _, addr := range filter.Addresses {
+		if addr == [common.AddressLength]byte{} {
+			return pkgerrors.Errorf("empty address")
+		}
+	}
+	return nil
+}
+
 // HasFilter returns true if the log poller has an active filter with the given name.
 func (lp *logPoller) HasFilter(name string) bool {
 	lp.filterMu.RLock()
@@ -332,6 +416,18 @@ func (lp *logPoller) HasFilter(name string) bool {
 	return ok
 }
 
+// HasFilters reports, for each of the given names, whether the log poller has an active filter with that name.
+func (lp *logPoller) HasFilters(names []string) map[string]bool {
+	lp.filterMu.RLock()
+	defer lp.filterMu.RUnlock()
+
+	present := make(map[string]bool, len(names))
+	for _, name := range names {
+		_, present[name] = lp.filters[name]
+	}
+	return present
+}
+
 // GetFilters returns a deep copy of the filters map.
 func (lp *logPoller) GetFilters() map[string]Filter {
 	lp.filterMu.RLock()
@@ -1278,6 +1278,17 @@ func TestLogPoller_LoadFilters(t *testing.T) {
 		assert.False(t, th.LogPoller.HasFilter("fourth Filter"))
 	})
 
+	t.Run("HasFilters", func(t *testing.T) {
+		present := th.LogPoller.HasFilters([]string{"first Filter", "fourth Filter", "third Filter", "fifth Filter"})
+		assert.Equal(t, map[string]bool{
+			"first Filter":  true,
+			"fourth Filter": false,
+			"third Filter":  true,
+			"fifth Filter":  false,
+		}, present)
+		assert.Empty(t, th.LogPoller.HasFilters(nil))
+	})
+
 	t.Run("GetFilters", func(t *testing.T) {
 		filters := th.LogPoller.GetFilters()
 		assert.Equal(t, 3, len(filters))
@@ -1293,6 +1304,70 @@ func TestLogPoller_LoadFilters(t *testing.T) {
 	})
 }
 
+func TestLogPoller_RegisterFilters(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+	lggr := logger.Test(t)
+	a1 := common.HexToAddress("0x2ab9a2dc53736b361b72d900cdf9f78f9406fbbb")
+	a2 := common.HexToAddress("0x2ab9a2dc53736b361b72d900cdf9f78f9406fbbc")
+
+	// We need full db here, because we want to test transaction rollbacks.
+	_, db := heavyweight.FullTestDBV2(t, nil)
+	orm := logpoller.NewORM(testutils.NewRandomEVMChainID(), db, lggr)
+	lp := logpoller.NewLogPoller(orm, nil, lggr, nil, logpoller.Opts{
+		PollPeriod:               time.Hour,
+		BackfillBatchSize:        1,
+		RpcBatchSize:             2,
+		KeepFinalizedBlocksDepth: 1000,
+	})
+
+	filter1 := logpoller.Filter{Name: "first Filter", EventSigs: []common.Hash{EmitterABI.Events["Log1"].ID}, Addresses: []common.Address{a1}}
+	filter2 := logpoller.Filter{Name: "second Filter", EventSigs: []common.Hash{EmitterABI.Events["Log2"].ID}, Addresses: []common.Address{a2}}
+	// Passes the in-memory validation, but violates the non-empty name constraint of the filters table.
+	unnamed := logpoller.Filter{EventSigs: []common.Hash{EmitterABI.Events["Log1"].ID}, Addresses: []common.Address{a2}}
+
+	assertNoFilters := func(t *testing.T) {
+		assert.Empty(t, lp.GetFilters())
+		filters, err := orm.LoadFilters(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, filters)
+		assert.Equal(t, []common.Address{{}}, lp.Filter(nil, nil, nil).Addresses)
+	}
+
+	t.Run("invalid filters are rejected", func(t *testing.T) {
+		err := lp.RegisterFilters(ctx, []logpoller.Filter{filter1, {Name: "no address", EventSigs: filter1.EventSigs}})
+		require.ErrorContains(t, err, `invalid filter "no address"`)
+		err = lp.RegisterFilters(ctx, []logpoller.Filter{filter1, filter1})
+		require.ErrorContains(t, err, `duplicate filter name "first Filter"`)
+		assertNoFilters(t)
+	})
+
+	t.Run("partial failure rolls back the whole batch", func(t *testing.T) {
+		err := lp.RegisterFilters(ctx, []logpoller.Filter{filter1, filter2, unnamed})
+		require.ErrorContains(t, err, "error inserting filters")
+		assert.False(t, lp.HasFilter(filter1.Name))
+		assert.False(t, lp.HasFilter(filter2.Name))
+		assertNoFilters(t)
+	})
+
+	t.Run("register and unregister batch", func(t *testing.T) {
+		require.NoError(t, lp.RegisterFilters(ctx, []logpoller.Filter{filter1, filter2}))
+		assert.True(t, lp.HasFilter(filter1.Name))
+		assert.True(t, lp.HasFilter(filter2.Name))
+		assert.Equal(t, []common.Address{a1, a2}, lp.Filter(nil, nil, nil).Addresses)
+		filters, err := orm.LoadFilters(ctx)
+		require.NoError(t, err)
+		assert.Len(t, filters, 2)
+
+		// Registering already present filters is a no-op.
+		require.NoError(t, lp.RegisterFilters(ctx, []logpoller.Filter{filter1, filter2}))
+
+		// Unknown names are skipped.
+		require.NoError(t, lp.UnregisterFilters(ctx, []string{filter1.Name, "fourth Filter", filter2.Name}))
+		assertNoFilters(t)
+	})
+}
+
 func TestLogPoller_GetBlocks_Range(t *testing.T) {
 	t.Parallel()
 
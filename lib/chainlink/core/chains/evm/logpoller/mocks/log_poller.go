@@ -394,6 +394,54 @@ func (_c *LogPoller_HasFilter_Call) RunAndReturn(run func(string) bool) *LogPoll
 	return _c
 }
 
+// HasFilters provides a mock function with given fields: names
+func (_m *LogPoller) HasFilters(names []string) map[string]bool {
+	ret := _m.Called(names)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasFilters")
+	}
+
+	var r0 map[string]bool
+	if rf, ok := ret.Get(0).(func([]string) map[string]bool); ok {
+		r0 = rf(names)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]bool)
+		}
+	}
+
+	return r0
+}
+
+// LogPoller_HasFilters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasFilters'
+type LogPoller_HasFilters_Call struct {
+	*mock.Call
+}
+
+// HasFilters is a helper method to define mock.On call
+//   - names []string
+func (_e *LogPoller_Expecter) HasFilters(names interface{}) *LogPoller_HasFilters_Call {
+	return &LogPoller_HasFilters_Call{Call: _e.mock.On("HasFilters", names)}
+}
+
+func (_c *LogPoller_HasFilters_Call) Run(run func(names []string)) *LogPoller_HasFilters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string))
+	})
+	return _c
+}
+
+func (_c *LogPoller_HasFilters_Call) Return(_a0 map[string]bool) *LogPoller_HasFilters_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LogPoller_HasFilters_Call) RunAndReturn(run func([]string) map[string]bool) *LogPoller_HasFilters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // HealthReport provides a mock function with given fields:
 func (_m *LogPoller) HealthReport() map[string]error {
 	ret := _m.Called()
@@ -1683,6 +1731,53 @@ func (_c *LogPoller_RegisterFilter_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// RegisterFilters provides a mock function with given fields: ctx, filters
+func (_m *LogPoller) RegisterFilters(ctx context.Context, filters []logpoller.Filter) error {
+	ret := _m.Called(ctx, filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegisterFilters")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []logpoller.Filter) error); ok {
+		r0 = rf(ctx, filters)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LogPoller_RegisterFilters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegisterFilters'
+type LogPoller_RegisterFilters_Call struct {
+	*mock.Call
+}
+
+// RegisterFilters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filters []logpoller.Filter
+func (_e *LogPoller_Expecter) RegisterFilters(ctx interface{}, filters interface{}) *LogPoller_RegisterFilters_Call {
+	return &LogPoller_RegisterFilters_Call{Call: _e.mock.On("RegisterFilters", ctx, filters)}
+}
+
+func (_c *LogPoller_RegisterFilters_Call) Run(run func(ctx context.Context, filters []logpoller.Filter)) *LogPoller_RegisterFilters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]logpoller.Filter))
+	})
+	return _c
+}
+
+func (_c *LogPoller_RegisterFilters_Call) Return(_a0 error) *LogPoller_RegisterFilters_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LogPoller_RegisterFilters_Call) RunAndReturn(run func(context.Context, []logpoller.Filter) error) *LogPoller_RegisterFilters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Replay provides a mock function with given fields: ctx, fromBlock
 func (_m *LogPoller) Replay(ctx context.Context, fromBlock int64) error {
 	ret := _m.Called(ctx, fromBlock)
@@ -1856,6 +1951,53 @@ func (_c *LogPoller_UnregisterFilter_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// UnregisterFilters provides a mock function with given fields: ctx, names
+func (_m *LogPoller) UnregisterFilters(ctx context.Context, names []string) error {
+	ret := _m.Called(ctx, names)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnregisterFilters")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, names)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LogPoller_UnregisterFilters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnregisterFilters'
+type LogPoller_UnregisterFilters_Call struct {
+	*mock.Call
+}
+
+// UnregisterFilters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - names []string
+func (_e *LogPoller_Expecter) UnregisterFilters(ctx interface{}, names interface{}) *LogPoller_UnregisterFilters_Call {
+	return &LogPoller_UnregisterFilters_Call{Call: _e.mock.On("UnregisterFilters", ctx, names)}
+}
+
+func (_c *LogPoller_UnregisterFilters_Call) Run(run func(ctx context.Context, names []string)) *LogPoller_UnregisterFilters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *LogPoller_UnregisterFilters_Call) Return(_a0 error) *LogPoller_UnregisterFilters_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LogPoller_UnregisterFilters_Call) RunAndReturn(run func(context.Context, []string) error) *LogPoller_UnregisterFilters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewLogPoller creates a new instance of LogPoller. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewLogPoller(t interface {
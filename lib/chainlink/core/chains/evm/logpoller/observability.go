@@ -112,6 +112,12 @@ func (o *ObservedORM) InsertFilter(ctx context.Context, filter Filter) error {
 	})
 }
 
+func (o *ObservedORM) InsertFilters(ctx context.Context, filters []Filter) error {
+	return withObservedExec(o, "InsertFilters", create, func() error {
+		return o.ORM.InsertFilters(ctx, filters)
+	})
+}
+
 func (o *ObservedORM) LoadFilters(ctx context.Context) (map[string]Filter, error) {
 	return withObservedQuery(o, "LoadFilters", func() (map[string]Filter, error) {
 		return o.ORM.LoadFilters(ctx)
@@ -124,6 +130,12 @@ func (o *ObservedORM) DeleteFilter(ctx context.Context, name string) error {
 	})
 }
 
+func (o *ObservedORM) DeleteFilters(ctx context.Context, names []string) error {
+	return withObservedExec(o, "DeleteFilters", del, func() error {
+		return o.ORM.DeleteFilters(ctx, names)
+	})
+}
+
 func (o *ObservedORM) DeleteBlocksBefore(ctx context.Context, end int64, limit int64) (int64, error) {
 	return withObservedExecAndRowsAffected(o, "DeleteBlocksBefore", del, func() (int64, error) {
 		return o.ORM.DeleteBlocksBefore(ctx, end, limit)
@@ -30,9 +30,11 @@ type ORM interface {
 	InsertLogs(ctx context.Context, logs []Log) error
 	InsertLogsWithBlock(ctx context.Context, logs []Log, block LogPollerBlock) error
 	InsertFilter(ctx context.Context, filter Filter) error
+	InsertFilters(ctx context.Context, filters []Filter) error
 
 	LoadFilters(ctx context.Context) (map[string]Filter, error)
 	DeleteFilter(ctx context.Context, name string) error
+	DeleteFilters(ctx context.Context, names []string) error
 
 	DeleteLogsByRowID(ctx context.Context, rowIDs []uint64) (int64, error)
 	InsertBlock(ctx context.Context, blockHash common.Hash, blockNumber int64, blockTimestamp time.Time, finalizedBlock int64) error
@@ -159,6 +161,18 @@ func (o *DSORM) InsertFilter(ctx context.Context, filter Filter) (err error) {
 	return err
 }
 
+// InsertFilters inserts all the filters in a single transaction, none of them are inserted if any insert fails.
+func (o *DSORM) InsertFilters(ctx context.Context, filters []Filter) error {
+	return o.Transact(ctx, func(orm *DSORM) error {
+		for _, filter := range filters {
+			if err := orm.InsertFilter(ctx, filter); err != nil {
+				return fmt.Errorf("failed to insert filter %q: %w", filter.Name, err)
+			}
+		}
+		return nil
+	})
+}
+
 // DeleteFilter removes all events,address pairs associated with the Filter
 func (o *DSORM) DeleteFilter(ctx context.Context, name string) error {
 	_, err := o.ds.ExecContext(ctx,
@@ -167,6 +181,18 @@ func (o *DSORM) DeleteFilter(ctx context.Context, name string) error {
 	return err
 }
 
+// DeleteFilters removes all the named filters in a single transaction, none of them are removed if any delete fails.
+func (o *DSORM) DeleteFilters(ctx context.Context, names []string) error {
+	return o.Transact(ctx, func(orm *DSORM) error {
+		for _, name := range names {
+			if err := orm.DeleteFilter(ctx, name); err != nil {
+				return fmt.Errorf("failed to delete filter %q: %w", name, err)
+			}
+		}
+		return nil
+	})
+}
+
 // LoadFilters returns all filters for this chain
 func (o *DSORM) LoadFilters(ctx context.Context) (map[string]Filter, error) {
 	query := `SELECT name,
@@ -17,10 +17,18 @@ type MercuryTLS interface {
 	CertFile() string
 }
 
+type MercuryTransmitterBackoff interface {
+	Min() time.Duration
+	Max() time.Duration
+}
+
 type MercuryTransmitter interface {
 	TransmitQueueMaxSize() uint32
 	TransmitTimeout() commonconfig.Duration
 	TransmitConcurrency() uint32
+	TransmitTimeoutFloor() float64
+	TransmitBackoff() MercuryTransmitterBackoff
+	DeleteBackoff() MercuryTransmitterBackoff
 }
 
 type Mercury interface {
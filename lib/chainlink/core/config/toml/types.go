@@ -1331,6 +1331,10 @@ type MercuryTransmitter struct {
 	TransmitQueueMaxSize *uint32
 	TransmitTimeout      *commonconfig.Duration
 	TransmitConcurrency  *uint32
+	TransmitTimeoutFloor *float64
+
+	TransmitBackoff MercuryTransmitterBackoff `toml:",omitempty"`
+	DeleteBackoff   MercuryTransmitterBackoff `toml:",omitempty"`
 }
 
 func (m *MercuryTransmitter) setFrom(f *MercuryTransmitter) {
@@ -1343,6 +1347,46 @@ func (m *MercuryTransmitter) setFrom(f *MercuryTransmitter) {
 	if v := f.TransmitConcurrency; v != nil {
 		m.TransmitConcurrency = v
 	}
+	if v := f.TransmitTimeoutFloor; v != nil {
+		m.TransmitTimeoutFloor = v
+	}
+	m.TransmitBackoff.setFrom(&f.TransmitBackoff)
+	m.DeleteBackoff.setFrom(&f.DeleteBackoff)
+}
+
+func (m *MercuryTransmitter) ValidateConfig() (err error) {
+	if m.TransmitTimeoutFloor != nil {
+		if f := *m.TransmitTimeoutFloor; f <= 0 || f > 1 {
+			err = multierr.Append(err, configutils.ErrInvalid{Name: "TransmitTimeoutFloor", Value: f, Msg: "must be greater than 0 and at most 1"})
+		}
+	}
+	return
+}
+
+// MercuryTransmitterBackoff configures the exponential backoff between retries
+// of a mercury transmitter queue loop
+type MercuryTransmitterBackoff struct {
+	Min *commonconfig.Duration
+	Max *commonconfig.Duration
+}
+
+func (b *MercuryTransmitterBackoff) setFrom(f *MercuryTransmitterBackoff) {
+	if v := f.Min; v != nil {
+		b.Min = v
+	}
+	if v := f.Max; v != nil {
+		b.Max = v
+	}
+}
+
+func (b *MercuryTransmitterBackoff) ValidateConfig() (err error) {
+	if b.Min != nil && b.Min.Duration() <= 0 {
+		err = multierr.Append(err, configutils.ErrInvalid{Name: "Min", Value: b.Min.String(), Msg: "must be greater than 0"})
+	}
+	if b.Min != nil && b.Max != nil && b.Max.Duration() < b.Min.Duration() {
+		err = multierr.Append(err, configutils.ErrInvalid{Name: "Max", Value: b.Max.String(), Msg: "must be greater than or equal to Min"})
+	}
+	return
 }
 
 type Mercury struct {
@@ -5,6 +5,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -577,5 +578,112 @@ func TestMercuryTLS_ValidateTLSCertPath(t *testing.T) {
 	}
 }
 
+func TestMercuryTransmitter_ValidateTransmitTimeoutFloor(t *testing.T) {
+	tests := []struct {
+		name    string
+		floor   *float64
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:  "valid upper bound",
+			floor: ptr(1.0),
+		},
+		{
+			name:  "valid value",
+			floor: ptr(0.9),
+		},
+		{
+			name:  "nil TransmitTimeoutFloor",
+			floor: nil,
+		},
+		{
+			name:    "invalid zero value",
+			floor:   ptr(0.0),
+			wantErr: true,
+			errMsg:  configutils.ErrInvalid{Name: "TransmitTimeoutFloor", Value: 0.0, Msg: "must be greater than 0 and at most 1"}.Error(),
+		},
+		{
+			name:    "invalid value greater than 1",
+			floor:   ptr(1.1),
+			wantErr: true,
+			errMsg:  configutils.ErrInvalid{Name: "TransmitTimeoutFloor", Value: 1.1, Msg: "must be greater than 0 and at most 1"}.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := MercuryTransmitter{
+				TransmitTimeoutFloor: tt.floor,
+			}
+
+			err := m.ValidateConfig()
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errMsg, err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMercuryTransmitterBackoff_ValidateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		min     *commonconfig.Duration
+		max     *commonconfig.Duration
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name: "valid",
+			min:  commonconfig.MustNewDuration(5 * time.Millisecond),
+			max:  commonconfig.MustNewDuration(time.Second),
+		},
+		{
+			name: "valid equal bounds",
+			min:  commonconfig.MustNewDuration(time.Second),
+			max:  commonconfig.MustNewDuration(time.Second),
+		},
+		{
+			name: "nil bounds",
+		},
+		{
+			name:    "invalid zero Min",
+			min:     commonconfig.MustNewDuration(0),
+			max:     commonconfig.MustNewDuration(time.Second),
+			wantErr: true,
+			errMsg:  configutils.ErrInvalid{Name: "Min", Value: "0s", Msg: "must be greater than 0"}.Error(),
+		},
+		{
+			name:    "invalid Max below Min",
+			min:     commonconfig.MustNewDuration(time.Second),
+			max:     commonconfig.MustNewDuration(time.Millisecond),
+			wantErr: true,
+			errMsg:  configutils.ErrInvalid{Name: "Max", Value: "1ms", Msg: "must be greater than or equal to Min"}.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := MercuryTransmitterBackoff{
+				Min: tt.min,
+				Max: tt.max,
+			}
+
+			err := b.ValidateConfig()
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tt.errMsg, err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 // ptr is a utility function for converting a value to a pointer to the value.
 func ptr[T any](t T) *T { return &t }
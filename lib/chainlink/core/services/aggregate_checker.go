@@ -0,0 +1,188 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+)
+
+var (
+	_ Checker                 = (*AggregateChecker)(nil)
+	_ services.HealthReporter = (*AggregateChecker)(nil)
+)
+
+// AggregateChecker rolls up a set of named child Checkers, and any services registered with it directly,
+// into a single health status.
+// Child reports are namespaced by the child name, so the report of service "Foo" on child "EVM" is keyed "EVM.Foo".
+// The aggregate is only healthy (or ready) if all of its children and registered services are.
+type AggregateChecker struct {
+	name     string
+	children map[string]Checker
+
+	servicesMu sync.RWMutex
+	services   map[string]services.HealthReporter
+}
+
+// NewAggregateChecker returns an AggregateChecker reporting under name and rolling up the given children.
+func NewAggregateChecker(name string, children map[string]Checker) *AggregateChecker {
+	return &AggregateChecker{
+		name:     name,
+		children: maps.Clone(children),
+		services: make(map[string]services.HealthReporter),
+	}
+}
+
+// Register a service to be checked directly by the aggregate.
+func (a *AggregateChecker) Register(service services.HealthReporter) error {
+	name := service.Name()
+	if name == "" {
+		return fmt.Errorf("misconfigured check %#v for %T", name, service)
+	}
+	a.servicesMu.Lock()
+	defer a.servicesMu.Unlock()
+	if _, ok := a.services[name]; ok {
+		return fmt.Errorf("duplicate name %q: service names must be unique", name)
+	}
+	a.services[name] = service
+	return nil
+}
+
+// Unregister a service previously registered with Register.
+func (a *AggregateChecker) Unregister(name string) error {
+	if name == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+	a.servicesMu.Lock()
+	defer a.servicesMu.Unlock()
+	delete(a.services, name)
+	return nil
+}
+
+// IsReady returns the rolled up readiness of the children and the registered services.
+func (a *AggregateChecker) IsReady() (ready bool, errs map[string]error) {
+	errs = make(map[string]error)
+	ready = true
+	for _, childName := range a.childNames() {
+		childReady, childErrs := a.children[childName].IsReady()
+		ready = a.mergeChild(errs, childName, childReady, childErrs) && ready
+	}
+	for name, s := range a.registered() {
+		err := s.Ready()
+		errs[name] = err
+		ready = ready && err == nil
+	}
+	return ready, errs
+}
+
+// IsHealthy returns the rolled up health of the children and the registered services.
+func (a *AggregateChecker) IsHealthy() (healthy bool, errs map[string]error) {
+	errs = make(map[string]error)
+	healthy = true
+	for _, childName := range a.childNames() {
+		childHealthy, childErrs := a.children[childName].IsHealthy()
+		healthy = a.mergeChild(errs, childName, childHealthy, childErrs) && healthy
+	}
+	for _, s := range a.registered() {
+		for name, err := range s.HealthReport() {
+			errs[name] = err
+			healthy = healthy && err == nil
+		}
+	}
+	return healthy, errs
+}
+
+// Start starts all the children, closing the ones already started if any of them fails.
+func (a *AggregateChecker) Start() error {
+	var started []Checker
+	for _, childName := range a.childNames() {
+		child := a.children[childName]
+		if err := child.Start(); err != nil {
+			for _, s := range started {
+				err = errors.Join(err, s.Close())
+			}
+			return fmt.Errorf("failed to start checker %s: %w", childName, err)
+		}
+		started = append(started, child)
+	}
+	return nil
+}
+
+// Close closes all the children.
+func (a *AggregateChecker) Close() error {
+	var errs error
+	for _, childName := range a.childNames() {
+		if err := a.children[childName].Close(); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to close checker %s: %w", childName, err))
+		}
+	}
+	return errs
+}
+
+func (a *AggregateChecker) Name() string { return a.name }
+
+// Ready returns an error naming the children and services that are not ready.
+func (a *AggregateChecker) Ready() error {
+	ready, errs := a.IsReady()
+	if ready {
+		return nil
+	}
+	_, err := FailingChecks(errs)
+	return fmt.Errorf("%s is not ready: %w", a.name, err)
+}
+
+// HealthReport returns the merged health report of the children and the registered services,
+// along with the rolled up status of the aggregate under its own name.
+func (a *AggregateChecker) HealthReport() map[string]error {
+	healthy, errs := a.IsHealthy()
+	report := map[string]error{a.name: nil}
+	if !healthy {
+		_, err := FailingChecks(errs)
+		report[a.name] = fmt.Errorf("%s is unhealthy: %w", a.name, err)
+	}
+	for name, err := range errs {
+		report[a.name+"."+name] = err
+	}
+	return report
+}
+
+// mergeChild copies the child errors into errs, namespaced by the child name.
+// A child reporting a failed status without any error is recorded under its own name,
+// so that the failure is not lost when merging.
+func (a *AggregateChecker) mergeChild(errs map[string]error, childName string, ok bool, childErrs map[string]error) bool {
+	failed := false
+	for name, err := range childErrs {
+		errs[childName+"."+name] = err
+		failed = failed || err != nil
+	}
+	if !ok && !failed {
+		errs[childName] = fmt.Errorf("checker %s reported a failure without any errors", childName)
+	}
+	return ok && !failed
+}
+
+func (a *AggregateChecker) childNames() []string {
+	return slices.Sorted(maps.Keys(a.children))
+}
+
+func (a *AggregateChecker) registered() map[string]services.HealthReporter {
+	a.servicesMu.RLock()
+	defer a.servicesMu.RUnlock()
+	return maps.Clone(a.services)
+}
+
+// FailingChecks returns the names of the failing checks of a report returned by IsReady or IsHealthy in sorted order,
+// along with their errors joined in the same order, so that log lines and health endpoints do not depend on the
+// iteration order of the report. The first name is the first failing check, and the error is nil if none are failing.
+func FailingChecks(report map[string]error) (failing []string, err error) {
+	for _, name := range slices.Sorted(maps.Keys(report)) {
+		if report[name] != nil {
+			failing = append(failing, name)
+			err = errors.Join(err, fmt.Errorf("%s: %w", name, report[name]))
+		}
+	}
+	return failing, err
+}
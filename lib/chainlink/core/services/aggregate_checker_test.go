@@ -0,0 +1,139 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services"
+	"github.com/smartcontractkit/chainlink/v2/core/services/mocks"
+)
+
+type fakeReporter struct {
+	name   string
+	ready  error
+	health map[string]error
+}
+
+func (f fakeReporter) Name() string                   { return f.name }
+func (f fakeReporter) Ready() error                   { return f.ready }
+func (f fakeReporter) HealthReport() map[string]error { return f.health }
+
+func TestAggregateChecker_IsHealthy(t *testing.T) {
+	evm := mocks.NewChecker(t)
+	p2p := mocks.NewChecker(t)
+	agg := services.NewAggregateChecker("Node", map[string]services.Checker{"EVM": evm, "P2P": p2p})
+
+	evm.EXPECT().IsHealthy().Return(true, map[string]error{"Txm": nil}).Once()
+	p2p.EXPECT().IsHealthy().Return(true, map[string]error{"Peer": nil}).Once()
+	healthy, errs := agg.IsHealthy()
+	assert.True(t, healthy)
+	assert.Equal(t, map[string]error{"EVM.Txm": nil, "P2P.Peer": nil}, errs)
+
+	evm.EXPECT().IsHealthy().Return(true, map[string]error{"Txm": nil}).Once()
+	p2p.EXPECT().IsHealthy().Return(true, map[string]error{"Peer": nil}).Once()
+	assert.Equal(t, map[string]error{"Node": nil, "Node.EVM.Txm": nil, "Node.P2P.Peer": nil}, agg.HealthReport())
+
+	// one child becoming unhealthy flips the aggregate
+	txmErr := errors.New("txm stuck")
+	evm.EXPECT().IsHealthy().Return(false, map[string]error{"Txm": txmErr}).Once()
+	p2p.EXPECT().IsHealthy().Return(true, map[string]error{"Peer": nil}).Once()
+	healthy, errs = agg.IsHealthy()
+	assert.False(t, healthy)
+	assert.Equal(t, map[string]error{"EVM.Txm": txmErr, "P2P.Peer": nil}, errs)
+
+	evm.EXPECT().IsHealthy().Return(false, map[string]error{"Txm": txmErr}).Once()
+	p2p.EXPECT().IsHealthy().Return(true, map[string]error{"Peer": nil}).Once()
+	report := agg.HealthReport()
+	require.ErrorIs(t, report["Node"], txmErr)
+	assert.ErrorContains(t, report["Node"], "EVM.Txm: txm stuck")
+	assert.Equal(t, txmErr, report["Node.EVM.Txm"])
+	assert.NoError(t, report["Node.P2P.Peer"])
+
+	// a child reporting unhealthy without errors is still surfaced
+	evm.EXPECT().IsHealthy().Return(true, map[string]error{}).Once()
+	p2p.EXPECT().IsHealthy().Return(false, map[string]error{}).Once()
+	healthy, errs = agg.IsHealthy()
+	assert.False(t, healthy)
+	assert.ErrorContains(t, errs["P2P"], "checker P2P reported a failure without any errors")
+}
+
+func TestAggregateChecker_Register(t *testing.T) {
+	child := mocks.NewChecker(t)
+	child.EXPECT().IsHealthy().Return(true, map[string]error{"Txm": nil})
+	child.EXPECT().IsReady().Return(true, map[string]error{"Txm": nil})
+	agg := services.NewAggregateChecker("Node", map[string]services.Checker{"EVM": child})
+
+	reporter := fakeReporter{name: "Feeds", health: map[string]error{"Feeds": nil}}
+	require.NoError(t, agg.Register(reporter))
+	require.ErrorContains(t, agg.Register(reporter), `duplicate name "Feeds"`)
+	require.Error(t, agg.Register(fakeReporter{}))
+
+	healthy, errs := agg.IsHealthy()
+	assert.True(t, healthy)
+	assert.Equal(t, map[string]error{"EVM.Txm": nil, "Feeds": nil}, errs)
+	require.NoError(t, agg.Ready())
+
+	notReady := errors.New("not started")
+	require.NoError(t, agg.Unregister("Feeds"))
+	require.NoError(t, agg.Register(fakeReporter{name: "Feeds", ready: notReady, health: map[string]error{"Feeds": notReady}}))
+	healthy, _ = agg.IsHealthy()
+	assert.False(t, healthy)
+	ready, errs := agg.IsReady()
+	assert.False(t, ready)
+	assert.Equal(t, notReady, errs["Feeds"])
+	require.ErrorIs(t, agg.Ready(), notReady)
+
+	require.NoError(t, agg.Unregister("Feeds"))
+	healthy, _ = agg.IsHealthy()
+	assert.True(t, healthy)
+}
+
+func TestAggregateChecker_StartClose(t *testing.T) {
+	a := mocks.NewChecker(t)
+	b := mocks.NewChecker(t)
+	agg := services.NewAggregateChecker("Node", map[string]services.Checker{"A": a, "B": b})
+
+	a.EXPECT().Start().Return(nil).Once()
+	b.EXPECT().Start().Return(nil).Once()
+	require.NoError(t, agg.Start())
+
+	closeErr := errors.New("close failed")
+	a.EXPECT().Close().Return(nil).Once()
+	b.EXPECT().Close().Return(closeErr).Once()
+	require.ErrorIs(t, agg.Close(), closeErr)
+
+	// children already started are closed if a later one fails to start
+	startErr := errors.New("start failed")
+	a.EXPECT().Start().Return(nil).Once()
+	b.EXPECT().Start().Return(startErr).Once()
+	a.EXPECT().Close().Return(nil).Once()
+	err := agg.Start()
+	require.ErrorIs(t, err, startErr)
+	require.ErrorContains(t, err, "failed to start checker B")
+}
+
+func TestFailingChecks(t *testing.T) {
+	errTxm, errPeer := errors.New("txm stuck"), errors.New("no peers")
+	report := map[string]error{
+		"P2P.Peer": errPeer,
+		"EVM.Head": nil,
+		"EVM.Txm":  errTxm,
+		"Feeds":    nil,
+	}
+
+	// the order is the same however the report is iterated
+	for i := 0; i < 20; i++ {
+		failing, err := services.FailingChecks(report)
+		require.Equal(t, []string{"EVM.Txm", "P2P.Peer"}, failing)
+		require.EqualError(t, err, "EVM.Txm: txm stuck\nP2P.Peer: no peers")
+		require.ErrorIs(t, err, errTxm)
+		require.ErrorIs(t, err, errPeer)
+	}
+
+	failing, err := services.FailingChecks(map[string]error{"EVM.Head": nil})
+	require.Empty(t, failing)
+	require.NoError(t, err)
+}
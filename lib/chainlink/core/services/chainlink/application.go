@@ -286,10 +286,6 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 			srvcs = append(srvcs, wfLauncher, registrySyncer)
 
 			if cfg.Capabilities().WorkflowRegistry().Address() != "" {
-				if gatewayConnectorWrapper == nil {
-					return nil, errors.New("unable to create workflow registry syncer without gateway connector")
-				}
-
 				err = keyStore.Workflow().EnsureKey(context.Background())
 				if err != nil {
 					return nil, fmt.Errorf("failed to ensure workflow key: %w", err)
@@ -303,32 +299,46 @@ func NewApplication(opts ApplicationOpts) (Application, error) {
 					return nil, fmt.Errorf("expected 1 key, got %d", len(keys))
 				}
 
-				connector := gatewayConnectorWrapper.GetGatewayConnector()
-				webAPILggr := globalLogger.Named("WebAPITarget")
-
-				webAPIConfig := webapi.ServiceConfig{
-					RateLimiter: common2.RateLimiterConfig{
-						GlobalRPS:      100.0,
-						GlobalBurst:    100,
-						PerSenderRPS:   100.0,
-						PerSenderBurst: 100,
-					},
-				}
+				// fetch workflow artifacts through the gateway when there is one, and
+				// directly otherwise, with the restricted client since workflow owners
+				// choose the URLs
+				var fetcher syncer.Fetcher
+				if gatewayConnectorWrapper != nil {
+					connector := gatewayConnectorWrapper.GetGatewayConnector()
+					webAPILggr := globalLogger.Named("WebAPITarget")
+
+					webAPIConfig := webapi.ServiceConfig{
+						RateLimiter: common2.RateLimiterConfig{
+							GlobalRPS:      100.0,
+							GlobalBurst:    100,
+							PerSenderRPS:   100.0,
+							PerSenderBurst: 100,
+						},
+					}
 
-				outgoingConnectorHandler, err := webapi.NewOutgoingConnectorHandler(connector,
-					webAPIConfig,
-					capabilities2.MethodWebAPITarget, webAPILggr)
-				if err != nil {
-					return nil, fmt.Errorf("could not create outgoing connector handler: %w", err)
+					outgoingConnectorHandler, err := webapi.NewOutgoingConnectorHandler(connector,
+						webAPIConfig,
+						capabilities2.MethodWebAPITarget, webAPILggr)
+					if err != nil {
+						return nil, fmt.Errorf("could not create outgoing connector handler: %w", err)
+					}
+					fetcher = syncer.NewFetcherFunc(globalLogger, outgoingConnectorHandler)
+				} else {
+					fetcher = syncer.NewHTTPFetcher(globalLogger, restrictedHTTPClient, syncer.HTTPFetcherConfig{})
 				}
 
 				eventHandler := syncer.NewEventHandler(globalLogger, syncer.NewWorkflowRegistryDS(opts.DS, globalLogger),
-					syncer.NewFetcherFunc(globalLogger, outgoingConnectorHandler), workflowstore.NewDBStore(opts.DS, globalLogger, clockwork.NewRealClock()), opts.CapabilitiesRegistry,
+					fetcher, workflowstore.NewDBStore(opts.DS, globalLogger, clockwork.NewRealClock()), opts.CapabilitiesRegistry,
 					custmsg.NewLabeler(), clockwork.NewRealClock(), keys[0])
 
-				loader := syncer.NewWorkflowRegistryContractLoader(cfg.Capabilities().WorkflowRegistry().Address(), func(ctx context.Context, bytes []byte) (syncer.ContractReader, error) {
+				loader, err := syncer.NewWorkflowRegistryContractLoader(cfg.Capabilities().WorkflowRegistry().Address(), syncer.MaxWorkflowMetadataPageSize, func(ctx context.Context, bytes []byte) (syncer.ContractReader, error) {
 					return relayer.NewContractReader(ctx, bytes)
-				}, eventHandler)
+				}, eventHandler, syncer.WithLoadProgress(func(loaded int) {
+					globalLogger.Infow("Loading workflows from the workflow registry", "loaded", loaded)
+				}))
+				if err != nil {
+					return nil, fmt.Errorf("could not create workflow registry contract loader: %w", err)
+				}
 
 				wfSyncer := syncer.NewWorkflowRegistry(globalLogger, func(ctx context.Context, bytes []byte) (syncer.ContractReader, error) {
 					return relayer.NewContractReader(ctx, bytes)
@@ -54,6 +54,32 @@ func (m *mercuryTransmitterConfig) TransmitConcurrency() uint32 {
 	return *m.c.TransmitConcurrency
 }
 
+func (m *mercuryTransmitterConfig) TransmitTimeoutFloor() float64 {
+	return *m.c.TransmitTimeoutFloor
+}
+
+func (m *mercuryTransmitterConfig) TransmitBackoff() config.MercuryTransmitterBackoff {
+	return &mercuryTransmitterBackoffConfig{c: m.c.TransmitBackoff}
+}
+
+func (m *mercuryTransmitterConfig) DeleteBackoff() config.MercuryTransmitterBackoff {
+	return &mercuryTransmitterBackoffConfig{c: m.c.DeleteBackoff}
+}
+
+var _ config.MercuryTransmitterBackoff = (*mercuryTransmitterBackoffConfig)(nil)
+
+type mercuryTransmitterBackoffConfig struct {
+	c toml.MercuryTransmitterBackoff
+}
+
+func (m *mercuryTransmitterBackoffConfig) Min() time.Duration {
+	return m.c.Min.Duration()
+}
+
+func (m *mercuryTransmitterBackoffConfig) Max() time.Duration {
+	return m.c.Max.Duration()
+}
+
 type mercuryConfig struct {
 	c toml.Mercury
 	s toml.MercurySecrets
@@ -2,7 +2,9 @@ package chainlink
 
 import (
 	"testing"
+	"time"
 
+	commonconfig "github.com/smartcontractkit/chainlink-common/pkg/config"
 	"github.com/smartcontractkit/chainlink-common/pkg/types"
 
 	"github.com/stretchr/testify/assert"
@@ -48,3 +50,27 @@ func TestMercuryTLS(t *testing.T) {
 
 	assert.Equal(t, certPath, cfg.TLS().CertFile())
 }
+
+func TestMercuryTransmitter(t *testing.T) {
+	floor := 0.75
+	cfg := mercuryConfig{c: toml.Mercury{
+		Transmitter: toml.MercuryTransmitter{
+			TransmitTimeoutFloor: &floor,
+			TransmitBackoff: toml.MercuryTransmitterBackoff{
+				Min: commonconfig.MustNewDuration(10 * time.Millisecond),
+				Max: commonconfig.MustNewDuration(2 * time.Second),
+			},
+			DeleteBackoff: toml.MercuryTransmitterBackoff{
+				Min: commonconfig.MustNewDuration(3 * time.Second),
+				Max: commonconfig.MustNewDuration(4 * time.Minute),
+			},
+		},
+	}}
+
+	tc := cfg.Transmitter()
+	assert.Equal(t, floor, tc.TransmitTimeoutFloor())
+	assert.Equal(t, 10*time.Millisecond, tc.TransmitBackoff().Min())
+	assert.Equal(t, 2*time.Second, tc.TransmitBackoff().Max())
+	assert.Equal(t, 3*time.Second, tc.DeleteBackoff().Min())
+	assert.Equal(t, 4*time.Minute, tc.DeleteBackoff().Max())
+}
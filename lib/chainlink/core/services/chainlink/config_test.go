@@ -844,6 +844,15 @@ func TestConfig_Marshal(t *testing.T) {
 			TransmitQueueMaxSize: ptr(uint32(123)),
 			TransmitTimeout:      commoncfg.MustNewDuration(234 * time.Second),
 			TransmitConcurrency:  ptr(uint32(456)),
+			TransmitTimeoutFloor: ptr(0.75),
+			TransmitBackoff: toml.MercuryTransmitterBackoff{
+				Min: commoncfg.MustNewDuration(10 * time.Millisecond),
+				Max: commoncfg.MustNewDuration(2 * time.Second),
+			},
+			DeleteBackoff: toml.MercuryTransmitterBackoff{
+				Min: commoncfg.MustNewDuration(3 * time.Second),
+				Max: commoncfg.MustNewDuration(4 * time.Minute),
+			},
 		},
 		VerboseLogging: ptr(true),
 	}
@@ -1355,6 +1364,15 @@ CertFile = '/path/to/cert.pem'
 TransmitQueueMaxSize = 123
 TransmitTimeout = '3m54s'
 TransmitConcurrency = 456
+TransmitTimeoutFloor = 0.75
+
+[Mercury.Transmitter.TransmitBackoff]
+Min = '10ms'
+Max = '2s'
+
+[Mercury.Transmitter.DeleteBackoff]
+Min = '3s'
+Max = '4m0s'
 `},
 		{"full", full, fullTOML},
 		{"multi-chain", multiChain, multiChainTOML},
@@ -887,6 +887,7 @@ type WorkflowSpec struct {
 	WorkflowOwner string             `toml:"-" db:"workflow_owner"` // Derived. Do not modify. the owner of the workflow.
 	WorkflowName  string             `toml:"-" db:"workflow_name"`  // Derived. Do not modify. the name of the workflow.
 	Status        WorkflowSpecStatus `db:"status"`
+	DonID         uint32             `toml:"-" db:"don_id"` // the DON the workflow is assigned to in the workflow registry
 	BinaryURL     string             `db:"binary_url"`
 	ConfigURL     string             `db:"config_url"`
 	SecretsID     sql.NullInt64      `db:"secrets_id"`
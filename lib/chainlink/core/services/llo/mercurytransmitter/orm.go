@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"time"
 
 	"github.com/lib/pq"
 
@@ -24,6 +25,26 @@ type ORM interface {
 	Cleanup(ctx context.Context) error
 }
 
+// DeadLetter is a transmission that a mercury server permanently rejected,
+// along with the payload sent and the error returned by the server
+type DeadLetter struct {
+	Transmission
+	Payload   []byte
+	Code      int32
+	Error     string
+	CreatedAt time.Time
+}
+
+// DeadLetterORM may optionally be implemented by an ORM to keep the
+// transmissions rejected with a non-retryable error for later inspection or
+// replay, rather than dropping them
+type DeadLetterORM interface {
+	InsertDeadLetter(ctx context.Context, t *Transmission, payload []byte, code int32, errMsg string) error
+	GetDeadLetters(ctx context.Context, serverURL string) ([]DeadLetter, error)
+}
+
+var _ DeadLetterORM = (*orm)(nil)
+
 type orm struct {
 	ds    sqlutil.DataSource
 	donID uint32
@@ -152,18 +173,9 @@ func (o *orm) Get(ctx context.Context, serverURL string) ([]*Transmission, error
 			return nil, fmt.Errorf("llo orm: failed to scan transmission: %w", err)
 		}
 		transmission.ConfigDigest = ocrtypes.ConfigDigest(digest)
-		if len(signatures) != len(signers) {
-			return nil, errors.New("signatures and signers must have the same length")
-		}
-		for i, sig := range signatures {
-			if signers[i] > math.MaxUint8 {
-				// this is to appease the linter but shouldn't ever happen
-				return nil, fmt.Errorf("signer is too large (got: %d, max: %d)", signers[i], math.MaxUint8)
-			}
-			transmission.Sigs = append(transmission.Sigs, ocrtypes.AttributedOnchainSignature{
-				Signature: sig,
-				Signer:    commontypes.OracleID(signers[i]), //nolint
-			})
+		transmission.Sigs, err = attributedSignatures(signatures, signers)
+		if err != nil {
+			return nil, err
 		}
 
 		transmissions = append(transmissions, &transmission)
@@ -176,7 +188,8 @@ func (o *orm) Get(ctx context.Context, serverURL string) ([]*Transmission, error
 }
 
 // Prune keeps at most maxSize rows for the given job ID,
-// deleting the oldest transactions.
+// deleting the oldest transactions. The dead letters of the server are kept
+// to the same size, deleting the oldest ones.
 func (o *orm) Prune(ctx context.Context, serverURL string, maxSize int) error {
 	// Prune the oldest requests by epoch and round.
 	_, err := o.ds.ExecContext(ctx, `
@@ -193,13 +206,127 @@ func (o *orm) Prune(ctx context.Context, serverURL string, maxSize int) error {
 	if err != nil {
 		return fmt.Errorf("llo orm: failed to prune transmissions: %w", err)
 	}
+
+	_, err = o.ds.ExecContext(ctx, `
+		DELETE FROM llo_mercury_dead_letters
+		WHERE don_id = $1 AND server_url = $2 AND
+		id NOT IN (
+		    SELECT id
+			FROM llo_mercury_dead_letters
+			WHERE don_id = $1 AND server_url = $2
+			ORDER BY created_at DESC, id DESC
+			LIMIT $3
+		)
+	`, o.donID, serverURL, maxSize)
+	if err != nil {
+		return fmt.Errorf("llo orm: failed to prune dead letters: %w", err)
+	}
 	return nil
 }
 
+// Cleanup deletes all transmissions and dead letters of the DON
 func (o *orm) Cleanup(ctx context.Context) error {
-	_, err := o.ds.ExecContext(ctx, `DELETE FROM llo_mercury_transmit_queue WHERE don_id = $1`, o.donID)
+	return sqlutil.TransactDataSource(ctx, o.ds, nil, func(tx sqlutil.DataSource) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM llo_mercury_transmit_queue WHERE don_id = $1`, o.donID)
+		if err != nil {
+			return fmt.Errorf("llo orm: failed to cleanup transmissions: %w", err)
+		}
+		_, err = tx.ExecContext(ctx, `DELETE FROM llo_mercury_dead_letters WHERE don_id = $1`, o.donID)
+		if err != nil {
+			return fmt.Errorf("llo orm: failed to cleanup dead letters: %w", err)
+		}
+		return nil
+	})
+}
+
+// InsertDeadLetter records a transmission that the server rejected with a
+// non-retryable error
+func (o *orm) InsertDeadLetter(ctx context.Context, t *Transmission, payload []byte, code int32, errMsg string) error {
+	if t.SeqNr > math.MaxInt64 {
+		// this is to appease the linter but shouldn't ever happen
+		return fmt.Errorf("seqNr is too large (got: %d, max: %d)", t.SeqNr, math.MaxInt64)
+	}
+	signatures := make(pq.ByteaArray, len(t.Sigs))
+	signers := make(pq.Int32Array, len(t.Sigs))
+	for i, sig := range t.Sigs {
+		signatures[i] = sig.Signature
+		signers[i] = int32(sig.Signer)
+	}
+	h := t.Hash()
+	_, err := o.ds.ExecContext(ctx, `
+	INSERT INTO llo_mercury_dead_letters (don_id, server_url, config_digest, seq_nr, report, lifecycle_stage, report_format, signatures, signers, transmission_hash, payload, error_code, error)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`, o.donID, t.ServerURL, t.ConfigDigest[:], int64(t.SeqNr), t.Report.Report, string(t.Report.Info.LifeCycleStage), uint32(t.Report.Info.ReportFormat), signatures, signers, h[:], payload, code, errMsg) //nolint
 	if err != nil {
-		return fmt.Errorf("llo orm: failed to cleanup transmissions: %w", err)
+		return fmt.Errorf("llo orm: failed to insert dead letter: %w", err)
 	}
 	return nil
 }
+
+// GetDeadLetters returns all dead letters for the given server, most recent first
+func (o *orm) GetDeadLetters(ctx context.Context, serverURL string) ([]DeadLetter, error) {
+	rows, err := o.ds.QueryContext(ctx, `
+		SELECT config_digest, seq_nr, report, lifecycle_stage, report_format, signatures, signers, payload, error_code, error, created_at
+		FROM llo_mercury_dead_letters
+		WHERE don_id = $1 AND server_url = $2
+		ORDER BY created_at DESC, id DESC
+	`, o.donID, serverURL)
+	if err != nil {
+		return nil, fmt.Errorf("llo orm: failed to get dead letters: %w", err)
+	}
+	defer rows.Close()
+
+	var deadLetters []DeadLetter
+	for rows.Next() {
+		dl := DeadLetter{Transmission: Transmission{ServerURL: serverURL}}
+		var digest []byte
+		var signatures pq.ByteaArray
+		var signers pq.Int32Array
+
+		err := rows.Scan(
+			&digest,
+			&dl.SeqNr,
+			&dl.Report.Report,
+			&dl.Report.Info.LifeCycleStage,
+			&dl.Report.Info.ReportFormat,
+			&signatures,
+			&signers,
+			&dl.Payload,
+			&dl.Code,
+			&dl.Error,
+			&dl.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("llo orm: failed to scan dead letter: %w", err)
+		}
+		dl.ConfigDigest = ocrtypes.ConfigDigest(digest)
+		dl.Sigs, err = attributedSignatures(signatures, signers)
+		if err != nil {
+			return nil, err
+		}
+		deadLetters = append(deadLetters, dl)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("llo orm: failed to scan dead letters: %w", err)
+	}
+
+	return deadLetters, nil
+}
+
+func attributedSignatures(signatures pq.ByteaArray, signers pq.Int32Array) ([]ocrtypes.AttributedOnchainSignature, error) {
+	if len(signatures) != len(signers) {
+		return nil, errors.New("signatures and signers must have the same length")
+	}
+	var sigs []ocrtypes.AttributedOnchainSignature
+	for i, sig := range signatures {
+		if signers[i] > math.MaxUint8 {
+			// this is to appease the linter but shouldn't ever happen
+			return nil, fmt.Errorf("signer is too large (got: %d, max: %d)", signers[i], math.MaxUint8)
+		}
+		sigs = append(sigs, ocrtypes.AttributedOnchainSignature{
+			Signature: sig,
+			Signer:    commontypes.OracleID(signers[i]), //nolint
+		})
+	}
+	return sigs, nil
+}
@@ -87,3 +87,50 @@ func TestORM(t *testing.T) {
 		require.Len(t, result, 0)
 	})
 }
+
+func TestORM_DeadLetters(t *testing.T) {
+	ctx := testutils.Context(t)
+	db := pgtest.NewSqlxDB(t)
+
+	donID := uint32(654321)
+	orm := NewORM(db, donID).(DeadLetterORM)
+
+	transmissions := makeSampleTransmissions()[:2]
+	require.NoError(t, orm.InsertDeadLetter(ctx, transmissions[0], []byte{1, 2, 3}, 1, "invalid report"))
+	require.NoError(t, orm.InsertDeadLetter(ctx, transmissions[1], []byte{4, 5, 6}, 3, "stale report"))
+
+	deadLetters, err := orm.GetDeadLetters(ctx, sURL)
+	require.NoError(t, err)
+	require.Len(t, deadLetters, 2)
+	// most recent first
+	assert.Equal(t, *transmissions[1], deadLetters[0].Transmission)
+	assert.Equal(t, []byte{4, 5, 6}, deadLetters[0].Payload)
+	assert.Equal(t, int32(3), deadLetters[0].Code)
+	assert.Equal(t, "stale report", deadLetters[0].Error)
+	assert.False(t, deadLetters[0].CreatedAt.IsZero())
+	assert.Equal(t, *transmissions[0], deadLetters[1].Transmission)
+
+	deadLetters, err = orm.GetDeadLetters(ctx, "other server url")
+	require.NoError(t, err)
+	assert.Empty(t, deadLetters)
+
+	// dead letters are scoped to the DON
+	deadLetters, err = NewORM(db, donID+1).(DeadLetterORM).GetDeadLetters(ctx, sURL)
+	require.NoError(t, err)
+	assert.Empty(t, deadLetters)
+
+	t.Run("Prune", func(t *testing.T) {
+		// the most recent dead letters are kept
+		require.NoError(t, orm.(ORM).Prune(ctx, sURL, 1))
+		deadLetters, err := orm.GetDeadLetters(ctx, sURL)
+		require.NoError(t, err)
+		require.Len(t, deadLetters, 1)
+		assert.Equal(t, *transmissions[1], deadLetters[0].Transmission)
+	})
+	t.Run("Cleanup", func(t *testing.T) {
+		require.NoError(t, orm.(ORM).Cleanup(ctx))
+		deadLetters, err := orm.GetDeadLetters(ctx, sURL)
+		require.NoError(t, err)
+		assert.Empty(t, deadLetters)
+	})
+}
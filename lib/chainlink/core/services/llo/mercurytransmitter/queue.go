@@ -59,9 +59,15 @@ type TransmitQueue interface {
 	services.Service
 
 	BlockingPop() (t *Transmission)
-	Push(t *Transmission) (ok bool)
+	// Push adds a transmission to the queue. Retried transmissions are
+	// de-prioritized relative to fresh transmissions with the same seqNr so
+	// that a failing report cannot starve newer ones.
+	Push(t *Transmission, retry bool) (ok bool)
 	Init(ts []*Transmission)
 	IsEmpty() bool
+	// Transmissions returns a snapshot of the transmissions currently in the
+	// queue, without removing them
+	Transmissions() []*Transmission
 }
 
 // maxlen controls how many items will be stored in the queue
@@ -83,12 +89,15 @@ func NewTransmitQueue(lggr logger.Logger, serverURL string, maxlen int, asyncDel
 }
 
 func (tq *transmitQueue) Init(ts []*Transmission) {
-	pq := priorityQueue(ts)
+	pq := make(priorityQueue, len(ts))
+	for i, t := range ts {
+		pq[i] = &queuedTransmission{t, false}
+	}
 	heap.Init(&pq) // ensure the heap is ordered
 	tq.pq = &pq
 }
 
-func (tq *transmitQueue) Push(t *Transmission) (ok bool) {
+func (tq *transmitQueue) Push(t *Transmission, retry bool) (ok bool) {
 	tq.cond.L.Lock()
 	defer tq.cond.L.Unlock()
 
@@ -100,12 +109,12 @@ func (tq *transmitQueue) Push(t *Transmission) (ok bool) {
 		// evict oldest entry to make room
 		removed := heap.PopMax(tq.pq)
 		tq.lggr.Criticalw(fmt.Sprintf("Transmit queue is full; dropping oldest transmission (reached max length of %d)", tq.maxlen), "transmission", removed)
-		if removed, ok := removed.(*Transmission); ok {
+		if removed, ok := removed.(*queuedTransmission); ok {
 			tq.asyncDeleter.AsyncDelete(removed.Hash())
 		}
 	}
 
-	heap.Push(tq.pq, t)
+	heap.Push(tq.pq, &queuedTransmission{t, retry})
 	tq.cond.Signal()
 
 	return true
@@ -134,6 +143,19 @@ func (tq *transmitQueue) IsEmpty() bool {
 	return tq.pq.Len() == 0
 }
 
+func (tq *transmitQueue) Transmissions() []*Transmission {
+	tq.mu.RLock()
+	defer tq.mu.RUnlock()
+	if tq.pq == nil {
+		return nil
+	}
+	ts := make([]*Transmission, len(*tq.pq))
+	for i, qt := range *tq.pq {
+		ts[i] = qt.Transmission
+	}
+	return ts
+}
+
 func (tq *transmitQueue) Start(context.Context) error {
 	return tq.StartOnce("TransmitQueue", func() error {
 		t := services.NewTicker(promInterval)
@@ -212,7 +234,7 @@ func (tq *transmitQueue) pop() *Transmission {
 	if tq.pq.Len() == 0 {
 		return nil
 	}
-	return heap.Pop(tq.pq).(*Transmission)
+	return heap.Pop(tq.pq).(*queuedTransmission).Transmission
 }
 
 // HEAP
@@ -222,14 +244,25 @@ func (tq *transmitQueue) pop() *Transmission {
 
 var _ heap.Interface = &priorityQueue{}
 
-type priorityQueue []*Transmission
+// queuedTransmission is a Transmission along with its ordering hint
+type queuedTransmission struct {
+	*Transmission
+	// retry is true if the transmission was pushed back after a failed transmit
+	retry bool
+}
+
+type priorityQueue []*queuedTransmission
 
 func (pq priorityQueue) Len() int { return len(pq) }
 
 func (pq priorityQueue) Less(i, j int) bool {
 	// We want Pop to give us the latest round, so we use greater than here
 	// i.e. a later seqNr is "less" than an earlier one
-	return pq[i].SeqNr > pq[j].SeqNr
+	if pq[i].SeqNr != pq[j].SeqNr {
+		return pq[i].SeqNr > pq[j].SeqNr
+	}
+	// For the same seqNr, fresh transmissions take precedence over retries
+	return !pq[i].retry && pq[j].retry
 }
 
 func (pq priorityQueue) Swap(i, j int) {
@@ -249,5 +282,5 @@ func (pq *priorityQueue) Pop() any {
 }
 
 func (pq *priorityQueue) Push(x any) {
-	*pq = append(*pq, x.(*Transmission))
+	*pq = append(*pq, x.(*queuedTransmission))
 }
@@ -36,7 +36,7 @@ func Test_Queue(t *testing.T) {
 
 	t.Run("successfully add transmissions to transmit queue", func(t *testing.T) {
 		for _, tt := range testTransmissions {
-			ok := transmitQueue.Push(tt)
+			ok := transmitQueue.Push(tt, false)
 			require.True(t, ok)
 		}
 		report := transmitQueue.HealthReport()
@@ -44,7 +44,7 @@ func Test_Queue(t *testing.T) {
 	})
 
 	t.Run("transmit queue is more than 50% full", func(t *testing.T) {
-		transmitQueue.Push(testTransmissions[2])
+		transmitQueue.Push(testTransmissions[2], false)
 		report := transmitQueue.HealthReport()
 		assert.Equal(t, report[transmitQueue.Name()].Error(), "transmit priority queue is greater than 50% full (4/7)")
 	})
@@ -57,7 +57,7 @@ func Test_Queue(t *testing.T) {
 	t.Run("transmit queue is full and evicts the oldest transmission", func(t *testing.T) {
 		// add 5 more transmissions to overflow the queue by 1
 		for i := 0; i < 5; i++ {
-			transmitQueue.Push(testTransmissions[1])
+			transmitQueue.Push(testTransmissions[1], false)
 		}
 
 		// expecting testTransmissions[0] to get evicted and not present in the queue anymore
@@ -85,11 +85,39 @@ func Test_Queue(t *testing.T) {
 		}()
 		go func() {
 			defer wg.Done()
-			transmitQueue.Push(testTransmissions[0])
+			transmitQueue.Push(testTransmissions[0], false)
 		}()
 		wg.Wait()
 	})
 
+	t.Run("fresh transmissions take precedence over retries", func(t *testing.T) {
+		transmitQueue := NewTransmitQueue(lggr, sURL, 7, deleter)
+		transmitQueue.Init([]*Transmission{})
+
+		retried := makeSampleTransmission(5)
+		fresh := makeSampleTransmission(5)
+		fresh.Report.Report = []byte{4, 5, 6}
+		require.True(t, transmitQueue.Push(retried, true))
+		require.True(t, transmitQueue.Push(fresh, false))
+
+		assert.Same(t, fresh, transmitQueue.BlockingPop())
+		assert.Same(t, retried, transmitQueue.BlockingPop())
+		assert.True(t, transmitQueue.IsEmpty())
+	})
+
+	t.Run("newest seqNr wins over a fresh older transmission", func(t *testing.T) {
+		transmitQueue := NewTransmitQueue(lggr, sURL, 7, deleter)
+		transmitQueue.Init([]*Transmission{})
+
+		retried := makeSampleTransmission(6)
+		fresh := makeSampleTransmission(5)
+		require.True(t, transmitQueue.Push(fresh, false))
+		require.True(t, transmitQueue.Push(retried, true))
+
+		assert.Same(t, retried, transmitQueue.BlockingPop())
+		assert.Same(t, fresh, transmitQueue.BlockingPop())
+	})
+
 	t.Run("initializes transmissions", func(t *testing.T) {
 		expected := makeSampleTransmission(1)
 		transmissions := []*Transmission{
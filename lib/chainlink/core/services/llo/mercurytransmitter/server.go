@@ -2,10 +2,10 @@ package mercurytransmitter
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"sync"
-	"sync/atomic"
 	"time"
 
 	"github.com/jpillora/backoff"
@@ -22,6 +22,7 @@ import (
 	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
 	"github.com/smartcontractkit/chainlink-data-streams/llo"
 
+	"github.com/smartcontractkit/chainlink/v2/core/config"
 	corelogger "github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/llo/evm"
 	"github.com/smartcontractkit/chainlink/v2/core/services/relay/evm/mercury/wsrpc"
@@ -54,6 +55,14 @@ var (
 	},
 		[]string{"donID", "serverURL"},
 	)
+	promTransmitQueueDeleteOverflowCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "llo",
+		Subsystem: "mercurytransmitter",
+		Name:      "transmit_queue_delete_overflow_count",
+		Help:      "Running count of deletes deferred to the persistence manager because the delete queue stayed full",
+	},
+		[]string{"donID", "serverURL"},
+	)
 	promTransmitServerErrorCount = promauto.NewCounterVec(prometheus.CounterOpts{
 		Namespace: "llo",
 		Subsystem: "mercurytransmitter",
@@ -62,8 +71,73 @@ var (
 	},
 		[]string{"donID", "serverURL", "code"},
 	)
+	promTransmitDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "llo",
+		Subsystem: "mercurytransmitter",
+		Name:      "transmit_duration_ms",
+		Help:      "Duration in milliseconds of each transmit attempt to the mercury server, including report packing",
+		Buckets:   []float64{10, 30, 100, 200, 250, 300, 350, 400, 500, 750, 1000, 3000, 10000},
+	},
+		[]string{"donID", "serverURL"},
+	)
 )
 
+// defaultStuckThreadThreshold is the minimum duration the transmit or delete
+// threads must be continuously busy before the server reports itself
+// unhealthy
+const defaultStuckThreadThreshold = 10 * time.Minute
+
+// maxDeleteBatchSize is the maximum number of transmission hashes deleted from
+// the DB in a single round-trip
+const maxDeleteBatchSize = 1000
+
+// deleteConcurrency is the number of delete workers per server. Deletes are
+// batched, so a couple of workers keep up regardless of TransmitConcurrency
+// without multiplying the DB connections held by each server.
+const deleteConcurrency = 2
+
+// drainTimeout bounds how long Drain may spend flushing to the DB on shutdown
+const drainTimeout = 10 * time.Second
+
+// defaultDeleteQueueFullTimeout bounds how long a transmit worker blocks on a
+// full delete queue before deferring the delete to the persistence manager
+const defaultDeleteQueueFullTimeout = 1 * time.Second
+
+// backoffFactor is the growth factor of the queue loop retry backoffs, e.g.
+// 5ms, 10ms, 20ms, 40ms etc for a minimum of 5ms
+const backoffFactor = 2
+
+func transmitTimeoutFloor(cfg QueueConfig) time.Duration {
+	return time.Duration(cfg.TransmitTimeoutFloor() * float64(cfg.TransmitTimeout().Duration()))
+}
+
+// errUnencodable is returned by transmit for transmissions which can never be
+// packed, e.g. because their report format is unsupported. Unlike transport
+// errors retrying them would never succeed and only block the queue.
+var errUnencodable = errors.New("transmission cannot be encoded")
+
+// unencodableDeadLetterCode is the code of dead letters for transmissions
+// which were never sent because they could not be encoded, as opposed to
+// those rejected by the server with its own error code. As there is no
+// encoded payload, the raw report is kept as the payload of these dead letters.
+const unencodableDeadLetterCode int32 = -1
+
+// BackoffConfig configures an exponential backoff
+type BackoffConfig struct {
+	Min    time.Duration
+	Max    time.Duration
+	Factor float64
+	Jitter bool
+}
+
+func (c BackoffConfig) backoff() backoff.Backoff {
+	return backoff.Backoff{Min: c.Min, Max: c.Max, Factor: c.Factor, Jitter: c.Jitter}
+}
+
+func newBackoffConfig(c config.MercuryTransmitterBackoff) BackoffConfig {
+	return BackoffConfig{Min: c.Min(), Max: c.Max(), Factor: backoffFactor, Jitter: true}
+}
+
 type ReportPacker interface {
 	Pack(digest types.ConfigDigest, seqNr uint64, report ocr2types.Report, sigs []ocr2types.AttributedOnchainSignature) ([]byte, error)
 }
@@ -75,12 +149,22 @@ type server struct {
 	verboseLogging bool
 
 	transmitTimeout time.Duration
+	// the jittered transmit timeout never drops below this
+	transmitTimeoutFloor time.Duration
+	// number of transmit workers sharing the queue
+	transmitConcurrency int
+	transmitBackoff     BackoffConfig
+	deleteBackoff       BackoffConfig
 
 	c  wsrpc.Client
 	pm *persistenceManager
 	q  TransmitQueue
+	// deadLetters is nil if the ORM does not keep dead letters
+	deadLetters DeadLetterORM
 
 	deleteQueue chan [32]byte
+	// how long to wait for room on a full delete queue
+	deleteQueueFullTimeout time.Duration
 
 	url string
 
@@ -93,14 +177,31 @@ type server struct {
 	transmitQueueDeleteErrorCount prometheus.Counter
 	transmitQueueInsertErrorCount prometheus.Counter
 	transmitQueuePushErrorCount   prometheus.Counter
+	// deletes deferred to the persistence manager as the delete queue was full
+	transmitQueueDeleteOverflowCount prometheus.Counter
+	transmitDuration                 prometheus.Observer
 
-	transmitThreadBusyCount atomic.Int32
-	deleteThreadBusyCount   atomic.Int32
+	transmitThreads      busyThreads
+	deleteThreads        busyThreads
+	stuckThreadThreshold time.Duration
 }
 
 type QueueConfig interface {
 	TransmitQueueMaxSize() uint32
 	TransmitTimeout() commonconfig.Duration
+	// TransmitConcurrency is the number of transmissions that may be in
+	// flight to the server at once
+	TransmitConcurrency() uint32
+	// TransmitTimeoutFloor is the fraction of the transmit timeout, in
+	// (0, 1], below which the jittered timeout never drops, as very short
+	// timeouts cause spurious transmit failures
+	TransmitTimeoutFloor() float64
+	// TransmitBackoff is the backoff between retries of failed
+	// transmissions; latency is a priority so it should be short
+	TransmitBackoff() config.MercuryTransmitterBackoff
+	// DeleteBackoff is the backoff between retries of failed deletes of
+	// transmitted reports, which only fail on rare DB errors
+	DeleteBackoff() config.MercuryTransmitterBackoff
 }
 
 func newServer(lggr logger.Logger, verboseLogging bool, cfg QueueConfig, client wsrpc.Client, orm ORM, serverURL string) *server {
@@ -112,15 +213,22 @@ func newServer(lggr logger.Logger, verboseLogging bool, cfg QueueConfig, client
 	} else {
 		codecLggr = corelogger.NullLogger
 	}
+	deadLetters, _ := orm.(DeadLetterORM)
 
 	s := &server{
 		logger.Sugared(lggr),
 		verboseLogging,
 		cfg.TransmitTimeout().Duration(),
+		transmitTimeoutFloor(cfg),
+		max(1, int(cfg.TransmitConcurrency())),
+		newBackoffConfig(cfg.TransmitBackoff()),
+		newBackoffConfig(cfg.DeleteBackoff()),
 		client,
 		pm,
 		NewTransmitQueue(lggr, serverURL, int(cfg.TransmitQueueMaxSize()), pm),
+		deadLetters,
 		make(chan [32]byte, int(cfg.TransmitQueueMaxSize())),
+		defaultDeleteQueueFullTimeout,
 		serverURL,
 		evm.NewReportCodecPremiumLegacy(codecLggr, pm.DonID()),
 		llo.JSONReportCodec{},
@@ -130,47 +238,136 @@ func newServer(lggr logger.Logger, verboseLogging bool, cfg QueueConfig, client
 		promTransmitQueueDeleteErrorCount.WithLabelValues(donIDStr, serverURL),
 		promTransmitQueueInsertErrorCount.WithLabelValues(donIDStr, serverURL),
 		promTransmitQueuePushErrorCount.WithLabelValues(donIDStr, serverURL),
-		atomic.Int32{},
-		atomic.Int32{},
+		promTransmitQueueDeleteOverflowCount.WithLabelValues(donIDStr, serverURL),
+		promTransmitDuration.WithLabelValues(donIDStr, serverURL),
+		busyThreads{},
+		busyThreads{},
+		// a transmit may legitimately take up to the (jittered) transmit timeout
+		max(defaultStuckThreadThreshold, 2*cfg.TransmitTimeout().Duration()),
 	}
 
 	return s
 }
 
+// jitteredTransmitTimeout returns the transmit timeout with jitter applied, so
+// that transmissions failing together are not retried in lockstep, but never
+// shorter than the floor.
+func (s *server) jitteredTransmitTimeout() time.Duration {
+	return max(utils.WithJitter(s.transmitTimeout), s.transmitTimeoutFloor)
+}
+
 func (s *server) HealthReport() map[string]error {
 	report := map[string]error{}
 	services.CopyHealth(report, s.c.HealthReport())
 	services.CopyHealth(report, s.q.HealthReport())
+	report[s.lggr.Name()+".TransmitThreads"] = s.threadStatus("transmit", &s.transmitThreads)
+	report[s.lggr.Name()+".DeleteThreads"] = s.threadStatus("delete", &s.deleteThreads)
 	return report
 }
 
+// threadStatus returns an error if a thread has been busy with the same work
+// for longer than stuckThreadThreshold, which indicates it is stuck
+func (s *server) threadStatus(name string, threads *busyThreads) error {
+	since := threads.oldest()
+	if since.IsZero() {
+		return nil
+	}
+	if busyFor := time.Since(since); busyFor > s.stuckThreadThreshold {
+		return fmt.Errorf("a %s thread has been busy with the same work for %s, exceeding threshold of %s; it may be stuck", name, busyFor.Round(time.Second), s.stuckThreadThreshold)
+	}
+	return nil
+}
+
+// busyThreads tracks when each busy thread started its current work, so that
+// threads whose work keeps overlapping are not mistaken for a stuck thread.
+// The zero value is ready to use.
+type busyThreads struct {
+	mu    sync.Mutex
+	next  uint64
+	since map[uint64]time.Time
+}
+
+// markBusy records that a thread started working, and returns the function
+// recording that it is done
+func (b *busyThreads) markBusy() (markIdle func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.since == nil {
+		b.since = make(map[uint64]time.Time)
+	}
+	id := b.next
+	b.next++
+	b.since[id] = time.Now()
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.since, id)
+	}
+}
+
+// count returns the number of busy threads
+func (b *busyThreads) count() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.since)
+}
+
+// oldest returns when the longest running work started, zero if all threads
+// are idle
+func (b *busyThreads) oldest() time.Time {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var oldest time.Time
+	for _, since := range b.since {
+		if oldest.IsZero() || since.Before(oldest) {
+			oldest = since
+		}
+	}
+	return oldest
+}
+
+// runLoops starts transmitConcurrency transmit workers and deleteConcurrency
+// delete workers, all sharing the server's queues
+func (s *server) runLoops(stopCh services.StopChan, wg *sync.WaitGroup, donIDStr string) {
+	s.runDeleteQueueLoops(stopCh, wg)
+	wg.Add(s.transmitConcurrency)
+	for i := 0; i < s.transmitConcurrency; i++ {
+		go s.runQueueLoop(stopCh, wg, donIDStr)
+	}
+}
+
+// runDeleteQueueLoops starts deleteConcurrency delete workers sharing the
+// server's delete queue
+func (s *server) runDeleteQueueLoops(stopCh services.StopChan, wg *sync.WaitGroup) {
+	wg.Add(deleteConcurrency)
+	for i := 0; i < deleteConcurrency; i++ {
+		go s.runDeleteQueueLoop(stopCh, wg)
+	}
+}
+
 func (s *server) runDeleteQueueLoop(stopCh services.StopChan, wg *sync.WaitGroup) {
 	defer wg.Done()
 	ctx, cancel := stopCh.NewCtx()
 	defer cancel()
 
-	// Exponential backoff for very rarely occurring errors (DB disconnect etc)
-	b := backoff.Backoff{
-		Min:    1 * time.Second,
-		Max:    120 * time.Second,
-		Factor: 2,
-		Jitter: true,
-	}
+	b := s.deleteBackoff.backoff()
 
 	for {
 		select {
 		case hash := <-s.deleteQueue:
+			// coalesce any other pending deletes into a single DB round-trip
+			hashes := s.drainDeleteQueue(hash)
+			markIdle := s.deleteThreads.markBusy()
 			for {
-				s.deleteThreadBusyCount.Add(1)
-				if err := s.pm.orm.Delete(ctx, [][32]byte{hash}); err != nil {
-					s.lggr.Errorw("Failed to delete transmission record", "err", err, "transmissionHash", hash)
+				if err := s.pm.orm.Delete(ctx, hashes); err != nil {
+					s.lggr.Errorw("Failed to delete transmission records", "err", err, "count", len(hashes), "firstTransmissionHash", hashes[0])
 					s.transmitQueueDeleteErrorCount.Inc()
 					select {
 					case <-time.After(b.Duration()):
 						// Wait a backoff duration before trying to delete again
 						continue
 					case <-stopCh:
-						s.deleteThreadBusyCount.Add(-1)
+						markIdle()
 						// abort and return immediately on stop even if items remain in queue
 						return
 					}
@@ -179,7 +376,7 @@ func (s *server) runDeleteQueueLoop(stopCh services.StopChan, wg *sync.WaitGroup
 			}
 			// success
 			b.Reset()
-			s.deleteThreadBusyCount.Add(-1)
+			markIdle()
 		case <-stopCh:
 			// abort and return immediately on stop even if items remain in queue
 			return
@@ -187,16 +384,72 @@ func (s *server) runDeleteQueueLoop(stopCh services.StopChan, wg *sync.WaitGroup
 	}
 }
 
+// drainDeleteQueue returns the given hash along with any hashes immediately
+// available on the delete queue, up to maxDeleteBatchSize
+func (s *server) drainDeleteQueue(first [32]byte) [][32]byte {
+	hashes := [][32]byte{first}
+	for len(hashes) < maxDeleteBatchSize {
+		select {
+		case hash := <-s.deleteQueue:
+			hashes = append(hashes, hash)
+		default:
+			return hashes
+		}
+	}
+	return hashes
+}
+
+// Drain flushes the pending deletes to the DB and persists the transmissions
+// still in the in-memory queue, so that they are neither lost nor transmitted
+// twice on restart. It must only be called once the queue loops have exited,
+// and gives up after drainTimeout.
+func (s *server) Drain(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, drainTimeout)
+	defer cancel()
+
+	// deletes are flushed first, so that a transmission which is both queued
+	// and pending deletion stays persisted
+	hashes := s.pm.resetDeleteQueue()
+drain:
+	for {
+		select {
+		case hash := <-s.deleteQueue:
+			hashes = append(hashes, hash)
+		default:
+			break drain
+		}
+	}
+	for start := 0; start < len(hashes); start += maxDeleteBatchSize {
+		batch := hashes[start:min(start+maxDeleteBatchSize, len(hashes))]
+		if err := s.pm.orm.Delete(ctx, batch); err != nil {
+			s.transmitQueueDeleteErrorCount.Inc()
+			return fmt.Errorf("failed to flush %d pending deletes for %s: %w", len(hashes)-start, s.url, err)
+		}
+	}
+
+	transmissions := s.q.Transmissions()
+	if err := s.pm.orm.Insert(ctx, transmissions); err != nil {
+		s.transmitQueueInsertErrorCount.Inc()
+		return fmt.Errorf("failed to persist %d queued transmissions for %s: %w", len(transmissions), s.url, err)
+	}
+	s.lggr.Debugw("Drained transmit queue", "deleted", len(hashes), "persisted", len(transmissions))
+	return nil
+}
+
 func (s *server) runQueueLoop(stopCh services.StopChan, wg *sync.WaitGroup, donIDStr string) {
+	s.runTransmitLoop(stopCh, wg, func(ctx context.Context, t *Transmission) error {
+		return s.transmitAndHandle(ctx, donIDStr, t)
+	})
+}
+
+// runTransmitLoop pops transmissions off the server's queue and transmits
+// them with transmit until the queue is closed. Transmissions which can never
+// be encoded are dead-lettered and dropped, those which failed otherwise are
+// pushed back onto the queue and retried after a backoff, and the rest are
+// deleted.
+func (s *server) runTransmitLoop(stopCh services.StopChan, wg *sync.WaitGroup, transmit func(ctx context.Context, t *Transmission) error) {
 	defer wg.Done()
-	// Exponential backoff with very short retry interval (since latency is a priority)
-	// 5ms, 10ms, 20ms, 40ms etc
-	b := backoff.Backoff{
-		Min:    5 * time.Millisecond,
-		Max:    1 * time.Second,
-		Factor: 2,
-		Jitter: true,
-	}
+	b := s.transmitBackoff.backoff()
 	ctx, cancel := stopCh.NewCtx()
 	defer cancel()
 	cont := true
@@ -208,21 +461,17 @@ func (s *server) runQueueLoop(stopCh services.StopChan, wg *sync.WaitGroup, donI
 				return false
 			}
 
-			s.transmitThreadBusyCount.Add(1)
-			defer s.transmitThreadBusyCount.Add(-1)
-
-			req, res, err := func(ctx context.Context) (*pb.TransmitRequest, *pb.TransmitResponse, error) {
-				ctx, cancelFn := context.WithTimeout(ctx, utils.WithJitter(s.transmitTimeout))
-				defer cancelFn()
-				return s.transmit(ctx, t)
-			}(ctx)
+			err := transmit(ctx, t)
 			if ctx.Err() != nil {
 				// only canceled on transmitter close so we can exit
 				return false
+			} else if errors.Is(err, errUnencodable) {
+				s.lggr.Criticalw("Transmit report failed; dropping transmission that can never be encoded", "err", err, "transmission", t)
+				s.insertDeadLetter(ctx, t, t.Report.Report, unencodableDeadLetterCode, err.Error())
+				s.scheduleDelete(stopCh, t)
+				return true
 			} else if err != nil {
-				s.transmitConnectionErrorCount.Inc()
-				s.lggr.Errorw("Transmit report failed", "err", err, "req.Payload", req.Payload, "req.ReportFormat", req.ReportFormat, "transmission", t)
-				if ok := s.q.Push(t); !ok {
+				if ok := s.q.Push(t, true); !ok {
 					s.lggr.Error("Failed to push report to transmit queue; queue is closed")
 					return false
 				}
@@ -237,34 +486,95 @@ func (s *server) runQueueLoop(stopCh services.StopChan, wg *sync.WaitGroup, donI
 			}
 
 			b.Reset()
-			if res.Error == "" {
-				s.transmitSuccessCount.Inc()
-				s.lggr.Debugw("Transmit report success", "req.ReportFormat", req.ReportFormat, "req.Payload", req.Payload, "transmission", t, "response", res)
-			} else {
-				// We don't need to retry here because the mercury server
-				// has confirmed it received the report. We only need to retry
-				// on networking/unknown errors
-				switch res.Code {
-				case DuplicateReport:
-					s.transmitSuccessCount.Inc()
-					s.transmitDuplicateCount.Inc()
-					s.lggr.Debugw("Transmit report success; duplicate report", "req.ReportFormat", req.ReportFormat, "req.Payload", req.Payload, "transmission", t, "response", res)
-				default:
-					promTransmitServerErrorCount.WithLabelValues(donIDStr, s.url, strconv.FormatInt(int64(res.Code), 10)).Inc()
-					s.lggr.Errorw("Transmit report failed; mercury server returned error", "req.ReportFormat", req.ReportFormat, "req.Payload", req.Payload, "response", res, "transmission", t, "err", res.Error, "code", res.Code)
-				}
-			}
-
-			select {
-			case s.deleteQueue <- t.Hash():
-			default:
-				s.lggr.Criticalw("Delete queue is full", "transmission", t, "transmissionHash", fmt.Sprintf("%x", t.Hash()))
-			}
+			s.scheduleDelete(stopCh, t)
 			return true
 		}()
 	}
 }
 
+// transmitAndHandle makes a single attempt to transmit the transmission to the
+// server and handles the response. It returns an error if the transmission
+// could not be encoded or sent, in which case it is not handled. Reports
+// rejected by the server are dead-lettered instead, as the server has
+// confirmed it received them and retrying would not help.
+func (s *server) transmitAndHandle(ctx context.Context, donIDStr string, t *Transmission) error {
+	defer s.transmitThreads.markBusy()()
+
+	start := time.Now()
+	req, res, err := func(ctx context.Context) (*pb.TransmitRequest, *pb.TransmitResponse, error) {
+		ctx, cancelFn := context.WithTimeout(ctx, s.jitteredTransmitTimeout())
+		defer cancelFn()
+		return s.transmit(ctx, t)
+	}(ctx)
+	// includes both packing and the RPC
+	s.transmitDuration.Observe(float64(time.Since(start).Milliseconds()))
+	if err != nil {
+		// ctx is only canceled on transmitter close, which is no connection error
+		if ctx.Err() == nil && !errors.Is(err, errUnencodable) {
+			s.transmitConnectionErrorCount.Inc()
+			s.lggr.Errorw("Transmit report failed", "err", err, "req.Payload", req.Payload, "req.ReportFormat", req.ReportFormat, "transmission", t)
+		}
+		return err
+	}
+
+	if res.Error == "" {
+		s.transmitSuccessCount.Inc()
+		s.lggr.Debugw("Transmit report success", "req.ReportFormat", req.ReportFormat, "req.Payload", req.Payload, "transmission", t, "response", res)
+		return nil
+	}
+	// We don't need to retry here because the mercury server
+	// has confirmed it received the report. We only need to retry
+	// on networking/unknown errors
+	switch res.Code {
+	case DuplicateReport:
+		s.transmitSuccessCount.Inc()
+		s.transmitDuplicateCount.Inc()
+		s.lggr.Debugw("Transmit report success; duplicate report", "req.ReportFormat", req.ReportFormat, "req.Payload", req.Payload, "transmission", t, "response", res)
+	default:
+		promTransmitServerErrorCount.WithLabelValues(donIDStr, s.url, strconv.FormatInt(int64(res.Code), 10)).Inc()
+		s.lggr.Errorw("Transmit report failed; mercury server returned error", "req.ReportFormat", req.ReportFormat, "req.Payload", req.Payload, "response", res, "transmission", t, "err", res.Error, "code", res.Code)
+		s.insertDeadLetter(ctx, t, req.Payload, res.Code, res.Error)
+	}
+	return nil
+}
+
+// scheduleDelete schedules the delete of a transmission accepted by the
+// server. If the delete queue is full it blocks for up to
+// deleteQueueFullTimeout, after which the delete is deferred to the
+// persistence manager rather than dropped, as a transmission that is not
+// deleted would be transmitted again after a restart.
+func (s *server) scheduleDelete(stopCh services.StopChan, t *Transmission) {
+	hash := t.Hash()
+	select {
+	case s.deleteQueue <- hash:
+		return
+	default:
+	}
+
+	timer := time.NewTimer(s.deleteQueueFullTimeout)
+	defer timer.Stop()
+	select {
+	case s.deleteQueue <- hash:
+		return
+	case <-timer.C:
+	case <-stopCh:
+	}
+	s.transmitQueueDeleteOverflowCount.Inc()
+	s.lggr.Warnw("Delete queue is full; deferring delete to the persistence manager", "transmission", t, "transmissionHash", fmt.Sprintf("%x", hash))
+	s.pm.AsyncDelete(hash)
+}
+
+// insertDeadLetter keeps a transmission rejected by the server, or which could
+// not be encoded, for later inspection or replay, if the ORM supports it
+func (s *server) insertDeadLetter(ctx context.Context, t *Transmission, payload []byte, code int32, errMsg string) {
+	if s.deadLetters == nil {
+		return
+	}
+	if err := s.deadLetters.InsertDeadLetter(ctx, t, payload, code, errMsg); err != nil {
+		s.lggr.Errorw("Failed to insert dead letter; transmission is lost", "err", err, "transmission", t, "code", code)
+	}
+}
+
 func (s *server) transmit(ctx context.Context, t *Transmission) (*pb.TransmitRequest, *pb.TransmitResponse, error) {
 	var payload []byte
 	var err error
@@ -275,11 +585,11 @@ func (s *server) transmit(ctx context.Context, t *Transmission) (*pb.TransmitReq
 	case llotypes.ReportFormatEVMPremiumLegacy:
 		payload, err = s.evmPremiumLegacyPacker.Pack(t.ConfigDigest, t.SeqNr, t.Report.Report, t.Sigs)
 	default:
-		return nil, nil, fmt.Errorf("Transmit failed; don't know how to Pack unsupported report format: %q", t.Report.Info.ReportFormat)
+		return nil, nil, fmt.Errorf("%w; don't know how to Pack unsupported report format: %q", errUnencodable, t.Report.Info.ReportFormat)
 	}
 
 	if err != nil {
-		return nil, nil, fmt.Errorf("Transmit: encode failed; %w", err)
+		return nil, nil, fmt.Errorf("%w; encode failed: %w", errUnencodable, err)
 	}
 
 	req := &pb.TransmitRequest{
@@ -0,0 +1,129 @@
+package mercurytransmitter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+)
+
+// A serverSet transmits to several mercury servers in parallel for
+// redundancy. A transmission is considered delivered as soon as a quorum of
+// the servers received it, and is only re-queued if the quorum is not met.
+//
+// The first server is the primary: the transmissions of the set are queued,
+// persisted and deleted by it, so only the primary's delete loops need to be
+// running.
+type serverSet struct {
+	lggr    logger.SugaredLogger
+	servers []*server
+	quorum  int
+}
+
+func newServerSet(lggr logger.Logger, servers []*server, quorum int) (*serverSet, error) {
+	if len(servers) == 0 {
+		return nil, errors.New("server set must contain at least one server")
+	}
+	if quorum < 1 || quorum > len(servers) {
+		return nil, fmt.Errorf("invalid quorum %d: must be between 1 and the number of servers (%d)", quorum, len(servers))
+	}
+	return &serverSet{
+		lggr:    logger.Sugared(logger.Named(lggr, "ServerSet")),
+		servers: servers,
+		quorum:  quorum,
+	}, nil
+}
+
+func (ss *serverSet) primary() *server {
+	return ss.servers[0]
+}
+
+// runLoops starts the primary's delete workers, and as many workers
+// transmitting its queue to the whole set as the primary has transmit workers.
+// The other servers only queue what they persisted before they joined the
+// set, so a single worker each is enough to drain their queues.
+func (ss *serverSet) runLoops(stopCh services.StopChan, wg *sync.WaitGroup, donIDStr string) {
+	p := ss.primary()
+	p.runDeleteQueueLoops(stopCh, wg)
+	wg.Add(p.transmitConcurrency)
+	for i := 0; i < p.transmitConcurrency; i++ {
+		go p.runTransmitLoop(stopCh, wg, func(ctx context.Context, t *Transmission) error {
+			return ss.transmit(ctx, wg, donIDStr, t)
+		})
+	}
+	for _, s := range ss.servers[1:] {
+		s.runDeleteQueueLoops(stopCh, wg)
+		wg.Add(1)
+		go s.runQueueLoop(stopCh, wg, donIDStr)
+	}
+}
+
+// transmit sends the transmission to all servers in parallel, each handling
+// its response as it would in its own queue loop. It returns nil as soon as a
+// quorum of servers received the transmission, which includes servers which
+// rejected it since retrying would not help. Transmissions still in flight at
+// that point carry on in the background, tracked by wg and by the busy
+// transmit threads of their server, so that every server gets the report.
+// Once the quorum can no longer be reached it waits for the servers still
+// transmitting before returning an error, as the transmission is then
+// re-queued and must not be sent to any server twice at once.
+func (ss *serverSet) transmit(ctx context.Context, wg *sync.WaitGroup, donIDStr string, t *Transmission) error {
+	// buffered so that stragglers never block after we return
+	results := make(chan error, len(ss.servers))
+	wg.Add(len(ss.servers))
+	for _, s := range ss.servers {
+		go func(s *server) {
+			defer wg.Done()
+			err := s.transmitAndHandle(ctx, donIDStr, ss.transmissionFor(s, t))
+			if err != nil {
+				err = fmt.Errorf("transmit to %s failed: %w", s.url, err)
+			}
+			results <- err
+		}(s)
+	}
+
+	var received, failed int
+	var errs error
+	for range ss.servers {
+		err := <-results
+		if errors.Is(err, errUnencodable) {
+			// encoding does not depend on the server, so none can receive it
+			return err
+		} else if err == nil {
+			received++
+			if received >= ss.quorum {
+				ss.lggr.Debugw("Transmit report success; quorum reached", "transmission", t, "quorum", ss.quorum)
+				return nil
+			}
+			continue
+		}
+		failed++
+		errs = errors.Join(errs, err)
+		if len(ss.servers)-failed < ss.quorum {
+			break
+		}
+	}
+	// await the stragglers before the transmission is re-queued
+	for i := received + failed; i < len(ss.servers); i++ {
+		<-results
+	}
+	err := fmt.Errorf("quorum not reached: %d of %d servers received the transmission, need %d: %w", received, len(ss.servers), ss.quorum, errs)
+	if ctx.Err() == nil {
+		ss.lggr.Errorw("Transmit report failed", "err", err, "transmission", t)
+	}
+	return err
+}
+
+// transmissionFor returns the transmission as sent to the given server, so
+// that it is logged and dead-lettered under that server's URL
+func (ss *serverSet) transmissionFor(s *server, t *Transmission) *Transmission {
+	if s == ss.primary() {
+		return t
+	}
+	st := *t
+	st.ServerURL = s.url
+	return &st
+}
@@ -0,0 +1,262 @@
+package mercurytransmitter
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/relay/evm/mercury/wsrpc/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/services/relay/evm/mercury/wsrpc/pb"
+)
+
+// newTestServers returns three servers where the one at sURL3 always fails to
+// transmit, along with the number of transmit attempts made to each of them
+func newTestServers(t *testing.T) ([]*server, []*atomic.Int32) {
+	lggr := logger.TestLogger(t)
+	var servers []*server
+	var attempts []*atomic.Int32
+	for _, url := range []string{sURL, sURL2, sURL3} {
+		n := &atomic.Int32{}
+		failing := url == sURL3
+		c := &mocks.MockWSRPCClient{TransmitF: func(ctx context.Context, in *pb.TransmitRequest) (*pb.TransmitResponse, error) {
+			n.Add(1)
+			if failing {
+				return nil, errors.New("transmission error")
+			}
+			return &pb.TransmitResponse{Code: 0, Error: ""}, nil
+		}}
+		servers = append(servers, newServer(lggr, true, mockCfg{}, c, &batchRecordingORM{}, url))
+		attempts = append(attempts, n)
+	}
+	return servers, attempts
+}
+
+func Test_newServerSet(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	servers, _ := newTestServers(t)
+
+	_, err := newServerSet(lggr, nil, 1)
+	require.EqualError(t, err, "server set must contain at least one server")
+	_, err = newServerSet(lggr, servers, 0)
+	require.EqualError(t, err, "invalid quorum 0: must be between 1 and the number of servers (3)")
+	_, err = newServerSet(lggr, servers, 4)
+	require.EqualError(t, err, "invalid quorum 4: must be between 1 and the number of servers (3)")
+
+	ss, err := newServerSet(lggr, servers, 2)
+	require.NoError(t, err)
+	assert.Equal(t, servers[0], ss.primary())
+}
+
+func Test_serverSet_transmit(t *testing.T) {
+	donIDStr := "555"
+	lggr := logger.TestLogger(t)
+	ctx := testutils.Context(t)
+
+	t.Run("succeeds when a quorum of servers accepts the transmission", func(t *testing.T) {
+		servers, _ := newTestServers(t)
+		ss, err := newServerSet(lggr, servers, 2)
+		require.NoError(t, err)
+		wg := &sync.WaitGroup{}
+
+		require.NoError(t, ss.transmit(ctx, wg, donIDStr, makeSampleTransmission(1)))
+		wg.Wait()
+	})
+
+	t.Run("fails when the quorum is not reached", func(t *testing.T) {
+		servers, _ := newTestServers(t)
+		ss, err := newServerSet(lggr, servers, 3)
+		require.NoError(t, err)
+		wg := &sync.WaitGroup{}
+
+		err = ss.transmit(ctx, wg, donIDStr, makeSampleTransmission(1))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "quorum not reached")
+		assert.Contains(t, err.Error(), sURL3)
+		wg.Wait()
+	})
+
+	t.Run("duplicate reports count towards the quorum", func(t *testing.T) {
+		servers, _ := newTestServers(t)
+		servers[1].c = &mocks.MockWSRPCClient{TransmitF: func(ctx context.Context, in *pb.TransmitRequest) (*pb.TransmitResponse, error) {
+			return &pb.TransmitResponse{Code: DuplicateReport, Error: "duplicate"}, nil
+		}}
+		ss, err := newServerSet(lggr, servers, 2)
+		require.NoError(t, err)
+		wg := &sync.WaitGroup{}
+
+		require.NoError(t, ss.transmit(ctx, wg, donIDStr, makeSampleTransmission(1)))
+		wg.Wait()
+	})
+
+	t.Run("reports rejected by a server count towards the quorum", func(t *testing.T) {
+		servers, _ := newTestServers(t)
+		servers[1].c = &mocks.MockWSRPCClient{TransmitF: func(ctx context.Context, in *pb.TransmitRequest) (*pb.TransmitResponse, error) {
+			return &pb.TransmitResponse{Code: 1, Error: "bad report"}, nil
+		}}
+		ss, err := newServerSet(lggr, servers, 2)
+		require.NoError(t, err)
+		wg := &sync.WaitGroup{}
+
+		require.NoError(t, ss.transmit(ctx, wg, donIDStr, makeSampleTransmission(1)))
+		wg.Wait()
+	})
+
+	t.Run("fails with errUnencodable for transmissions which can never be encoded", func(t *testing.T) {
+		servers, attempts := newTestServers(t)
+		ss, err := newServerSet(lggr, servers, 2)
+		require.NoError(t, err)
+		wg := &sync.WaitGroup{}
+		transmission := makeSampleTransmission(1)
+		transmission.Report.Info.ReportFormat = llotypes.ReportFormat(255)
+
+		err = ss.transmit(ctx, wg, donIDStr, transmission)
+		require.ErrorIs(t, err, errUnencodable)
+		wg.Wait()
+		for _, n := range attempts {
+			assert.Zero(t, n.Load())
+		}
+	})
+
+	t.Run("stragglers still transmit after the quorum is reached", func(t *testing.T) {
+		servers, attempts := newTestServers(t)
+		started := make(chan struct{})
+		release := make(chan struct{})
+		servers[2].c = &mocks.MockWSRPCClient{TransmitF: func(ctx context.Context, in *pb.TransmitRequest) (*pb.TransmitResponse, error) {
+			attempts[2].Add(1)
+			close(started)
+			<-release
+			return &pb.TransmitResponse{Code: 0, Error: ""}, nil
+		}}
+		connectionErrors := prometheus.NewCounter(prometheus.CounterOpts{Name: "test_connection_errors"})
+		servers[2].transmitConnectionErrorCount = connectionErrors
+		ss, err := newServerSet(lggr, servers, 2)
+		require.NoError(t, err)
+		wg := &sync.WaitGroup{}
+
+		require.NoError(t, ss.transmit(ctx, wg, donIDStr, makeSampleTransmission(1)))
+		<-started
+		// the straggler is tracked as a busy transmit thread of its server
+		assert.Equal(t, 1, servers[2].transmitThreads.count())
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), attempts[2].Load())
+		assert.Zero(t, servers[2].transmitThreads.count())
+		assert.Zero(t, testutil.ToFloat64(connectionErrors))
+	})
+
+	t.Run("awaits the stragglers when the quorum is not reached", func(t *testing.T) {
+		servers, _ := newTestServers(t)
+		started := make(chan struct{})
+		release := make(chan struct{})
+		var finished atomic.Bool
+		servers[1].c = &mocks.MockWSRPCClient{TransmitF: func(ctx context.Context, in *pb.TransmitRequest) (*pb.TransmitResponse, error) {
+			close(started)
+			<-release
+			finished.Store(true)
+			return &pb.TransmitResponse{Code: 0, Error: ""}, nil
+		}}
+		ss, err := newServerSet(lggr, servers, 3)
+		require.NoError(t, err)
+		wg := &sync.WaitGroup{}
+
+		// the failure of sURL3 already rules out the quorum, but the
+		// transmission is only re-queued once sURL2 is done with it
+		go func() {
+			<-started
+			close(release)
+		}()
+		err = ss.transmit(ctx, wg, donIDStr, makeSampleTransmission(1))
+		require.ErrorContains(t, err, "quorum not reached")
+		assert.True(t, finished.Load())
+		for _, s := range servers {
+			assert.Zero(t, s.transmitThreads.count())
+		}
+		wg.Wait()
+	})
+}
+
+func Test_serverSet_runLoops(t *testing.T) {
+	donIDStr := "555"
+	lggr := logger.TestLogger(t)
+
+	t.Run("re-queues the transmission when the quorum is not reached", func(t *testing.T) {
+		servers, attempts := newTestServers(t)
+		orm := servers[0].pm.orm.(*batchRecordingORM)
+		ss, err := newServerSet(lggr, servers, 3)
+		require.NoError(t, err)
+		q := newMockQ()
+		servers[0].q = q
+		for _, s := range servers[1:] {
+			s.q = newMockQ()
+		}
+		wg := &sync.WaitGroup{}
+		stopCh := make(chan struct{})
+
+		ss.runLoops(stopCh, wg, donIDStr)
+		q.Push(makeSampleTransmission(1), false)
+
+		require.Eventually(t, func() bool {
+			return attempts[2].Load() >= 3
+		}, testutils.WaitTimeout(t), 10*time.Millisecond)
+		_, hashes := orm.deleted()
+		assert.Zero(t, hashes)
+
+		close(stopCh)
+		// unblock the transmit workers waiting on the queue
+		for i := 0; i < servers[0].transmitConcurrency; i++ {
+			q.Close()
+		}
+		for _, s := range servers[1:] {
+			s.q.Close()
+		}
+		wg.Wait()
+	})
+
+	t.Run("the other servers drain their own queues with a single worker", func(t *testing.T) {
+		servers, attempts := newTestServers(t)
+		ss, err := newServerSet(lggr, servers, 2)
+		require.NoError(t, err)
+		qs := make([]*mockQ, len(servers))
+		for i, s := range servers {
+			qs[i] = newMockQ()
+			s.q = qs[i]
+		}
+		wg := &sync.WaitGroup{}
+		stopCh := make(chan struct{})
+
+		ss.runLoops(stopCh, wg, donIDStr)
+		// persisted for sURL2 before it joined the set
+		leftover := makeSampleTransmission(1)
+		leftover.ServerURL = sURL2
+		qs[1].Push(leftover, false)
+
+		orm := servers[1].pm.orm.(*batchRecordingORM)
+		require.Eventually(t, func() bool {
+			_, hashes := orm.deleted()
+			return hashes == 1
+		}, testutils.WaitTimeout(t), 10*time.Millisecond)
+		assert.Equal(t, int32(1), attempts[1].Load())
+		assert.Zero(t, attempts[0].Load())
+
+		close(stopCh)
+		for i := 0; i < servers[0].transmitConcurrency; i++ {
+			qs[0].Close()
+		}
+		qs[1].Close()
+		qs[2].Close()
+		wg.Wait()
+	})
+}
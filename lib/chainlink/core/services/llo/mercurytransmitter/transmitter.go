@@ -8,18 +8,19 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"strconv"
 	"sync"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/exp/maps"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/ocr3types"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
 	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting2plus/types"
 
-	commonconfig "github.com/smartcontractkit/chainlink-common/pkg/config"
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/chainlink-common/pkg/services"
 	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
@@ -102,9 +103,7 @@ type Transmitter interface {
 var _ Transmitter = (*transmitter)(nil)
 
 type Config interface {
-	TransmitQueueMaxSize() uint32
-	TransmitTimeout() commonconfig.Duration
-	TransmitConcurrency() uint32
+	QueueConfig
 }
 
 type transmitter struct {
@@ -113,8 +112,13 @@ type transmitter struct {
 	verboseLogging bool
 	cfg            Config
 
-	orm        ORM
-	servers    map[string]*server
+	orm     ORM
+	servers map[string]*server
+	// quorum of servers a transmission must be received by, zero if every
+	// server has its own queue
+	quorum int
+	// set transmits to all servers with the quorum, nil if quorum is zero
+	set        *serverSet
 	registerer prometheus.Registerer
 	collectors []prometheus.Collector
 
@@ -134,6 +138,10 @@ type Opts struct {
 	FromAccount    ed25519.PublicKey
 	DonID          uint32
 	ORM            ORM
+	// Quorum is the number of servers which must receive a transmission for
+	// it to be delivered. If set, transmissions are queued once and sent to
+	// all servers in parallel, otherwise each server has its own queue.
+	Quorum int
 }
 
 func New(opts Opts) Transmitter {
@@ -154,6 +162,8 @@ func newTransmitter(opts Opts) *transmitter {
 		opts.Cfg,
 		opts.ORM,
 		servers,
+		opts.Quorum,
+		nil,
 		opts.Registerer,
 		nil,
 		opts.DonID,
@@ -169,6 +179,20 @@ func (mt *transmitter) Start(ctx context.Context) (err error) {
 			mt.lggr.Debugw("Loading transmit requests from database")
 		}
 
+		if mt.quorum > 0 {
+			urls := maps.Keys(mt.servers)
+			slices.Sort(urls)
+			servers := make([]*server, len(urls))
+			for i, url := range urls {
+				servers[i] = mt.servers[url]
+			}
+			set, err := newServerSet(mt.lggr, servers, mt.quorum)
+			if err != nil {
+				return err
+			}
+			mt.set = set
+		}
+
 		{
 			var startClosers []services.StartClose
 			for _, s := range mt.servers {
@@ -182,17 +206,14 @@ func (mt *transmitter) Start(ctx context.Context) (err error) {
 				startClosers = append(startClosers, s.c, s.q, s.pm)
 
 				// Number of goroutines per server will be roughly
-				// 2*nServers*TransmitConcurrency because each server has a
-				// delete queue and a transmit queue.
+				// nServers*(TransmitConcurrency+deleteConcurrency) because
+				// each server has a delete queue and a transmit queue.
 				//
 				// This could potentially be reduced by implementing transmit batching,
 				// see: https://smartcontract-it.atlassian.net/browse/MERC-6635
-				nThreads := int(mt.cfg.TransmitConcurrency())
-				mt.wg.Add(2 * nThreads)
 				donIDStr := strconv.FormatUint(uint64(mt.donID), 10)
-				for i := 0; i < nThreads; i++ {
-					go s.runDeleteQueueLoop(mt.stopCh, mt.wg)
-					go s.runQueueLoop(mt.stopCh, mt.wg, donIDStr)
+				if mt.set == nil {
+					s.runLoops(mt.stopCh, mt.wg, donIDStr)
 				}
 				mt.collectors = append(mt.collectors, prometheus.NewGaugeFunc(
 					prometheus.GaugeOpts{
@@ -200,9 +221,9 @@ func (mt *transmitter) Start(ctx context.Context) (err error) {
 						Subsystem:   "mercurytransmitter",
 						Name:        "concurrent_transmit_gauge",
 						Help:        "Gauge that measures the number of transmit threads currently waiting on a remote transmit call. You may wish to alert if this exceeds some number for a given period of time, or if it ever reaches its max.",
-						ConstLabels: prometheus.Labels{"donID": donIDStr, "serverURL": s.url, "maxConcurrentTransmits": strconv.FormatInt(int64(nThreads), 10)},
+						ConstLabels: prometheus.Labels{"donID": donIDStr, "serverURL": s.url, "maxConcurrentTransmits": strconv.FormatInt(int64(s.transmitConcurrency), 10)},
 					}, func() float64 {
-						return float64(s.transmitThreadBusyCount.Load())
+						return float64(s.transmitThreads.count())
 					}))
 				mt.collectors = append(mt.collectors, prometheus.NewGaugeFunc(
 					prometheus.GaugeOpts{
@@ -210,9 +231,9 @@ func (mt *transmitter) Start(ctx context.Context) (err error) {
 						Subsystem:   "mercurytransmitter",
 						Name:        "concurrent_delete_gauge",
 						Help:        "Gauge that measures the number of delete threads currently waiting on a delete call to the DB. You may wish to alert if this exceeds some number for a given period of time, or if it ever reaches its max.",
-						ConstLabels: prometheus.Labels{"donID": donIDStr, "serverURL": s.url, "maxConcurrentDeletes": strconv.FormatInt(int64(nThreads), 10)},
+						ConstLabels: prometheus.Labels{"donID": donIDStr, "serverURL": s.url, "maxConcurrentDeletes": strconv.FormatInt(int64(deleteConcurrency), 10)},
 					}, func() float64 {
-						return float64(s.deleteThreadBusyCount.Load())
+						return float64(s.deleteThreads.count())
 					}))
 				for _, c := range mt.collectors {
 					if err := mt.registerer.Register(c); err != nil {
@@ -220,6 +241,9 @@ func (mt *transmitter) Start(ctx context.Context) (err error) {
 					}
 				}
 			}
+			if mt.set != nil {
+				mt.set.runLoops(mt.stopCh, mt.wg, strconv.FormatUint(uint64(mt.donID), 10))
+			}
 			if err := (&services.MultiStart{}).Start(ctx, startClosers...); err != nil {
 				return err
 			}
@@ -243,6 +267,13 @@ func (mt *transmitter) Close() error {
 		close(mt.stopCh)
 		mt.wg.Wait()
 
+		// Flush pending deletes and persist what is left in the queues now
+		// that nothing else is touching them
+		var drainErr error
+		for _, s := range mt.servers {
+			drainErr = errors.Join(drainErr, s.Drain(context.Background()))
+		}
+
 		// Close all the persistence managers
 		// Close all the clients
 		var closers []io.Closer
@@ -250,7 +281,7 @@ func (mt *transmitter) Close() error {
 			closers = append(closers, s.pm)
 			closers = append(closers, s.c)
 		}
-		err := services.CloseAll(closers...)
+		err := errors.Join(drainErr, services.CloseAll(closers...))
 		// Unregister all the gauge funcs
 		for _, c := range mt.collectors {
 			mt.registerer.Unregister(c)
@@ -276,8 +307,13 @@ func (mt *transmitter) Transmit(
 	seqNr uint64,
 	report ocr3types.ReportWithInfo[llotypes.ReportInfo],
 	sigs []types.AttributedOnchainSignature) error {
-	transmissions := make([]*Transmission, 0, len(mt.servers))
-	for serverURL := range mt.servers {
+	serverURLs := maps.Keys(mt.servers)
+	if mt.set != nil {
+		// the primary queues the transmissions of the set
+		serverURLs = []string{mt.set.primary().url}
+	}
+	transmissions := make([]*Transmission, 0, len(serverURLs))
+	for _, serverURL := range serverURLs {
 		transmissions = append(transmissions, &Transmission{
 			ServerURL:    serverURL,
 			ConfigDigest: digest,
@@ -298,7 +334,7 @@ func (mt *transmitter) Transmit(
 		}
 		g.Go(func() error {
 			s := mt.servers[t.ServerURL]
-			if ok := s.q.Push(t); !ok {
+			if ok := s.q.Push(t, false); !ok {
 				s.transmitQueuePushErrorCount.Inc()
 				return errors.New("transmit queue is closed")
 			}
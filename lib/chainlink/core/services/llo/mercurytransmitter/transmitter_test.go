@@ -4,10 +4,13 @@ import (
 	"context"
 	"crypto/ed25519"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	io_prometheus_client "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -15,6 +18,9 @@ import (
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
 
 	commonconfig "github.com/smartcontractkit/chainlink-common/pkg/config"
+	llotypes "github.com/smartcontractkit/chainlink-common/pkg/types/llo"
+
+	"github.com/smartcontractkit/chainlink/v2/core/config"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
@@ -37,6 +43,25 @@ func (m mockCfg) TransmitConcurrency() uint32 {
 	return 5
 }
 
+func (m mockCfg) TransmitTimeoutFloor() float64 {
+	return 0.9
+}
+
+func (m mockCfg) TransmitBackoff() config.MercuryTransmitterBackoff {
+	return mockBackoff{min: 5 * time.Millisecond, max: time.Second}
+}
+
+func (m mockCfg) DeleteBackoff() config.MercuryTransmitterBackoff {
+	return mockBackoff{min: time.Second, max: 120 * time.Second}
+}
+
+type mockBackoff struct {
+	min, max time.Duration
+}
+
+func (b mockBackoff) Min() time.Duration { return b.min }
+func (b mockBackoff) Max() time.Duration { return b.max }
+
 func Test_Transmitter_Transmit(t *testing.T) {
 	lggr := logger.TestLogger(t)
 	db := pgtest.NewSqlxDB(t)
@@ -103,6 +128,73 @@ func Test_Transmitter_Transmit(t *testing.T) {
 	})
 }
 
+func Test_Transmitter_Quorum(t *testing.T) {
+	ctx := testutils.Context(t)
+	lggr := logger.TestLogger(t)
+	db := pgtest.NewSqlxDB(t)
+	donID := uint32(123456)
+	orm := NewORM(db, donID)
+
+	// sURL accepts, sURL2 rejects and sURL3 is unreachable
+	var attempts3 atomic.Int32
+	clients := map[string]wsrpc.Client{
+		sURL: &mocks.MockWSRPCClient{TransmitF: func(ctx context.Context, in *pb.TransmitRequest) (*pb.TransmitResponse, error) {
+			return &pb.TransmitResponse{Code: 0, Error: ""}, nil
+		}},
+		sURL2: &mocks.MockWSRPCClient{TransmitF: func(ctx context.Context, in *pb.TransmitRequest) (*pb.TransmitResponse, error) {
+			return &pb.TransmitResponse{Code: 1, Error: "bad report"}, nil
+		}},
+		sURL3: &mocks.MockWSRPCClient{TransmitF: func(ctx context.Context, in *pb.TransmitRequest) (*pb.TransmitResponse, error) {
+			attempts3.Add(1)
+			return nil, errors.New("transmission error")
+		}},
+	}
+
+	mt := newTransmitter(Opts{
+		Lggr:        lggr,
+		Registerer:  prometheus.NewRegistry(),
+		Cfg:         mockCfg{},
+		Clients:     clients,
+		FromAccount: ed25519.PublicKey{},
+		DonID:       donID,
+		ORM:         orm,
+		Quorum:      2,
+	})
+	require.NoError(t, mt.Start(ctx))
+	t.Cleanup(func() { require.NoError(t, mt.Close()) })
+	// the server with the lowest URL is the primary
+	require.Equal(t, mt.servers[sURL], mt.set.primary())
+
+	sigs := []types.AttributedOnchainSignature{{
+		Signature: []byte{22},
+		Signer:    commontypes.OracleID(43),
+	}}
+	err := mt.Transmit(ctx, makeSampleConfigDigest(), 55, makeSampleReport(), sigs)
+	require.NoError(t, err)
+
+	// only the primary persists the transmission
+	for _, url := range []string{sURL2, sURL3} {
+		transmissions, err := orm.Get(ctx, url)
+		require.NoError(t, err)
+		assert.Empty(t, transmissions)
+	}
+
+	// accepted by sURL and received by sURL2, so it is delivered and deleted
+	require.Eventually(t, func() bool {
+		transmissions, err := orm.Get(ctx, sURL)
+		require.NoError(t, err)
+		return len(transmissions) == 0
+	}, testutils.WaitTimeout(t), 10*time.Millisecond)
+	assert.Positive(t, attempts3.Load())
+
+	// the rejection is dead-lettered under the server which rejected it
+	deadLetters, err := orm.(DeadLetterORM).GetDeadLetters(ctx, sURL2)
+	require.NoError(t, err)
+	require.Len(t, deadLetters, 1)
+	assert.Equal(t, uint64(55), deadLetters[0].SeqNr)
+	assert.Equal(t, "bad report", deadLetters[0].Error)
+}
+
 type mockQ struct {
 	ch chan *Transmission
 }
@@ -123,12 +215,13 @@ func (m *mockQ) BlockingPop() (t *Transmission) {
 	val := <-m.ch
 	return val
 }
-func (m *mockQ) Push(t *Transmission) (ok bool) {
+func (m *mockQ) Push(t *Transmission, retry bool) (ok bool) {
 	m.ch <- t
 	return true
 }
 func (m *mockQ) Init(transmissions []*Transmission) {}
 func (m *mockQ) IsEmpty() bool                      { return false }
+func (m *mockQ) Transmissions() []*Transmission     { return nil }
 
 func Test_Transmitter_runQueueLoop(t *testing.T) {
 	donIDStr := "555"
@@ -155,7 +248,7 @@ func Test_Transmitter_runQueueLoop(t *testing.T) {
 		go s.runQueueLoop(nil, wg, donIDStr)
 
 		transmission := makeSampleTransmission(1)
-		q.Push(transmission)
+		q.Push(transmission, false)
 
 		select {
 		case tr := <-transmit:
@@ -183,7 +276,7 @@ func Test_Transmitter_runQueueLoop(t *testing.T) {
 		go s.runQueueLoop(nil, wg, donIDStr)
 
 		transmission := makeSampleTransmission(1)
-		q.Push(transmission)
+		q.Push(transmission, false)
 
 		select {
 		case tr := <-transmit:
@@ -210,7 +303,7 @@ func Test_Transmitter_runQueueLoop(t *testing.T) {
 		go s.runQueueLoop(nil, wg, donIDStr)
 
 		transmission := makeSampleTransmission(1)
-		q.Push(transmission)
+		q.Push(transmission, false)
 
 		select {
 		case tr := <-transmit:
@@ -238,7 +331,7 @@ func Test_Transmitter_runQueueLoop(t *testing.T) {
 		go s.runQueueLoop(stopCh, wg, donIDStr)
 
 		transmission := makeSampleTransmission(1)
-		q.Push(transmission)
+		q.Push(transmission, false)
 
 		cnt := 0
 	Loop:
@@ -260,3 +353,588 @@ func Test_Transmitter_runQueueLoop(t *testing.T) {
 		wg.Wait()
 	})
 }
+
+// backdateBusyThreads moves the start of the work of all busy threads back by d
+func backdateBusyThreads(b *busyThreads, d time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for id, since := range b.since {
+		b.since[id] = since.Add(-d)
+	}
+}
+
+func Test_Server_HealthReport(t *testing.T) {
+	donIDStr := "555"
+	lggr := logger.TestLogger(t)
+	c := &mocks.MockWSRPCClient{}
+	db := pgtest.NewSqlxDB(t)
+	donID := uint32(123456)
+	orm := NewORM(db, donID)
+	cfg := mockCfg{}
+
+	s := newServer(lggr, true, cfg, c, orm, sURL)
+	s.stuckThreadThreshold = 100 * time.Millisecond
+
+	t.Run("healthy when idle", func(t *testing.T) {
+		for _, err := range s.HealthReport() {
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("healthy while overlapping work keeps the threads busy", func(t *testing.T) {
+		transmitThreadsKey := s.lggr.Name() + ".TransmitThreads"
+		// the threads are busy for longer than the threshold, but each piece of work is shorter
+		markIdle := s.transmitThreads.markBusy()
+		backdateBusyThreads(&s.transmitThreads, 2*s.stuckThreadThreshold)
+		next := s.transmitThreads.markBusy()
+		markIdle()
+		assert.NoError(t, s.HealthReport()[transmitThreadsKey])
+
+		// until the current piece of work takes too long
+		backdateBusyThreads(&s.transmitThreads, 2*s.stuckThreadThreshold)
+		assert.ErrorContains(t, s.HealthReport()[transmitThreadsKey], "a transmit thread has been busy with the same work")
+		next()
+		assert.Zero(t, s.transmitThreads.count())
+	})
+
+	t.Run("unhealthy while a transmit is hung", func(t *testing.T) {
+		transmitting := make(chan struct{})
+		release := make(chan struct{})
+		c.TransmitF = func(ctx context.Context, in *pb.TransmitRequest) (*pb.TransmitResponse, error) {
+			close(transmitting)
+			<-release
+			return &pb.TransmitResponse{Code: 0, Error: ""}, nil
+		}
+		q := newMockQ()
+		s.q = q
+		wg := &sync.WaitGroup{}
+		wg.Add(1)
+
+		go s.runQueueLoop(nil, wg, donIDStr)
+		q.Push(makeSampleTransmission(1), false)
+
+		select {
+		case <-transmitting:
+		case <-time.After(testutils.WaitTimeout(t)):
+			t.Fatal("expected a transmit request to be sent")
+		}
+
+		transmitThreadsKey := s.lggr.Name() + ".TransmitThreads"
+		require.Eventually(t, func() bool {
+			return s.HealthReport()[transmitThreadsKey] != nil
+		}, testutils.WaitTimeout(t), 10*time.Millisecond)
+		assert.ErrorContains(t, s.HealthReport()[transmitThreadsKey], "a transmit thread has been busy with the same work")
+		assert.NoError(t, s.HealthReport()[s.lggr.Name()+".DeleteThreads"])
+
+		close(release)
+		require.Eventually(t, func() bool {
+			return s.HealthReport()[transmitThreadsKey] == nil
+		}, testutils.WaitTimeout(t), 10*time.Millisecond)
+
+		q.Close()
+		wg.Wait()
+	})
+}
+
+type batchRecordingORM struct {
+	ORM
+
+	mu      sync.Mutex
+	batches [][][32]byte
+}
+
+func (o *batchRecordingORM) DonID() uint32 { return 123456 }
+
+func (o *batchRecordingORM) Delete(ctx context.Context, hashes [][32]byte) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.batches = append(o.batches, hashes)
+	return nil
+}
+
+func (o *batchRecordingORM) deleted() (batches int, hashes int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, b := range o.batches {
+		hashes += len(b)
+	}
+	return len(o.batches), hashes
+}
+
+func Test_Server_runDeleteQueueLoop(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	c := &mocks.MockWSRPCClient{}
+	orm := &batchRecordingORM{}
+	cfg := mockCfg{}
+
+	s := newServer(lggr, true, cfg, c, orm, sURL)
+
+	nDeletes := 100
+	for i := 0; i < nDeletes; i++ {
+		s.deleteQueue <- makeSampleTransmission(uint64(i)).Hash()
+	}
+
+	stopCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go s.runDeleteQueueLoop(stopCh, wg)
+
+	require.Eventually(t, func() bool {
+		_, hashes := orm.deleted()
+		return hashes == nDeletes
+	}, testutils.WaitTimeout(t), 10*time.Millisecond)
+
+	close(stopCh)
+	wg.Wait()
+
+	batches, _ := orm.deleted()
+	assert.Less(t, batches, nDeletes, "expected deletes to be flushed in batches")
+	assert.Equal(t, 1, batches)
+}
+
+func Test_Server_TransmitDuration(t *testing.T) {
+	donIDStr := "555"
+	lggr := logger.TestLogger(t)
+	c := &mocks.MockWSRPCClient{}
+	orm := &batchRecordingORM{}
+	cfg := mockCfg{}
+
+	s := newServer(lggr, true, cfg, c, orm, sURL)
+
+	sampleCount := func(t *testing.T) uint64 {
+		m := &io_prometheus_client.Metric{}
+		require.NoError(t, s.transmitDuration.(prometheus.Metric).Write(m))
+		return m.GetHistogram().GetSampleCount()
+	}
+	initialCount := sampleCount(t)
+
+	// fail the first two attempts so the transmission is retried
+	var attempts atomic.Int32
+	done := make(chan struct{})
+	c.TransmitF = func(ctx context.Context, in *pb.TransmitRequest) (*pb.TransmitResponse, error) {
+		if attempts.Add(1) <= 2 {
+			return nil, errors.New("transmission error")
+		}
+		close(done)
+		return &pb.TransmitResponse{Code: 0, Error: ""}, nil
+	}
+	q := newMockQ()
+	s.q = q
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+
+	go s.runQueueLoop(nil, wg, donIDStr)
+	q.Push(makeSampleTransmission(1), false)
+
+	select {
+	case <-done:
+	case <-time.After(testutils.WaitTimeout(t)):
+		t.Fatal("expected the transmission to eventually succeed")
+	}
+
+	q.Close()
+	wg.Wait()
+
+	assert.Equal(t, int32(3), attempts.Load())
+	assert.Equal(t, uint64(3), sampleCount(t)-initialCount)
+}
+
+func Test_Server_Drain(t *testing.T) {
+	ctx := testutils.Context(t)
+	lggr := logger.TestLogger(t)
+	c := &mocks.MockWSRPCClient{}
+	db := pgtest.NewSqlxDB(t)
+	donID := uint32(123456)
+	orm := NewORM(db, donID)
+	cfg := mockCfg{}
+
+	s := newServer(lggr, true, cfg, c, orm, sURL)
+	s.q.Init(nil)
+
+	// a transmission that was delivered but whose delete is still pending
+	delivered := makeSampleTransmission(1)
+	require.NoError(t, orm.Insert(ctx, []*Transmission{delivered}))
+	s.deleteQueue <- delivered.Hash()
+
+	// transmissions that only exist in memory
+	queued := []*Transmission{makeSampleTransmission(2), makeSampleTransmission(3)}
+	for _, tr := range queued {
+		require.True(t, s.q.Push(tr, false))
+	}
+
+	require.NoError(t, s.Drain(ctx))
+
+	persisted, err := orm.Get(ctx, sURL)
+	require.NoError(t, err)
+	require.Len(t, persisted, 2)
+	// returned in descending seqNr order
+	assert.Equal(t, queued[1].Hash(), persisted[0].Hash())
+	assert.Equal(t, queued[0].Hash(), persisted[1].Hash())
+	assert.Empty(t, s.deleteQueue)
+
+	// draining again is a no-op
+	require.NoError(t, s.Drain(ctx))
+	persisted, err = orm.Get(ctx, sURL)
+	require.NoError(t, err)
+	assert.Len(t, persisted, 2)
+}
+
+type queueSizeCfg struct {
+	mockCfg
+	size uint32
+}
+
+func (c queueSizeCfg) TransmitQueueMaxSize() uint32 { return c.size }
+
+func Test_Server_DeleteQueueFull(t *testing.T) {
+	const deleteQueueSize, n = 2, 5
+	ctx := testutils.Context(t)
+	donIDStr := "555"
+	lggr := logger.TestLogger(t)
+	c := &mocks.MockWSRPCClient{}
+	orm := &batchRecordingORM{}
+
+	s := newServer(lggr, true, queueSizeCfg{size: deleteQueueSize}, c, orm, sURL)
+	s.deleteQueueFullTimeout = 10 * time.Millisecond
+	require.Equal(t, deleteQueueSize, cap(s.deleteQueue))
+
+	transmitted := make(chan struct{}, n)
+	c.TransmitF = func(ctx context.Context, in *pb.TransmitRequest) (*pb.TransmitResponse, error) {
+		transmitted <- struct{}{}
+		return &pb.TransmitResponse{Code: 0, Error: ""}, nil
+	}
+	q := newMockQ()
+	s.q = q
+	stopCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	// only the transmit loop runs, so nothing drains the delete queue
+	wg.Add(1)
+	go s.runQueueLoop(stopCh, wg, donIDStr)
+
+	for i := 0; i < n; i++ {
+		q.Push(makeSampleTransmission(uint64(i)), false)
+	}
+	for i := 0; i < n; i++ {
+		select {
+		case <-transmitted:
+		case <-time.After(testutils.WaitTimeout(t)):
+			t.Fatal("expected all transmissions to be sent despite the full delete queue")
+		}
+	}
+
+	// the deletes that did not fit in the delete queue are deferred to the
+	// persistence manager instead of being dropped
+	deferred := func() int {
+		s.pm.deleteMu.Lock()
+		defer s.pm.deleteMu.Unlock()
+		return len(s.pm.deleteQueue)
+	}
+	require.Eventually(t, func() bool {
+		return len(s.deleteQueue) == deleteQueueSize && deferred() == n-deleteQueueSize
+	}, testutils.WaitTimeout(t), 10*time.Millisecond)
+
+	require.NoError(t, s.pm.Start(ctx))
+	t.Cleanup(func() { require.NoError(t, s.pm.Close()) })
+	wg.Add(1)
+	go s.runDeleteQueueLoop(stopCh, wg)
+
+	// every transmission is eventually deleted
+	require.Eventually(t, func() bool {
+		_, hashes := orm.deleted()
+		return hashes == n
+	}, testutils.WaitTimeout(t), 10*time.Millisecond)
+
+	close(stopCh)
+	q.Close()
+	wg.Wait()
+}
+
+type concurrencyCfg struct {
+	mockCfg
+	concurrency uint32
+}
+
+func (c concurrencyCfg) TransmitConcurrency() uint32 { return c.concurrency }
+
+func Test_Server_TransmitConcurrency(t *testing.T) {
+	const concurrency = 3
+	donIDStr := "555"
+	lggr := logger.TestLogger(t)
+	c := &mocks.MockWSRPCClient{}
+	orm := &batchRecordingORM{}
+
+	s := newServer(lggr, true, concurrencyCfg{concurrency: concurrency}, c, orm, sURL)
+
+	var inFlight, maxInFlight atomic.Int32
+	release := make(chan struct{})
+	transmitted := make(chan struct{}, 2*concurrency)
+	c.TransmitF = func(ctx context.Context, in *pb.TransmitRequest) (*pb.TransmitResponse, error) {
+		n := inFlight.Add(1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		<-release
+		inFlight.Add(-1)
+		transmitted <- struct{}{}
+		return &pb.TransmitResponse{Code: 0, Error: ""}, nil
+	}
+	q := newMockQ()
+	s.q = q
+	stopCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	s.runLoops(stopCh, wg, donIDStr)
+
+	for i := 0; i < 2*concurrency; i++ {
+		q.Push(makeSampleTransmission(uint64(i)), false)
+	}
+
+	// all workers pick up a transmission, but no more than that
+	require.Eventually(t, func() bool {
+		return inFlight.Load() == concurrency
+	}, testutils.WaitTimeout(t), 10*time.Millisecond)
+	// the busy workers leave the rest of the transmissions queued
+	assert.Len(t, q.ch, concurrency)
+
+	close(release)
+	for i := 0; i < 2*concurrency; i++ {
+		select {
+		case <-transmitted:
+		case <-time.After(testutils.WaitTimeout(t)):
+			t.Fatal("expected all transmissions to be sent")
+		}
+	}
+	assert.Equal(t, int32(concurrency), maxInFlight.Load())
+
+	// every transmission is deleted exactly once
+	require.Eventually(t, func() bool {
+		_, hashes := orm.deleted()
+		return hashes == 2*concurrency
+	}, testutils.WaitTimeout(t), 10*time.Millisecond)
+
+	close(stopCh)
+	// unblock the transmit workers waiting on the queue
+	for i := 0; i < concurrency; i++ {
+		q.Close()
+	}
+	wg.Wait()
+}
+
+type backoffCfg struct {
+	mockCfg
+	transmit mockBackoff
+}
+
+func (c backoffCfg) TransmitBackoff() config.MercuryTransmitterBackoff { return c.transmit }
+
+func Test_Server_Backoff(t *testing.T) {
+	donIDStr := "555"
+	lggr := logger.TestLogger(t)
+	c := &mocks.MockWSRPCClient{}
+	orm := &batchRecordingORM{}
+
+	t.Run("uses the configured backoffs", func(t *testing.T) {
+		s := newServer(lggr, true, mockCfg{}, c, orm, sURL)
+		assert.Equal(t, BackoffConfig{Min: 5 * time.Millisecond, Max: time.Second, Factor: 2, Jitter: true}, s.transmitBackoff)
+		assert.Equal(t, BackoffConfig{Min: time.Second, Max: 120 * time.Second, Factor: 2, Jitter: true}, s.deleteBackoff)
+	})
+
+	t.Run("first retry waits for the configured minimum", func(t *testing.T) {
+		minDelay := 200 * time.Millisecond
+		cfg := backoffCfg{transmit: mockBackoff{min: minDelay, max: time.Second}}
+		s := newServer(lggr, true, cfg, c, orm, sURL)
+		assert.Equal(t, minDelay, s.transmitBackoff.Min)
+
+		attempts := make(chan time.Time, 2)
+		var n atomic.Int32
+		c.TransmitF = func(ctx context.Context, in *pb.TransmitRequest) (*pb.TransmitResponse, error) {
+			attempts <- time.Now()
+			if n.Add(1) == 1 {
+				return nil, errors.New("transmission error")
+			}
+			return &pb.TransmitResponse{Code: 0, Error: ""}, nil
+		}
+		q := newMockQ()
+		s.q = q
+		wg := &sync.WaitGroup{}
+		wg.Add(1)
+
+		go s.runQueueLoop(nil, wg, donIDStr)
+		q.Push(makeSampleTransmission(1), false)
+
+		var times []time.Time
+		for len(times) < 2 {
+			select {
+			case at := <-attempts:
+				times = append(times, at)
+			case <-time.After(testutils.WaitTimeout(t)):
+				t.Fatal("expected the transmission to be retried")
+			}
+		}
+		assert.GreaterOrEqual(t, times[1].Sub(times[0]), minDelay)
+
+		q.Close()
+		wg.Wait()
+	})
+}
+
+func Test_Server_DeadLetters(t *testing.T) {
+	ctx := testutils.Context(t)
+	donIDStr := "555"
+	lggr := logger.TestLogger(t)
+	c := &mocks.MockWSRPCClient{}
+	db := pgtest.NewSqlxDB(t)
+	donID := uint32(123456)
+	orm := NewORM(db, donID)
+	cfg := mockCfg{}
+
+	s := newServer(lggr, true, cfg, c, orm, sURL)
+	require.NotNil(t, s.deadLetters)
+
+	transmit := make(chan *pb.TransmitRequest, 1)
+	c.TransmitF = func(ctx context.Context, in *pb.TransmitRequest) (*pb.TransmitResponse, error) {
+		transmit <- in
+		return &pb.TransmitResponse{Code: 1, Error: "invalid report"}, nil
+	}
+	q := newMockQ()
+	s.q = q
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+
+	go s.runQueueLoop(nil, wg, donIDStr)
+	transmission := makeSampleTransmission(1)
+	q.Push(transmission, false)
+
+	var req *pb.TransmitRequest
+	select {
+	case req = <-transmit:
+	case <-time.After(testutils.WaitTimeout(t)):
+		t.Fatal("expected a transmit request to be sent")
+	}
+	// the server response is still an acknowledgment, so the transmission is not retried
+	select {
+	case hash := <-s.deleteQueue:
+		assert.Equal(t, transmission.Hash(), hash)
+	case <-time.After(testutils.WaitTimeout(t)):
+		t.Fatal("expected the transmission to be deleted")
+	}
+
+	deadLetters, err := s.deadLetters.GetDeadLetters(ctx, sURL)
+	require.NoError(t, err)
+	require.Len(t, deadLetters, 1)
+	assert.Equal(t, transmission.Hash(), deadLetters[0].Hash())
+	assert.Equal(t, req.Payload, deadLetters[0].Payload)
+	assert.Equal(t, int32(1), deadLetters[0].Code)
+	assert.Equal(t, "invalid report", deadLetters[0].Error)
+
+	q.Close()
+	wg.Wait()
+
+	// ORMs without dead letter support are fine too
+	assert.Nil(t, newServer(lggr, true, cfg, c, &batchRecordingORM{}, sURL).deadLetters)
+}
+
+func Test_Server_UnsupportedReportFormat(t *testing.T) {
+	ctx := testutils.Context(t)
+	donIDStr := "555"
+	lggr := logger.TestLogger(t)
+	c := &mocks.MockWSRPCClient{}
+	db := pgtest.NewSqlxDB(t)
+	donID := uint32(123456)
+	orm := NewORM(db, donID)
+	cfg := mockCfg{}
+
+	s := newServer(lggr, true, cfg, c, orm, sURL)
+
+	var transmitCount atomic.Int64
+	c.TransmitF = func(ctx context.Context, in *pb.TransmitRequest) (*pb.TransmitResponse, error) {
+		transmitCount.Add(1)
+		return &pb.TransmitResponse{}, nil
+	}
+	q := newMockQ()
+	s.q = q
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+
+	go s.runQueueLoop(nil, wg, donIDStr)
+	unsupported := makeSampleTransmission(1)
+	unsupported.Report.Info.ReportFormat = llotypes.ReportFormat(255)
+	q.Push(unsupported, false)
+
+	// the transmission can never be encoded, so it is deleted instead of being
+	// re-queued forever
+	select {
+	case hash := <-s.deleteQueue:
+		assert.Equal(t, unsupported.Hash(), hash)
+	case <-time.After(testutils.WaitTimeout(t)):
+		t.Fatal("expected the transmission to be deleted")
+	}
+
+	// and does not block the transmissions queued after it
+	supported := makeSampleTransmission(2)
+	q.Push(supported, false)
+	select {
+	case hash := <-s.deleteQueue:
+		assert.Equal(t, supported.Hash(), hash)
+	case <-time.After(testutils.WaitTimeout(t)):
+		t.Fatal("expected the transmission to be deleted")
+	}
+	assert.Equal(t, int64(1), transmitCount.Load())
+
+	deadLetters, err := s.deadLetters.GetDeadLetters(ctx, sURL)
+	require.NoError(t, err)
+	require.Len(t, deadLetters, 1)
+	assert.Equal(t, unsupported.Hash(), deadLetters[0].Hash())
+	assert.Equal(t, unencodableDeadLetterCode, deadLetters[0].Code)
+	assert.Equal(t, []byte(unsupported.Report.Report), deadLetters[0].Payload)
+	assert.Contains(t, deadLetters[0].Error, "unsupported report format")
+
+	q.Close()
+	wg.Wait()
+}
+
+type transmitTimeoutCfg struct {
+	mockCfg
+	floor float64
+}
+
+func (c transmitTimeoutCfg) TransmitTimeout() commonconfig.Duration {
+	return *commonconfig.MustNewDuration(time.Second)
+}
+func (c transmitTimeoutCfg) TransmitTimeoutFloor() float64 { return c.floor }
+
+func Test_Server_JitteredTransmitTimeout(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	c := &mocks.MockWSRPCClient{}
+	orm := &batchRecordingORM{}
+	timeout := time.Second
+	// WithJitter adds up to 10%
+	maxJitter := timeout / 10
+
+	t.Run("floor is the configured fraction of the timeout", func(t *testing.T) {
+		s := newServer(lggr, true, transmitTimeoutCfg{floor: 0.9}, c, orm, sURL)
+		assert.Equal(t, 900*time.Millisecond, s.transmitTimeoutFloor)
+	})
+
+	t.Run("stays within the floor and the jitter", func(t *testing.T) {
+		s := newServer(lggr, true, transmitTimeoutCfg{floor: 0.95}, c, orm, sURL)
+		floor := 950 * time.Millisecond
+		require.Equal(t, floor, s.transmitTimeoutFloor)
+		for i := 0; i < 10_000; i++ {
+			got := s.jitteredTransmitTimeout()
+			require.GreaterOrEqual(t, got, floor)
+			require.LessOrEqual(t, got, timeout+maxJitter)
+		}
+	})
+
+	t.Run("a floor of the whole timeout only lengthens it", func(t *testing.T) {
+		s := newServer(lggr, true, transmitTimeoutCfg{floor: 1}, c, orm, sURL)
+		for i := 0; i < 10_000; i++ {
+			got := s.jitteredTransmitTimeout()
+			require.GreaterOrEqual(t, got, timeout)
+			require.LessOrEqual(t, got, timeout+maxJitter)
+		}
+	})
+}
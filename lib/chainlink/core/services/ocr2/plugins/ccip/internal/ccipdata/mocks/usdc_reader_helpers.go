@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// USDCMessagesInTx stubs GetUSDCMessagesInTxRange for the transaction with its USDC messages keyed by log index,
+// instead of an expectation per range. Like the reader, a range read returns the messages with a log index in
+// [fromLogIndex, toLogIndex), ordered by log index.
+func (_e *USDCReader_Expecter) USDCMessagesInTx(txHash string, messagesByLogIndex map[int64][]byte) *USDCReader_GetUSDCMessagesInTxRange_Call {
+	logIndexes := slices.Sorted(maps.Keys(messagesByLogIndex))
+	return _e.GetUSDCMessagesInTxRange(mock.Anything, mock.Anything, mock.Anything, txHash).
+		RunAndReturn(func(_ context.Context, fromLogIndex, toLogIndex int64, _ string) ([][]byte, error) {
+			messages := make([][]byte, 0)
+			for _, logIndex := range logIndexes {
+				if logIndex >= fromLogIndex && logIndex < toLogIndex {
+					messages = append(messages, messagesByLogIndex[logIndex])
+				}
+			}
+			return messages, nil
+		})
+}
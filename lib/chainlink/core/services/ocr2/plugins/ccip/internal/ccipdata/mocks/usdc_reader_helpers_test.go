@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+)
+
+func TestUSDCReader_USDCMessagesInTx(t *testing.T) {
+	ctx := testutils.Context(t)
+	reader := NewUSDCReader(t)
+	reader.EXPECT().USDCMessagesInTx("0xtx", map[int64][]byte{
+		7: []byte("third"),
+		2: []byte("first"),
+		5: []byte("second"),
+	})
+
+	messages, err := reader.GetUSDCMessagesInTxRange(ctx, 0, 10, "0xtx")
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte("first"), []byte("second"), []byte("third")}, messages)
+
+	// the upper bound is exclusive
+	messages, err = reader.GetUSDCMessagesInTxRange(ctx, 2, 7, "0xtx")
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte("first"), []byte("second")}, messages)
+
+	messages, err = reader.GetUSDCMessagesInTxRange(ctx, 8, 10, "0xtx")
+	require.NoError(t, err)
+	require.Empty(t, messages)
+}
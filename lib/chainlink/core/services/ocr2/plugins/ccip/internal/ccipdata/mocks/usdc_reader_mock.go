@@ -82,6 +82,67 @@ func (_c *USDCReader_GetUSDCMessagePriorToLogIndexInTx_Call) RunAndReturn(run fu
 	return _c
 }
 
+// GetUSDCMessagesInTxRange provides a mock function with given fields: ctx, fromLogIndex, toLogIndex, txHash
+func (_m *USDCReader) GetUSDCMessagesInTxRange(ctx context.Context, fromLogIndex int64, toLogIndex int64, txHash string) ([][]byte, error) {
+	ret := _m.Called(ctx, fromLogIndex, toLogIndex, txHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUSDCMessagesInTxRange")
+	}
+
+	var r0 [][]byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string) ([][]byte, error)); ok {
+		return rf(ctx, fromLogIndex, toLogIndex, txHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64, string) [][]byte); ok {
+		r0 = rf(ctx, fromLogIndex, toLogIndex, txHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64, string) error); ok {
+		r1 = rf(ctx, fromLogIndex, toLogIndex, txHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// USDCReader_GetUSDCMessagesInTxRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUSDCMessagesInTxRange'
+type USDCReader_GetUSDCMessagesInTxRange_Call struct {
+	*mock.Call
+}
+
+// GetUSDCMessagesInTxRange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fromLogIndex int64
+//   - toLogIndex int64
+//   - txHash string
+func (_e *USDCReader_Expecter) GetUSDCMessagesInTxRange(ctx interface{}, fromLogIndex interface{}, toLogIndex interface{}, txHash interface{}) *USDCReader_GetUSDCMessagesInTxRange_Call {
+	return &USDCReader_GetUSDCMessagesInTxRange_Call{Call: _e.mock.On("GetUSDCMessagesInTxRange", ctx, fromLogIndex, toLogIndex, txHash)}
+}
+
+func (_c *USDCReader_GetUSDCMessagesInTxRange_Call) Run(run func(ctx context.Context, fromLogIndex int64, toLogIndex int64, txHash string)) *USDCReader_GetUSDCMessagesInTxRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *USDCReader_GetUSDCMessagesInTxRange_Call) Return(_a0 [][]byte, _a1 error) *USDCReader_GetUSDCMessagesInTxRange_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *USDCReader_GetUSDCMessagesInTxRange_Call) RunAndReturn(run func(context.Context, int64, int64, string) ([][]byte, error)) *USDCReader_GetUSDCMessagesInTxRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewUSDCReader creates a new instance of USDCReader. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUSDCReader(t interface {
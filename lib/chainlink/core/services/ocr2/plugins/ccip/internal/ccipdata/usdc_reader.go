@@ -37,6 +37,10 @@ type USDCReader interface {
 	// if usdcTokenIndexOffset is 1 we select usdc1
 	// The message logs are found using the provided transaction hash.
 	GetUSDCMessagePriorToLogIndexInTx(ctx context.Context, logIndex int64, usdcTokenIndexOffset int, txHash string) ([]byte, error)
+	// GetUSDCMessagesInTxRange returns the data of all the USDC messages of the provided transaction
+	// with a log index in [fromLogIndex, toLogIndex), ordered by log index.
+	// It allows fetching the USDC messages of several tokens with a single call.
+	GetUSDCMessagesInTxRange(ctx context.Context, fromLogIndex, toLogIndex int64, txHash string) ([][]byte, error)
 }
 
 type USDCReaderImpl struct {
@@ -81,7 +85,9 @@ func parseUSDCMessageSent(logData []byte) ([]byte, error) {
 	return decodeAbiStruct, nil
 }
 
-func (u *USDCReaderImpl) GetUSDCMessagePriorToLogIndexInTx(ctx context.Context, logIndex int64, usdcTokenIndexOffset int, txHash string) ([]byte, error) {
+// getUSDCLogsInTx returns all the USDC message sent logs of the provided tx hash,
+// using the short-lived in-mem cache to avoid fetching them from the log poller repeatedly.
+func (u *USDCReaderImpl) getUSDCLogsInTx(ctx context.Context, txHash string) ([]logpoller.Log, error) {
 	var lpLogs []logpoller.Log
 
 	// fetch all the usdc logs for the provided tx hash
@@ -110,6 +116,14 @@ func (u *USDCReaderImpl) GetUSDCMessagePriorToLogIndexInTx(ctx context.Context,
 		u.shortLivedInMemLogs.Set(k, logs, cache.DefaultExpiration)
 		u.lggr.Debugw("fetched logs from lp", "logs", len(lpLogs))
 	}
+	return lpLogs, nil
+}
+
+func (u *USDCReaderImpl) GetUSDCMessagePriorToLogIndexInTx(ctx context.Context, logIndex int64, usdcTokenIndexOffset int, txHash string) ([]byte, error) {
+	lpLogs, err := u.getUSDCLogsInTx(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
 
 	// collect the logs with log index less than the provided log index
 	allUsdcTokensData := make([][]byte, 0)
@@ -134,6 +148,31 @@ func (u *USDCReaderImpl) GetUSDCMessagePriorToLogIndexInTx(ctx context.Context,
 	return parseUSDCMessageSent(allUsdcTokensData[usdcTokenIndex])
 }
 
+func (u *USDCReaderImpl) GetUSDCMessagesInTxRange(ctx context.Context, fromLogIndex, toLogIndex int64, txHash string) ([][]byte, error) {
+	if fromLogIndex > toLogIndex {
+		return nil, errors.Errorf("invalid log index range [%d, %d)", fromLogIndex, toLogIndex)
+	}
+
+	lpLogs, err := u.getUSDCLogsInTx(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	usdcMessages := make([][]byte, 0)
+	for _, current := range lpLogs {
+		if current.LogIndex < fromLogIndex || current.LogIndex >= toLogIndex {
+			continue
+		}
+		u.lggr.Infow("Found USDC message", "logIndex", current.LogIndex, "txHash", current.TxHash.Hex(), "data", hexutil.Encode(current.Data))
+		parsed, err := parseUSDCMessageSent(current.Data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse usdc message at log index %d", current.LogIndex)
+		}
+		usdcMessages = append(usdcMessages, parsed)
+	}
+	return usdcMessages, nil
+}
+
 func NewUSDCReader(ctx context.Context, lggr logger.Logger, jobID string, transmitter common.Address, lp logpoller.LogPoller, registerFilters bool) (*USDCReaderImpl, error) {
 	eventSig := utils.Keccak256Fixed([]byte("MessageSent(bytes)"))
 
@@ -127,6 +127,79 @@ func TestLogPollerClient_GetUSDCMessagePriorToLogIndexInTx(t *testing.T) {
 	})
 }
 
+func TestLogPollerClient_GetUSDCMessagesInTxRange(t *testing.T) {
+	addr := utils.RandomAddress()
+	txHash := common.BytesToHash(addr[:])
+	ccipLogIndex := int64(100)
+	lggr := logger.Test(t)
+
+	usdcMessage := func(t *testing.T, payload string) []byte {
+		encoded, err := utils.ABIEncode(`[{"type": "bytes"}]`, []byte(payload))
+		require.NoError(t, err)
+		return encoded
+	}
+	lpLogs := []logpoller.Log{
+		{LogIndex: ccipLogIndex - 5, Data: usdcMessage(t, "usdc-1")},
+		{LogIndex: ccipLogIndex - 3, Data: usdcMessage(t, "usdc-2")},
+		{LogIndex: ccipLogIndex - 1, Data: usdcMessage(t, "usdc-3")},
+		{LogIndex: ccipLogIndex + 1, Data: usdcMessage(t, "usdc-4")},
+	}
+
+	t.Run("batched read matches repeated single reads", func(t *testing.T) {
+		ctx := tests.Context(t)
+		lp := lpmocks.NewLogPoller(t)
+		u, _ := NewUSDCReader(ctx, lggr, "job_123", utils.RandomAddress(), lp, false)
+		lp.On("IndexedLogsByTxHash",
+			mock.Anything,
+			u.usdcMessageSent,
+			u.transmitterAddress,
+			txHash,
+		).Return(lpLogs, nil).Once()
+
+		batched, err := u.GetUSDCMessagesInTxRange(ctx, 0, ccipLogIndex, txHash.String())
+		require.NoError(t, err)
+		require.Len(t, batched, 3)
+
+		// single reads select messages backwards from the ccip log index, served from the in-mem cache
+		for offset := 0; offset < len(batched); offset++ {
+			single, err := u.GetUSDCMessagePriorToLogIndexInTx(ctx, ccipLogIndex, offset, txHash.String())
+			require.NoError(t, err)
+			assert.Equal(t, batched[len(batched)-1-offset], single)
+		}
+		assert.Equal(t, []byte("usdc-1"), batched[0])
+		lp.AssertExpectations(t)
+	})
+
+	t.Run("range bounds are applied", func(t *testing.T) {
+		ctx := tests.Context(t)
+		lp := lpmocks.NewLogPoller(t)
+		u, _ := NewUSDCReader(ctx, lggr, "job_123", utils.RandomAddress(), lp, false)
+		lp.On("IndexedLogsByTxHash",
+			mock.Anything,
+			u.usdcMessageSent,
+			u.transmitterAddress,
+			txHash,
+		).Return(lpLogs, nil)
+
+		msgs, err := u.GetUSDCMessagesInTxRange(ctx, ccipLogIndex-3, ccipLogIndex+1, txHash.String())
+		require.NoError(t, err)
+		assert.Equal(t, [][]byte{[]byte("usdc-2"), []byte("usdc-3")}, msgs)
+
+		msgs, err = u.GetUSDCMessagesInTxRange(ctx, ccipLogIndex+2, ccipLogIndex+10, txHash.String())
+		require.NoError(t, err)
+		assert.Empty(t, msgs)
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		ctx := tests.Context(t)
+		lp := lpmocks.NewLogPoller(t)
+		u, _ := NewUSDCReader(ctx, lggr, "job_123", utils.RandomAddress(), lp, false)
+
+		_, err := u.GetUSDCMessagesInTxRange(ctx, ccipLogIndex, ccipLogIndex-1, txHash.String())
+		require.Error(t, err)
+	})
+}
+
 func TestParse(t *testing.T) {
 	expectedBody, err := hexutil.Decode("0x000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000f80000000000000001000000020000000000048d71000000000000000000000000eb08f243e5d3fcff26a9e38ae5520a669f4019d000000000000000000000000023a04d5935ed8bc8e3eb78db3541f0abfb001c6e0000000000000000000000006cb3ed9b441eb674b58495c8b3324b59faff5243000000000000000000000000000000005425890298aed601595a70ab815c96711a31bc65000000000000000000000000ab4f961939bfe6a93567cc57c59eed7084ce2131000000000000000000000000000000000000000000000000000000000000271000000000000000000000000035e08285cfed1ef159236728f843286c55fc08610000000000000000")
 	require.NoError(t, err)
@@ -43,6 +43,11 @@ type PluginConfig struct {
 
 	// Mercury servers
 	Servers map[string]utils.PlainHexBytes `json:"servers" toml:"servers"`
+
+	// ServersQuorum is the number of servers which must receive a report for
+	// it to be considered delivered. If zero, reports are delivered to every
+	// server independently.
+	ServersQuorum uint32 `json:"serversQuorum" toml:"serversQuorum"`
 }
 
 func (p *PluginConfig) Unmarshal(data []byte) error {
@@ -76,6 +81,9 @@ func (p PluginConfig) Validate() (merr error) {
 			}
 		}
 	}
+	if int(p.ServersQuorum) > len(p.Servers) {
+		merr = errors.Join(merr, fmt.Errorf("llo: ServersQuorum must not exceed the number of servers (%d), got: %d", len(p.Servers), p.ServersQuorum))
+	}
 
 	if p.ChannelDefinitions != "" {
 		if p.ChannelDefinitionsContractAddress != (common.Address{}) {
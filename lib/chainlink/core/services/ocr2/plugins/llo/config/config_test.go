@@ -34,6 +34,7 @@ func Test_Config(t *testing.T) {
 		t.Run("with all possible values set", func(t *testing.T) {
 			rawToml := fmt.Sprintf(`
 				Servers = { "example.com:80" = "724ff6eae9e900270edfff233e16322a70ec06e1a6e62a81ef13921f398f6c93", "example2.invalid:1234" = "524ff6eae9e900270edfff233e16322a70ec06e1a6e62a81ef13921f398f6c93" }
+				ServersQuorum = 2
 				BenchmarkMode = true
 				ChannelDefinitionsContractAddress = "0xdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef"
 				ChannelDefinitionsContractFromBlock = 1234
@@ -50,6 +51,7 @@ func Test_Config(t *testing.T) {
 			assert.Equal(t, "0xDeaDbeefdEAdbeefdEadbEEFdeadbeEFdEaDbeeF", mc.ChannelDefinitionsContractAddress.Hex())
 			assert.Equal(t, int64(1234), mc.ChannelDefinitionsContractFromBlock)
 			assert.JSONEq(t, cdjson, mc.ChannelDefinitions)
+			assert.Equal(t, uint32(2), mc.ServersQuorum)
 			assert.True(t, mc.BenchmarkMode)
 
 			err = mc.Validate()
@@ -156,6 +158,16 @@ func Test_PluginConfig_Validate(t *testing.T) {
 		assert.Contains(t, err.Error(), "ServerPubKey must be a 32-byte hex string")
 		assert.Contains(t, err.Error(), "invalid value for ServerURL: llo: invalid value for ServerURL, got: \"not a valid url\"")
 	})
+
+	t.Run("with a quorum exceeding the number of servers", func(t *testing.T) {
+		servers := map[string]utils.PlainHexBytes{
+			"example.com:80": utils.PlainHexBytes([]byte{1, 2, 3}),
+		}
+		pc := PluginConfig{Servers: servers, ServersQuorum: 2}
+
+		err := pc.Validate()
+		assert.Contains(t, err.Error(), "llo: ServersQuorum must not exceed the number of servers (1), got: 2")
+	})
 }
 
 func Test_PluginConfig_GetServers(t *testing.T) {
@@ -2,7 +2,6 @@ package workflow_registry_syncer_test
 
 import (
 	"context"
-	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -40,6 +39,10 @@ func (m *testEvtHandler) Handle(ctx context.Context, event syncer.Event) error {
 	return nil
 }
 
+func (m *testEvtHandler) Close(ctx context.Context) error {
+	return nil
+}
+
 func newTestEvtHandler() *testEvtHandler {
 	return &testEvtHandler{
 		events: make([]syncer.Event, 0),
@@ -84,23 +87,21 @@ func Test_InitialStateSync(t *testing.T) {
 	// that the syncer will query the contract multiple times to get the full list of workflows
 	numberWorkflows := 250
 	for i := 0; i < numberWorkflows; i++ {
-		var workflowID [32]byte
-		_, err = rand.Read((workflowID)[:])
-		require.NoError(t, err)
 		workflow := RegisterWorkflowCMD{
 			Name:       fmt.Sprintf("test-wf-%d", i),
 			DonID:      donID,
 			Status:     uint8(1),
 			SecretsURL: "someurl",
 		}
-		workflow.ID = workflowID
+		workflow.ID = workflowIDFor(t, []byte(fmt.Sprintf("binary-%d", i)), nil, workflow.SecretsURL)
 		registerWorkflow(t, backendTH, wfRegistryC, workflow)
 	}
 
 	testEventHandler := newTestEvtHandler()
-	loader := syncer.NewWorkflowRegistryContractLoader(wfRegistryAddr.Hex(), func(ctx context.Context, bytes []byte) (syncer.ContractReader, error) {
+	loader, err := syncer.NewWorkflowRegistryContractLoader(wfRegistryAddr.Hex(), syncer.MaxWorkflowMetadataPageSize, func(ctx context.Context, bytes []byte) (syncer.ContractReader, error) {
 		return backendTH.NewContractReader(ctx, t, bytes)
 	}, testEventHandler)
+	require.NoError(t, err)
 
 	// Create the worker
 	worker := syncer.NewWorkflowRegistry(
@@ -163,11 +164,7 @@ func Test_SecretsWorker(t *testing.T) {
 
 	defer giveTicker.Stop()
 
-	// fill ID with randomd data
-	var giveID [32]byte
-	_, err := rand.Read((giveID)[:])
-	require.NoError(t, err)
-	giveWorkflow.ID = giveID
+	giveWorkflow.ID = workflowIDFor(t, []byte("binary"), nil, giveSecretsURL)
 
 	// Deploy a test workflow_registry
 	wfRegistryAddr, _, wfRegistryC, err := workflow_registry_wrapper.DeployWorkflowRegistry(backendTH.ContractsOwner, backendTH.Backend.Client())
@@ -223,7 +220,7 @@ func Test_SecretsWorker(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, contents, giveContents)
 
-	handler := syncer.NewEventHandler(lggr, orm, fetcherFn, nil, nil,
+	handler := syncer.NewEventHandler(lggr, orm, syncer.FetcherFunc(fetcherFn), nil, nil,
 		emitter, clockwork.NewFakeClock(), workflowkey.Key{})
 
 	worker := syncer.NewWorkflowRegistry(lggr, func(ctx context.Context, bytes []byte) (syncer.ContractReader, error) {
@@ -297,6 +294,14 @@ func updateAllowedDONs(
 	require.ElementsMatch(t, donIDs, gotDons)
 }
 
+// workflowIDFor returns the workflow ID of the given contents, as expected by the syncer
+func workflowIDFor(t *testing.T, binary, config []byte, secretsURL string) [32]byte {
+	t.Helper()
+	b, err := hex.DecodeString(syncer.ComputeWorkflowID(binary, config, secretsURL))
+	require.NoError(t, err)
+	return [32]byte(b)
+}
+
 type RegisterWorkflowCMD struct {
 	Name       string
 	ID         [32]byte
@@ -569,6 +569,7 @@ func (r *Relayer) NewLLOProvider(ctx context.Context, rargs commontypes.RelayArg
 				FromAccount:    privKey.PublicKey,
 				DonID:          relayConfig.LLODONID,
 				ORM:            mercurytransmitter.NewORM(r.ds, relayConfig.LLODONID),
+				Quorum:         int(lloCfg.ServersQuorum),
 			},
 			RetirementReportCache: r.retirementReportCache,
 		})
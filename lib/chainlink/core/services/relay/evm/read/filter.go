@@ -14,8 +14,14 @@ import (
 
 type Registrar interface {
 	HasFilter(string) bool
+	// HasFilters reports the presence of each of the named filters.
+	HasFilters([]string) map[string]bool
 	RegisterFilter(context.Context, logpoller.Filter) error
+	// RegisterFilters registers all the filters or none of them.
+	RegisterFilters(context.Context, []logpoller.Filter) error
 	UnregisterFilter(context.Context, string) error
+	// UnregisterFilters unregisters all the named filters or none of them.
+	UnregisterFilters(context.Context, []string) error
 }
 
 type syncedFilter struct {
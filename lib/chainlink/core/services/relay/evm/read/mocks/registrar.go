@@ -68,6 +68,54 @@ func (_c *Registrar_HasFilter_Call) RunAndReturn(run func(string) bool) *Registr
 	return _c
 }
 
+// HasFilters provides a mock function with given fields: _a0
+func (_m *Registrar) HasFilters(_a0 []string) map[string]bool {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasFilters")
+	}
+
+	var r0 map[string]bool
+	if rf, ok := ret.Get(0).(func([]string) map[string]bool); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]bool)
+		}
+	}
+
+	return r0
+}
+
+// Registrar_HasFilters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasFilters'
+type Registrar_HasFilters_Call struct {
+	*mock.Call
+}
+
+// HasFilters is a helper method to define mock.On call
+//   - _a0 []string
+func (_e *Registrar_Expecter) HasFilters(_a0 interface{}) *Registrar_HasFilters_Call {
+	return &Registrar_HasFilters_Call{Call: _e.mock.On("HasFilters", _a0)}
+}
+
+func (_c *Registrar_HasFilters_Call) Run(run func(_a0 []string)) *Registrar_HasFilters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string))
+	})
+	return _c
+}
+
+func (_c *Registrar_HasFilters_Call) Return(_a0 map[string]bool) *Registrar_HasFilters_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Registrar_HasFilters_Call) RunAndReturn(run func([]string) map[string]bool) *Registrar_HasFilters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RegisterFilter provides a mock function with given fields: _a0, _a1
 func (_m *Registrar) RegisterFilter(_a0 context.Context, _a1 logpoller.Filter) error {
 	ret := _m.Called(_a0, _a1)
@@ -115,6 +163,53 @@ func (_c *Registrar_RegisterFilter_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// RegisterFilters provides a mock function with given fields: _a0, _a1
+func (_m *Registrar) RegisterFilters(_a0 context.Context, _a1 []logpoller.Filter) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegisterFilters")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []logpoller.Filter) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Registrar_RegisterFilters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegisterFilters'
+type Registrar_RegisterFilters_Call struct {
+	*mock.Call
+}
+
+// RegisterFilters is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 []logpoller.Filter
+func (_e *Registrar_Expecter) RegisterFilters(_a0 interface{}, _a1 interface{}) *Registrar_RegisterFilters_Call {
+	return &Registrar_RegisterFilters_Call{Call: _e.mock.On("RegisterFilters", _a0, _a1)}
+}
+
+func (_c *Registrar_RegisterFilters_Call) Run(run func(_a0 context.Context, _a1 []logpoller.Filter)) *Registrar_RegisterFilters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]logpoller.Filter))
+	})
+	return _c
+}
+
+func (_c *Registrar_RegisterFilters_Call) Return(_a0 error) *Registrar_RegisterFilters_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Registrar_RegisterFilters_Call) RunAndReturn(run func(context.Context, []logpoller.Filter) error) *Registrar_RegisterFilters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UnregisterFilter provides a mock function with given fields: _a0, _a1
 func (_m *Registrar) UnregisterFilter(_a0 context.Context, _a1 string) error {
 	ret := _m.Called(_a0, _a1)
@@ -162,6 +257,53 @@ func (_c *Registrar_UnregisterFilter_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// UnregisterFilters provides a mock function with given fields: _a0, _a1
+func (_m *Registrar) UnregisterFilters(_a0 context.Context, _a1 []string) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnregisterFilters")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Registrar_UnregisterFilters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnregisterFilters'
+type Registrar_UnregisterFilters_Call struct {
+	*mock.Call
+}
+
+// UnregisterFilters is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 []string
+func (_e *Registrar_Expecter) UnregisterFilters(_a0 interface{}, _a1 interface{}) *Registrar_UnregisterFilters_Call {
+	return &Registrar_UnregisterFilters_Call{Call: _e.mock.On("UnregisterFilters", _a0, _a1)}
+}
+
+func (_c *Registrar_UnregisterFilters_Call) Run(run func(_a0 context.Context, _a1 []string)) *Registrar_UnregisterFilters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *Registrar_UnregisterFilters_Call) Return(_a0 error) *Registrar_UnregisterFilters_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Registrar_UnregisterFilters_Call) RunAndReturn(run func(context.Context, []string) error) *Registrar_UnregisterFilters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewRegistrar creates a new instance of Registrar. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRegistrar(t interface {
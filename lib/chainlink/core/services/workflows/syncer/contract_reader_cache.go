@@ -0,0 +1,89 @@
+package syncer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+)
+
+// contractReaderCache holds the contract readers bound by the syncer, keyed by contract address.  Repeated binds to
+// the same address reuse the bound reader instead of creating a new one, which would register its log poller filters
+// again.  A reader is only created and bound again after its binding has been reported as failed.
+type contractReaderCache struct {
+	newContractReaderFn newContractReaderFn
+
+	mu      sync.Mutex
+	readers map[string]ContractReader
+}
+
+func newContractReaderCache(newContractReaderFn newContractReaderFn) *contractReaderCache {
+	return &contractReaderCache{
+		newContractReaderFn: newContractReaderFn,
+		readers:             make(map[string]ContractReader),
+	}
+}
+
+// Get returns the reader bound to the contract, creating and binding one if there is none yet.
+func (c *contractReaderCache) Get(ctx context.Context, bc types.BoundContract) (ContractReader, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if reader, ok := c.readers[bc.Address]; ok {
+		return reader, nil
+	}
+
+	reader, err := getWorkflowRegistryEventReader(ctx, c.newContractReaderFn, bc)
+	if err != nil {
+		return nil, err
+	}
+	c.readers[bc.Address] = reader
+	return reader, nil
+}
+
+// Invalidate drops the reader whose binding to the contract failed, so that the next Get binds a new one, and
+// releases it.  Does nothing if the reader was already replaced.
+func (c *contractReaderCache) Invalidate(ctx context.Context, bc types.BoundContract, failed ContractReader) error {
+	c.mu.Lock()
+	reader, ok := c.readers[bc.Address]
+	if !ok || reader != failed {
+		c.mu.Unlock()
+		return nil
+	}
+	delete(c.readers, bc.Address)
+	c.mu.Unlock()
+
+	return closeContractReader(ctx, reader, bc)
+}
+
+// contractUnbinder is implemented by contract readers which can remove the binding of a contract.
+type contractUnbinder interface {
+	Unbind(ctx context.Context, bindings []types.BoundContract) error
+}
+
+// closeContractReader releases a reader dropped from the cache.  The contract is unbound so that the log poller
+// filters registered by the bind are removed, and the reader is closed.  The syncer does not start its readers, so a
+// reader which was never started is not reported as failing to close.
+func closeContractReader(ctx context.Context, reader ContractReader, bc types.BoundContract) error {
+	var errs error
+	if unbinder, ok := reader.(contractUnbinder); ok {
+		if err := unbinder.Unbind(ctx, []types.BoundContract{bc}); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to unbind contract reader: %w", err))
+		}
+	}
+	if closer, ok := reader.(io.Closer); ok {
+		if err := closer.Close(); err != nil && !errors.Is(err, services.ErrCannotStopUnstarted) {
+			errs = errors.Join(errs, fmt.Errorf("failed to close contract reader: %w", err))
+		}
+	}
+	return errs
+}
+
+// isBindingError reports whether a query failed as the contract is not bound to the reader, which binding a new
+// reader fixes.  Other errors, such as a lost connection to the database, are retried with the same reader.
+func isBindingError(err error) bool {
+	return errors.Is(err, types.ErrInvalidConfig) || errors.Is(err, types.ErrInvalidType)
+}
@@ -0,0 +1,97 @@
+package syncer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+// closingContractReader is a contract reader released by the cache.
+type closingContractReader struct {
+	*MockContractReader
+	unbound atomic.Bool
+	closed  atomic.Bool
+}
+
+func (r *closingContractReader) Unbind(context.Context, []types.BoundContract) error {
+	r.unbound.Store(true)
+	return nil
+}
+
+func (r *closingContractReader) Close() error {
+	r.closed.Store(true)
+	return services.ErrCannotStopUnstarted
+}
+
+func Test_queryEvent_RebindsOnlyAfterBindingFailure(t *testing.T) {
+	var (
+		ctx, cancel = context.WithCancel(testutils.Context(t))
+		lggr        = logger.TestLogger(t)
+		failing     = &closingContractReader{MockContractReader: NewMockContractReader(t)}
+		healthy     = NewMockContractReader(t)
+		created     atomic.Int32
+	)
+	defer cancel()
+
+	// each reader is bound exactly once, the second one replacing the first once its binding failed
+	failing.EXPECT().Bind(mock.Anything, mock.Anything).Return(nil).Once()
+	failing.EXPECT().QueryKey(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]types.Sequence{}, nil).Once()
+	failing.EXPECT().QueryKey(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("connection lost")).Once()
+	failing.EXPECT().QueryKey(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("%w: contract not bound", types.ErrInvalidConfig)).Once()
+	healthy.EXPECT().Bind(mock.Anything, mock.Anything).Return(nil).Once()
+	healthy.EXPECT().QueryKey(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([]types.Sequence{}, nil).Times(2)
+
+	readers := []ContractReader{failing, healthy}
+	cache := newContractReaderCache(func(context.Context, []byte) (ContractReader, error) {
+		n := int(created.Add(1))
+		if n > len(readers) {
+			return nil, errors.New("unexpected rebind")
+		}
+		return readers[n-1], nil
+	})
+
+	var (
+		signal  = make(chan struct{})
+		batchCh = make(chan []WorkflowRegistryEventResponse, 4)
+		done    = make(chan struct{})
+	)
+	go func() {
+		defer close(done)
+		queryEvent(ctx, signal, lggr, cache, "0", queryEventConfig{
+			ContractName:              WorkflowRegistryContractName,
+			ContractAddress:           "0xdeadbeef",
+			WorkflowEventPollerConfig: WorkflowEventPollerConfig{QueryCount: 20},
+		}, ForceUpdateSecretsEvent, batchCh)
+	}()
+
+	// the first poll succeeds, the next two fail without sending a batch, and the following polls succeed
+	for _, wantBatch := range []bool{true, false, false, true, true} {
+		signal <- struct{}{}
+		if wantBatch {
+			<-batchCh
+		}
+	}
+	cancel()
+	<-done
+
+	// the reader is kept on the lost connection, and only replaced and released once its binding failed
+	assert.Equal(t, int32(2), created.Load())
+	assert.True(t, failing.unbound.Load())
+	assert.True(t, failing.closed.Load())
+	require.Empty(t, batchCh)
+}
@@ -2,31 +2,97 @@ package syncer
 
 import (
 	"errors"
+	"maps"
+	"slices"
 	"sync"
-
-	"github.com/smartcontractkit/chainlink/v2/core/services/workflows"
+	"time"
 )
 
+// workflowEngine is the part of the workflow engine lifecycle managed through the registry.
+type workflowEngine interface {
+	Ready() error
+	Close() error
+}
+
 type engineRegistry struct {
-	engines map[string]*workflows.Engine
-	mu      sync.RWMutex
+	engines   map[string]workflowEngine
+	startedAt map[string]time.Time
+	owners    map[string]string
+	mu        sync.RWMutex
 }
 
 func newEngineRegistry() *engineRegistry {
 	return &engineRegistry{
-		engines: make(map[string]*workflows.Engine),
+		engines:   make(map[string]workflowEngine),
+		startedAt: make(map[string]time.Time),
+		owners:    make(map[string]string),
 	}
 }
 
-// Add adds an engine to the registry.
-func (r *engineRegistry) Add(id string, engine *workflows.Engine) {
+// Add adds an engine to the registry, recording the time it was added as its start time.
+func (r *engineRegistry) Add(id string, engine workflowEngine) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.engines[id] = engine
+	r.startedAt[id] = time.Now()
+	delete(r.owners, id)
+}
+
+// AddForOwner adds an engine running a workflow of the given owner to the registry, so that it is counted by
+// CountByOwner.  A reservation made for the workflow by TryReserveForOwner is taken over by the engine.
+func (r *engineRegistry) AddForOwner(id, owner string, engine workflowEngine) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	r.engines[id] = engine
+	r.startedAt[id] = time.Now()
+	r.owners[id] = owner
+}
+
+// TryReserveForOwner reserves a slot for a workflow of the given owner if the owner has fewer than limit engines
+// and reservations, returning that number and whether the slot was reserved.  The check and the reservation are
+// atomic, so concurrent registrations of the same owner cannot exceed the limit.  The reservation is counted by
+// CountByOwner until the engine is added with AddForOwner, or it is released with ReleaseReservation.
+func (r *engineRegistry) TryReserveForOwner(id, owner string, limit int) (int, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := r.countByOwner(owner)
+	if n >= limit {
+		return n, false
+	}
+	r.owners[id] = owner
+	return n, true
+}
+
+// ReleaseReservation releases the reservation made by TryReserveForOwner, unless an engine was added for the
+// workflow since.
+func (r *engineRegistry) ReleaseReservation(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.engines[id]; !ok {
+		delete(r.owners, id)
+	}
+}
+
+// CountByOwner returns the number of engines in the registry running a workflow of the given owner, including the
+// reservations made for the owner.
+func (r *engineRegistry) CountByOwner(owner string) int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.countByOwner(owner)
+}
+
+func (r *engineRegistry) countByOwner(owner string) int {
+	var n int
+	for _, o := range r.owners {
+		if o == owner {
+			n++
+		}
+	}
+	return n
 }
 
 // Get retrieves an engine from the registry.
-func (r *engineRegistry) Get(id string) (*workflows.Engine, error) {
+func (r *engineRegistry) Get(id string) (workflowEngine, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	engine, found := r.engines[id]
@@ -48,8 +114,23 @@ func (r *engineRegistry) IsRunning(id string) bool {
 	return engine.Ready() == nil
 }
 
+// List returns the sorted IDs of the engines in the registry.
+func (r *engineRegistry) List() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return slices.Sorted(maps.Keys(r.engines))
+}
+
+// StartedAt returns the time the engine was added to the registry, and false if it is not found.
+func (r *engineRegistry) StartedAt(id string) (time.Time, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	startedAt, ok := r.startedAt[id]
+	return startedAt, ok
+}
+
 // Pop removes an engine from the registry and returns the engine if found.
-func (r *engineRegistry) Pop(id string) (*workflows.Engine, error) {
+func (r *engineRegistry) Pop(id string) (workflowEngine, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	engine, ok := r.engines[id]
@@ -57,20 +138,29 @@ func (r *engineRegistry) Pop(id string) (*workflows.Engine, error) {
 		return nil, errors.New("remove failed: engine not found")
 	}
 	delete(r.engines, id)
+	delete(r.startedAt, id)
+	delete(r.owners, id)
 	return engine, nil
 }
 
 // Close closes all engines in the registry.
 func (r *engineRegistry) Close() error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+	return r.CloseWith(func(_ string, engine workflowEngine) error {
+		return engine.Close()
+	})
+}
+
+// CloseWith removes the engines from the registry one by one and closes each with closeFn, returning the joined
+// errors.  The registry is not locked while an engine is closed, so a slow engine does not block the other callers.
+func (r *engineRegistry) CloseWith(closeFn func(id string, engine workflowEngine) error) error {
 	var err error
-	for id, engine := range r.engines {
-		closeErr := engine.Close()
-		if closeErr != nil {
-			err = errors.Join(err, closeErr)
+	for _, id := range r.List() {
+		engine, popErr := r.Pop(id)
+		if popErr != nil {
+			// already removed concurrently
+			continue
 		}
-		delete(r.engines, id)
+		err = errors.Join(err, closeFn(id, engine))
 	}
 	return err
 }
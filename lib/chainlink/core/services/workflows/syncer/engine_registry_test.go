@@ -0,0 +1,152 @@
+package syncer
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeEngine struct {
+	readyErr error
+	closeErr error
+	// block delays Close until it is closed
+	block  chan struct{}
+	closed atomic.Bool
+}
+
+func (e *fakeEngine) Ready() error { return e.readyErr }
+
+func (e *fakeEngine) Close() error {
+	if e.block != nil {
+		<-e.block
+	}
+	e.closed.Store(true)
+	return e.closeErr
+}
+
+func Test_engineRegistry_AddPopList(t *testing.T) {
+	er := newEngineRegistry()
+	assert.Empty(t, er.List())
+
+	before := time.Now()
+	er.Add("wf-b", &fakeEngine{})
+	er.Add("wf-a", &fakeEngine{})
+	assert.Equal(t, []string{"wf-a", "wf-b"}, er.List())
+
+	startedAt, ok := er.StartedAt("wf-a")
+	require.True(t, ok)
+	assert.False(t, startedAt.Before(before))
+
+	_, err := er.Pop("wf-a")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"wf-b"}, er.List())
+	_, ok = er.StartedAt("wf-a")
+	assert.False(t, ok)
+
+	_, err = er.Pop("wf-a")
+	require.Error(t, err)
+}
+
+func Test_engineRegistry_CountByOwner(t *testing.T) {
+	er := newEngineRegistry()
+	er.AddForOwner("wf-1", "owner-a", &fakeEngine{})
+	er.AddForOwner("wf-2", "owner-a", &fakeEngine{})
+	er.AddForOwner("wf-3", "owner-b", &fakeEngine{})
+	er.Add("wf-4", &fakeEngine{})
+	assert.Equal(t, 2, er.CountByOwner("owner-a"))
+	assert.Equal(t, 1, er.CountByOwner("owner-b"))
+
+	_, err := er.Pop("wf-1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, er.CountByOwner("owner-a"))
+
+	require.NoError(t, er.Close())
+	assert.Zero(t, er.CountByOwner("owner-a"))
+	assert.Zero(t, er.CountByOwner("owner-b"))
+}
+
+func Test_engineRegistry_TryReserveForOwner(t *testing.T) {
+	const limit, workers = 3, 20
+	er := newEngineRegistry()
+
+	var wg sync.WaitGroup
+	var reserved atomic.Int32
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			if _, ok := er.TryReserveForOwner(fmt.Sprintf("wf-%d", w), "owner-a", limit); ok {
+				reserved.Add(1)
+			}
+		}(w)
+	}
+	wg.Wait()
+	assert.Equal(t, int32(limit), reserved.Load())
+	assert.Equal(t, limit, er.CountByOwner("owner-a"))
+
+	active, ok := er.TryReserveForOwner("wf-other", "owner-a", limit)
+	assert.False(t, ok)
+	assert.Equal(t, limit, active)
+	_, ok = er.TryReserveForOwner("wf-other", "owner-b", limit)
+	assert.True(t, ok)
+
+	// a reservation taken over by an engine is kept, the others are released
+	var ids []string
+	for w := 0; w < workers; w++ {
+		ids = append(ids, fmt.Sprintf("wf-%d", w))
+	}
+	er.AddForOwner(ids[0], "owner-a", &fakeEngine{})
+	for _, id := range ids {
+		er.ReleaseReservation(id)
+	}
+	assert.Equal(t, 1, er.CountByOwner("owner-a"))
+	assert.True(t, er.IsRunning(ids[0]))
+}
+
+func Test_engineRegistry_Concurrent(t *testing.T) {
+	const workers, perWorker = 8, 50
+	er := newEngineRegistry()
+	h := &eventHandler{engineRegistry: er}
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				id := fmt.Sprintf("wf-%d-%d", w, i)
+				er.Add(id, &fakeEngine{})
+				// the snapshot only reports engines with a start time, even while others are added and popped
+				for _, wf := range h.RunningWorkflows() {
+					assert.False(t, wf.StartedAt.IsZero(), "missing start time for %s", wf.WorkflowID)
+				}
+				// pop every other engine
+				if i%2 == 0 {
+					_, err := er.Pop(id)
+					assert.NoError(t, err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	ids := er.List()
+	require.Len(t, ids, workers*perWorker/2)
+	for _, id := range ids {
+		_, ok := er.StartedAt(id)
+		assert.True(t, ok, "missing start time for %s", id)
+	}
+
+	running := h.RunningWorkflows()
+	require.Len(t, running, len(ids))
+	for i, wf := range running {
+		assert.Equal(t, ids[i], wf.WorkflowID)
+		startedAt, _ := er.StartedAt(wf.WorkflowID)
+		assert.Equal(t, startedAt, wf.StartedAt)
+	}
+}
@@ -3,9 +3,12 @@ package syncer
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/v2/core/capabilities/webapi"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
@@ -40,3 +43,78 @@ func NewFetcherFunc(
 		return payload.Body, nil
 	}
 }
+
+const (
+	defaultHTTPFetcherMaxResponseBytes = 20 * 1024 * 1024
+	defaultHTTPFetcherTimeout          = 30 * time.Second
+)
+
+// ErrResponseTooLarge is returned by the HTTP fetcher when the contents at a URL exceed its size limit.
+var ErrResponseTooLarge = errors.New("response exceeds the maximum size")
+
+type HTTPFetcherConfig struct {
+	// MaxResponseBytes is the largest response body accepted, defaults to 20MB.
+	MaxResponseBytes int64
+	// Timeout bounds each fetch, including reading the response body, defaults to 30s.
+	Timeout time.Duration
+}
+
+type httpFetcher struct {
+	lggr   logger.Logger
+	client *http.Client
+	config HTTPFetcherConfig
+}
+
+var _ Fetcher = (*httpFetcher)(nil)
+
+// NewHTTPFetcher returns a Fetcher which downloads the contents at a URL directly over HTTP,
+// rather than through the gateway. The URLs are chosen by workflow owners, so the client
+// should be a restricted one which refuses to dial private and loopback addresses, see
+// utils/http.NewRestrictedHTTPClient.
+func NewHTTPFetcher(lggr logger.Logger, client *http.Client, config HTTPFetcherConfig) Fetcher {
+	if config.MaxResponseBytes <= 0 {
+		config.MaxResponseBytes = defaultHTTPFetcherMaxResponseBytes
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaultHTTPFetcherTimeout
+	}
+	return &httpFetcher{
+		lggr:   lggr.Named("HTTPFetcher"),
+		client: client,
+		config: config,
+	}
+}
+
+func (f *httpFetcher) Fetch(ctx context.Context, url string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fetch request: %w", err)
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("failed to fetch %s: unexpected status %s", url, resp.Status)
+	}
+	if resp.ContentLength > f.config.MaxResponseBytes {
+		return nil, fmt.Errorf("failed to fetch %s: content length %d: %w", url, resp.ContentLength, ErrResponseTooLarge)
+	}
+
+	// read one byte past the limit to tell a body of exactly the limit from a larger one
+	body, err := io.ReadAll(io.LimitReader(resp.Body, f.config.MaxResponseBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response of %s: %w", url, err)
+	}
+	if int64(len(body)) > f.config.MaxResponseBytes {
+		return nil, fmt.Errorf("failed to fetch %s: %w", url, ErrResponseTooLarge)
+	}
+
+	f.lggr.Debugw("fetched contents", "url", url, "size", len(body))
+	return body, nil
+}
@@ -1,10 +1,16 @@
 package syncer
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -15,6 +21,7 @@ import (
 	gcmocks "github.com/smartcontractkit/chainlink/v2/core/services/gateway/connector/mocks"
 	ghcapabilities "github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers/capabilities"
 	"github.com/smartcontractkit/chainlink/v2/core/services/gateway/handlers/common"
+	clhttp "github.com/smartcontractkit/chainlink/v2/core/utils/http"
 )
 
 func TestNewFetcherFunc(t *testing.T) {
@@ -56,6 +63,115 @@ func TestNewFetcherFunc(t *testing.T) {
 	})
 }
 
+func TestNewHTTPFetcher(t *testing.T) {
+	ctx := context.Background()
+	lggr := logger.TestLogger(t)
+
+	const maxResponseBytes = 16
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(bytes.Repeat([]byte("a"), maxResponseBytes))
+	})
+	mux.HandleFunc("/too-large", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(bytes.Repeat([]byte("a"), maxResponseBytes+1))
+	})
+	mux.HandleFunc("/too-large-chunked", func(w http.ResponseWriter, _ *http.Request) {
+		// flushing before the whole body is written omits the Content-Length header
+		_, _ = w.Write([]byte("a"))
+		w.(http.Flusher).Flush()
+		_, _ = w.Write(bytes.Repeat([]byte("a"), maxResponseBytes))
+	})
+	mux.HandleFunc("/not-found", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	// the test server listens on loopback, which the restricted client refuses
+	fetcher := NewHTTPFetcher(lggr, clhttp.NewUnrestrictedHTTPClient(), HTTPFetcherConfig{
+		MaxResponseBytes: maxResponseBytes,
+		Timeout:          100 * time.Millisecond,
+	})
+
+	t.Run("OK-valid_request", func(t *testing.T) {
+		payload, err := fetcher.Fetch(ctx, server.URL+"/ok")
+		require.NoError(t, err)
+		require.Equal(t, bytes.Repeat([]byte("a"), maxResponseBytes), payload)
+	})
+
+	t.Run("NOK-response_too_large", func(t *testing.T) {
+		_, err := fetcher.Fetch(ctx, server.URL+"/too-large")
+		require.ErrorIs(t, err, ErrResponseTooLarge)
+
+		_, err = fetcher.Fetch(ctx, server.URL+"/too-large-chunked")
+		require.ErrorIs(t, err, ErrResponseTooLarge)
+	})
+
+	t.Run("NOK-unexpected_status", func(t *testing.T) {
+		_, err := fetcher.Fetch(ctx, server.URL+"/not-found")
+		require.ErrorContains(t, err, "404")
+	})
+
+	t.Run("NOK-timeout", func(t *testing.T) {
+		_, err := fetcher.Fetch(ctx, server.URL+"/slow")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+type noDBURLConfig struct{}
+
+func (noDBURLConfig) URL() url.URL { return url.URL{} }
+
+func TestNewHTTPFetcher_RestrictedClient(t *testing.T) {
+	ctx := context.Background()
+	lggr := logger.TestLogger(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("secret"))
+	})
+	fetcher := NewHTTPFetcher(lggr, clhttp.NewRestrictedHTTPClient(noDBURLConfig{}, lggr), HTTPFetcherConfig{})
+
+	t.Run("NOK-loopback_address", func(t *testing.T) {
+		server := httptest.NewServer(handler)
+		t.Cleanup(server.Close)
+
+		_, err := fetcher.Fetch(ctx, server.URL)
+		require.ErrorIs(t, err, clhttp.ErrDisallowedIP)
+	})
+
+	t.Run("NOK-private_address", func(t *testing.T) {
+		ip := privateInterfaceIP(t)
+		lis, err := net.Listen("tcp", net.JoinHostPort(ip.String(), "0"))
+		require.NoError(t, err)
+		server := httptest.NewUnstartedServer(handler)
+		server.Listener = lis
+		server.Start()
+		t.Cleanup(server.Close)
+
+		_, err = fetcher.Fetch(ctx, server.URL)
+		require.ErrorIs(t, err, clhttp.ErrDisallowedIP)
+	})
+}
+
+// privateInterfaceIP returns a private IPv4 address of one of the host's interfaces
+func privateInterfaceIP(t *testing.T) net.IP {
+	addrs, err := net.InterfaceAddrs()
+	require.NoError(t, err)
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.To4() != nil && ipNet.IP.IsPrivate() {
+			return ipNet.IP
+		}
+	}
+	t.Skip("no interface with a private IPv4 address")
+	return nil
+}
+
 func gatewayResponse(t *testing.T, msgID string) *api.Message {
 	headers := map[string]string{"Content-Type": "application/json"}
 	body := []byte("response body")
@@ -1,6 +1,7 @@
 package syncer
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
@@ -8,9 +9,12 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/jonboulle/clockwork"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/custmsg"
 	"github.com/smartcontractkit/chainlink-common/pkg/types/core"
@@ -26,6 +30,21 @@ import (
 
 var ErrNotImplemented = errors.New("not implemented")
 
+// ErrWorkflowQuotaExceeded is returned when an owner registers or activates more workflows than are allowed to run at once.
+var ErrWorkflowQuotaExceeded = errors.New("max active workflows per owner exceeded")
+
+var (
+	promStaleSecretsUsed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "workflow_syncer_stale_secrets_used",
+		Help: "Metric to track workflows proceeding with stale secrets because refreshing them failed",
+	}, []string{"workflow_owner", "workflow_name"})
+
+	promSecondsSinceSecretsRefresh = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "workflow_syncer_seconds_since_secrets_refresh",
+		Help: "Metric to track the seconds since the secrets were last successfully fetched, as of their last use",
+	}, []string{"secrets_url_hash"})
+)
+
 // WorkflowRegistryrEventType is the type of event that is emitted by the WorkflowRegistry
 type WorkflowRegistryEventType string
 
@@ -100,6 +119,7 @@ type WorkflowRegistryWorkflowDeletedV1 struct {
 	WorkflowName  string
 }
 
+// lastFetchedAtMap records when the secrets at each URL hash were last fetched.  The zero value is ready to use.
 type lastFetchedAtMap struct {
 	m map[string]time.Time
 	sync.RWMutex
@@ -108,6 +128,9 @@ type lastFetchedAtMap struct {
 func (l *lastFetchedAtMap) Set(url string, at time.Time) {
 	l.Lock()
 	defer l.Unlock()
+	if l.m == nil {
+		l.m = map[string]time.Time{}
+	}
 	l.m[url] = at
 }
 
@@ -124,22 +147,118 @@ func newLastFetchedAtMap() *lastFetchedAtMap {
 	}
 }
 
+// workflowLocks serializes the handling of the events of a workflow, so that for example a pause is not interleaved
+// with the registration of the same workflow.  The zero value is ready to use.
+type workflowLocks struct {
+	// all is held for reading while any workflow is locked, and for writing while all the workflows are locked
+	all   sync.RWMutex
+	mu    sync.Mutex
+	locks map[string]*workflowLock
+}
+
+// workflowLock is the lock of a single workflow, removed from workflowLocks once no caller holds or waits for it.
+type workflowLock struct {
+	sync.Mutex
+	// holders is the number of callers holding or waiting for the lock, guarded by workflowLocks.mu
+	holders int
+}
+
+// lock locks the workflow of the given owner and name, and returns the function unlocking it.
+func (l *workflowLocks) lock(owner []byte, name string) (unlock func()) {
+	key := workflowLockKey(owner, name)
+
+	l.all.RLock()
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*workflowLock)
+	}
+	m, ok := l.locks[key]
+	if !ok {
+		m = &workflowLock{}
+		l.locks[key] = m
+	}
+	m.holders++
+	l.mu.Unlock()
+
+	m.Lock()
+	return func() {
+		m.Unlock()
+
+		l.mu.Lock()
+		m.holders--
+		if m.holders == 0 {
+			delete(l.locks, key)
+		}
+		l.mu.Unlock()
+		l.all.RUnlock()
+	}
+}
+
+// lockAll locks all the workflows once the events being handled are done, and returns the function unlocking them.
+func (l *workflowLocks) lockAll() (unlock func()) {
+	l.all.Lock()
+	return l.all.Unlock
+}
+
+// len returns the number of workflows which are locked or waited for.
+func (l *workflowLocks) len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.locks)
+}
+
+// SecretsDecryptor decrypts the secrets of a workflow owner that are intended for this node.
+type SecretsDecryptor interface {
+	Decrypt(result secrets.EncryptedSecretsResult, workflowOwner string) (map[string]string, error)
+}
+
+type nodeSecretsDecryptor struct {
+	encryptionKey workflowkey.Key
+}
+
+// NewSecretsDecryptor returns a SecretsDecryptor decrypting the secrets with the node's workflow key.
+func NewSecretsDecryptor(encryptionKey workflowkey.Key) SecretsDecryptor {
+	return &nodeSecretsDecryptor{encryptionKey: encryptionKey}
+}
+
+func (d *nodeSecretsDecryptor) Decrypt(result secrets.EncryptedSecretsResult, workflowOwner string) (map[string]string, error) {
+	return secrets.DecryptSecretsForNode(result, d.encryptionKey, workflowOwner)
+}
+
 // eventHandler is a handler for WorkflowRegistryEvent events.  Each event type has a corresponding
 // method that handles the event.
 type eventHandler struct {
 	lggr                     logger.Logger
 	orm                      WorkflowRegistryDS
-	fetcher                  FetcherFunc
+	fetcher                  Fetcher
 	workflowStore            store.Store
 	capRegistry              core.CapabilitiesRegistry
 	engineRegistry           *engineRegistry
+	workflowLocks            workflowLocks
 	emitter                  custmsg.MessageEmitter
 	lastFetchedAtMap         *lastFetchedAtMap
 	clock                    clockwork.Clock
 	secretsFreshnessDuration time.Duration
-	encryptionKey            workflowkey.Key
+	engineCloseTimeout       time.Duration
+	fetchTimeout             time.Duration
+	maxWorkflowsPerOwner     int
+	batchConcurrency         int
+	secretsDecryptor         SecretsDecryptor
+	onEngineTransition       func(wfID string, transition EngineTransition)
+	// donID is the DON of the node, nil until it is known
+	donID atomic.Pointer[uint32]
 }
 
+// EngineTransition is a lifecycle transition of a workflow engine, see WithEngineLifecycleHook.
+type EngineTransition string
+
+const (
+	// EngineStarted is reported once an engine was started and is held by the handler.
+	EngineStarted EngineTransition = "started"
+	// EngineStopped is reported once an engine was removed from the handler and closed, even if closing it failed.
+	EngineStopped EngineTransition = "stopped"
+)
+
 type Event interface {
 	GetEventType() WorkflowRegistryEventType
 	GetData() any
@@ -147,18 +266,31 @@ type Event interface {
 
 var defaultSecretsFreshnessDuration = 24 * time.Hour
 
+// defaultEngineCloseTimeout bounds how long Close waits for each workflow engine to stop.
+var defaultEngineCloseTimeout = 10 * time.Second
+
+// defaultFetchTimeout bounds how long each download of a workflow artifact may take.
+var defaultFetchTimeout = 1 * time.Minute
+
+// defaultBatchConcurrency bounds how many workflows HandleBatch handles the events of at once.
+var defaultBatchConcurrency = 8
+
 // NewEventHandler returns a new eventHandler instance.
 func NewEventHandler(
 	lggr logger.Logger,
 	orm ORM,
-	gateway FetcherFunc,
+	gateway Fetcher,
 	workflowStore store.Store,
 	capRegistry core.CapabilitiesRegistry,
 	emitter custmsg.MessageEmitter,
 	clock clockwork.Clock,
 	encryptionKey workflowkey.Key,
+	opts ...func(*eventHandler),
 ) *eventHandler {
-	return &eventHandler{
+	if clock == nil {
+		clock = clockwork.NewRealClock()
+	}
+	h := &eventHandler{
 		lggr:                     lggr,
 		orm:                      orm,
 		fetcher:                  gateway,
@@ -169,7 +301,81 @@ func NewEventHandler(
 		lastFetchedAtMap:         newLastFetchedAtMap(),
 		clock:                    clock,
 		secretsFreshnessDuration: defaultSecretsFreshnessDuration,
-		encryptionKey:            encryptionKey,
+		engineCloseTimeout:       defaultEngineCloseTimeout,
+		fetchTimeout:             defaultFetchTimeout,
+		batchConcurrency:         defaultBatchConcurrency,
+		secretsDecryptor:         NewSecretsDecryptor(encryptionKey),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// WithSecretsDecryptor replaces the default decryption of the secrets with the node's workflow key.
+func WithSecretsDecryptor(d SecretsDecryptor) func(*eventHandler) {
+	return func(h *eventHandler) {
+		h.secretsDecryptor = d
+	}
+}
+
+// WithDonID sets the DON of the node, see SetDonID.
+func WithDonID(donID uint32) func(*eventHandler) {
+	return func(h *eventHandler) {
+		h.SetDonID(donID)
+	}
+}
+
+// WithMaxWorkflowsPerOwner limits how many workflows of a single owner may be active at once.  Workflows registered
+// or activated beyond the limit are stored as paused instead.  Non-positive limits disable the quota, which is the default.
+func WithMaxWorkflowsPerOwner(n int) func(*eventHandler) {
+	return func(h *eventHandler) {
+		h.maxWorkflowsPerOwner = n
+	}
+}
+
+// WithEngineLifecycleHook sets a callback invoked synchronously each time the handler starts or stops a workflow
+// engine.  It is intended for tests asserting the sequence of engine transitions.
+func WithEngineLifecycleHook(hook func(wfID string, transition EngineTransition)) func(*eventHandler) {
+	return func(h *eventHandler) {
+		h.onEngineTransition = hook
+	}
+}
+
+// WithSecretsFreshness sets how long fetched secrets are used before SecretsFor refreshes them.
+// Non-positive durations are ignored, keeping the default of 24h.
+func WithSecretsFreshness(d time.Duration) func(*eventHandler) {
+	return func(h *eventHandler) {
+		if d <= 0 {
+			h.lggr.Warnf("ignoring non-positive secrets freshness duration %s, using %s", d, h.secretsFreshnessDuration)
+			return
+		}
+		h.secretsFreshnessDuration = d
+	}
+}
+
+// WithFetchTimeout sets how long each download of a workflow binary, config or secrets may take.
+// Non-positive durations are ignored, keeping the default of 1m.
+func WithFetchTimeout(d time.Duration) func(*eventHandler) {
+	return func(h *eventHandler) {
+		if d <= 0 {
+			h.lggr.Warnf("ignoring non-positive fetch timeout %s, using %s", d, h.fetchTimeout)
+			return
+		}
+		h.fetchTimeout = d
+	}
+}
+
+// WithBatchConcurrency sets how many workflows HandleBatch handles the events of at once.
+// Non-positive values are ignored, keeping the default of 8.
+func WithBatchConcurrency(n int) func(*eventHandler) {
+	return func(h *eventHandler) {
+		if n <= 0 {
+			h.lggr.Warnf("ignoring non-positive batch concurrency %d, using %d", n, h.batchConcurrency)
+			return
+		}
+		h.batchConcurrency = n
 	}
 }
 
@@ -227,9 +433,15 @@ func (h *eventHandler) SecretsFor(ctx context.Context, workflowOwner, workflowNa
 				msg,
 				h.lggr,
 			)
+			promStaleSecretsUsed.WithLabelValues(workflowOwner, workflowName).Inc()
 		} else {
 			secretsPayload = updatedSecrets
 		}
+		lastFetchedAt, ok = h.lastFetchedAtMap.Get(secretsURLHash)
+	}
+	// secrets which were never fetched since startup have no age to report
+	if ok {
+		promSecondsSinceSecretsRefresh.WithLabelValues(secretsURLHash).Set(h.clock.Now().Sub(lastFetchedAt).Seconds())
 	}
 
 	res := secrets.EncryptedSecretsResult{}
@@ -238,11 +450,7 @@ func (h *eventHandler) SecretsFor(ctx context.Context, workflowOwner, workflowNa
 		return nil, fmt.Errorf("could not unmarshal secrets: %w", err)
 	}
 
-	return secrets.DecryptSecretsForNode(
-		res,
-		h.encryptionKey,
-		workflowOwner,
-	)
+	return h.secretsDecryptor.Decrypt(res, workflowOwner)
 }
 
 func (h *eventHandler) Handle(ctx context.Context, event Event) error {
@@ -277,6 +485,7 @@ func (h *eventHandler) Handle(ctx context.Context, event Event) error {
 			platform.KeyWorkflowOwner, hex.EncodeToString(payload.Owner),
 		)
 
+		defer h.workflowLocks.lock(payload.Owner, payload.WorkflowName)()
 		if err := h.workflowRegisteredEvent(ctx, payload); err != nil {
 			logCustMsg(ctx, cma, fmt.Sprintf("failed to handle workflow registered event: %v", err), h.lggr)
 			return err
@@ -297,6 +506,7 @@ func (h *eventHandler) Handle(ctx context.Context, event Event) error {
 			platform.KeyWorkflowOwner, hex.EncodeToString(payload.WorkflowOwner),
 		)
 
+		defer h.workflowLocks.lock(payload.WorkflowOwner, payload.WorkflowName)()
 		if err := h.workflowUpdatedEvent(ctx, payload); err != nil {
 			logCustMsg(ctx, cma, fmt.Sprintf("failed to handle workflow updated event: %v", err), h.lggr)
 			return err
@@ -317,6 +527,7 @@ func (h *eventHandler) Handle(ctx context.Context, event Event) error {
 			platform.KeyWorkflowOwner, hex.EncodeToString(payload.WorkflowOwner),
 		)
 
+		defer h.workflowLocks.lock(payload.WorkflowOwner, payload.WorkflowName)()
 		if err := h.workflowPausedEvent(ctx, payload); err != nil {
 			logCustMsg(ctx, cma, fmt.Sprintf("failed to handle workflow paused event: %v", err), h.lggr)
 			return err
@@ -335,6 +546,7 @@ func (h *eventHandler) Handle(ctx context.Context, event Event) error {
 			platform.KeyWorkflowName, payload.WorkflowName,
 			platform.KeyWorkflowOwner, hex.EncodeToString(payload.WorkflowOwner),
 		)
+		defer h.workflowLocks.lock(payload.WorkflowOwner, payload.WorkflowName)()
 		if err := h.workflowActivatedEvent(ctx, payload); err != nil {
 			logCustMsg(ctx, cma, fmt.Sprintf("failed to handle workflow activated event: %v", err), h.lggr)
 			return err
@@ -355,6 +567,7 @@ func (h *eventHandler) Handle(ctx context.Context, event Event) error {
 			platform.KeyWorkflowOwner, hex.EncodeToString(payload.WorkflowOwner),
 		)
 
+		defer h.workflowLocks.lock(payload.WorkflowOwner, payload.WorkflowName)()
 		if err := h.workflowDeletedEvent(ctx, payload); err != nil {
 			logCustMsg(ctx, cma, fmt.Sprintf("failed to handle workflow deleted event: %v", err), h.lggr)
 			return err
@@ -366,6 +579,110 @@ func (h *eventHandler) Handle(ctx context.Context, event Event) error {
 	}
 }
 
+// batchOrder orders the events of a workflow within a batch.  The events keep their order in the batch, except for
+// those which cannot apply yet as the workflow is not registered: they are handled right after its registration, so
+// that for example a workflow activated before it is registered ends up active.  A deletion is never reordered, the
+// events before it are about the deleted workflow and those after it about the workflow registered again.
+func batchOrder(events []Event) []Event {
+	var (
+		ordered    = make([]Event, 0, len(events))
+		pending    []Event
+		registered bool
+	)
+	for _, event := range events {
+		switch event.GetEventType() {
+		case WorkflowRegisteredEvent:
+			ordered = append(append(ordered, event), pending...)
+			pending, registered = nil, true
+		case WorkflowDeletedEvent:
+			ordered = append(append(ordered, pending...), event)
+			pending, registered = nil, false
+		default:
+			if registered {
+				ordered = append(ordered, event)
+			} else {
+				pending = append(pending, event)
+			}
+		}
+	}
+	// without a registration in the batch, the events apply to the workflow registered before
+	return append(ordered, pending...)
+}
+
+// HandleBatch handles a batch of events, such as those of the initial sync with the registry.  The events of each
+// workflow are handled one after another in the order of batchOrder, while the events of different workflows
+// are handled concurrently.  Every event is handled even if others fail, and the errors are joined.
+func (h *eventHandler) HandleBatch(ctx context.Context, events []Event) error {
+	var keys []string
+	groups := make(map[string][]Event)
+	for i, event := range events {
+		key, ok := workflowKey(event)
+		if !ok {
+			// Handle reports the events without a workflow, each is handled on its own
+			key = fmt.Sprintf("event-%d", i)
+		}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], event)
+	}
+
+	concurrency := h.batchConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+	sem := make(chan struct{}, concurrency)
+	// one slot per workflow, so that the joined errors are in the order of the batch
+	errs := make([]error, len(keys))
+	var wg sync.WaitGroup
+	for i, key := range keys {
+		group := batchOrder(groups[key])
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			for _, event := range group {
+				if err := h.Handle(ctx, event); err != nil {
+					errs[i] = errors.Join(errs[i], fmt.Errorf("failed to handle %s event: %w", event.GetEventType(), err))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// workflowKey returns the owner and name of the workflow an event is about, in the form used by workflowLocks.
+func workflowKey(event Event) (string, bool) {
+	var (
+		owner []byte
+		name  string
+	)
+	switch payload := event.GetData().(type) {
+	case WorkflowRegistryForceUpdateSecretsRequestedV1:
+		owner, name = payload.Owner, payload.WorkflowName
+	case WorkflowRegistryWorkflowRegisteredV1:
+		owner, name = payload.Owner, payload.WorkflowName
+	case WorkflowRegistryWorkflowUpdatedV1:
+		owner, name = payload.WorkflowOwner, payload.WorkflowName
+	case WorkflowRegistryWorkflowPausedV1:
+		owner, name = payload.WorkflowOwner, payload.WorkflowName
+	case WorkflowRegistryWorkflowActivatedV1:
+		owner, name = payload.WorkflowOwner, payload.WorkflowName
+	case WorkflowRegistryWorkflowDeletedV1:
+		owner, name = payload.WorkflowOwner, payload.WorkflowName
+	default:
+		return "", false
+	}
+	return workflowLockKey(owner, name), true
+}
+
+func workflowLockKey(owner []byte, name string) string {
+	return hex.EncodeToString(owner) + "/" + name
+}
+
 // workflowRegisteredEvent handles the WorkflowRegisteredEvent event type.
 func (h *eventHandler) workflowRegisteredEvent(
 	ctx context.Context,
@@ -373,59 +690,136 @@ func (h *eventHandler) workflowRegisteredEvent(
 ) error {
 	wfID := hex.EncodeToString(payload.WorkflowID[:])
 
+	// The syncer only loads the workflows of the node's DON, but as a workflow of another DON must never run on this
+	// node, it is skipped here too.
+	if donID := h.donID.Load(); donID != nil && payload.DonID != *donID {
+		cma := h.emitter.With(
+			platform.KeyWorkflowID, wfID,
+			platform.KeyWorkflowName, payload.WorkflowName,
+			platform.KeyWorkflowOwner, hex.EncodeToString(payload.Owner),
+		)
+		logCustMsg(ctx, cma, fmt.Sprintf("workflow skipped: it is assigned to DON %d, not to the DON %d of this node", payload.DonID, *donID), h.lggr)
+		return nil
+	}
+
+	status := job.WorkflowSpecStatusActive
+	if payload.Status == 1 {
+		status = job.WorkflowSpecStatusPaused
+	}
+
+	// The workflow ID is a hash of the workflow contents, so an engine running under the same ID is already running
+	// this workflow, e.g. when the registration is delivered again by a log replay.
+	if status == job.WorkflowSpecStatusActive && h.engineRegistry.IsRunning(wfID) {
+		h.lggr.Debugw("workflow engine already running, skipping duplicate registration", "workflowID", wfID)
+		return nil
+	}
+
+	// The registration carries the latest status of the workflow, so any other engine registered under the same ID
+	// is either superseded by the one started below, or must be stopped as the workflow is paused.
+	if e, err := h.engineRegistry.Pop(wfID); err == nil {
+		h.lggr.Warnw("closing superseded workflow engine", "workflowID", wfID, "status", status)
+		if err := e.Close(); err != nil {
+			h.lggr.Errorw("failed to close superseded workflow engine", "workflowID", wfID, "err", err)
+		}
+		h.engineTransition(wfID, EngineStopped)
+	}
+
+	// No engine is started for a paused workflow, so if it is already stored under the same ID there is nothing to
+	// fetch and it is only marked as paused, e.g. when the workflows are loaded again on a node restart.  Its DON is
+	// stored too, as specs stored before the DON was recorded have none.
+	if status == job.WorkflowSpecStatusPaused {
+		spec, err := h.orm.GetWorkflowSpec(ctx, hex.EncodeToString(payload.Owner), payload.WorkflowName)
+		if err == nil && spec.WorkflowID == wfID {
+			if spec.Status != job.WorkflowSpecStatusPaused || spec.DonID != payload.DonID {
+				spec.Status = job.WorkflowSpecStatusPaused
+				spec.DonID = payload.DonID
+				if _, err := h.orm.UpsertWorkflowSpec(ctx, spec); err != nil {
+					return fmt.Errorf("failed to update workflow spec: %w", err)
+				}
+			}
+			return nil
+		}
+	}
+
 	// Download the contents of binaryURL, configURL and secretsURL and cache them locally.
-	binary, err := h.fetcher(ctx, payload.BinaryURL)
+	binary, err := h.fetch(ctx, payload.BinaryURL)
 	if err != nil {
 		return fmt.Errorf("failed to fetch binary from %s : %w", payload.BinaryURL, err)
 	}
 
-	config, err := h.fetcher(ctx, payload.ConfigURL)
+	config, err := h.fetch(ctx, payload.ConfigURL)
 	if err != nil {
 		return fmt.Errorf("failed to fetch config from %s : %w", payload.ConfigURL, err)
 	}
 
-	secrets, err := h.fetcher(ctx, payload.SecretsURL)
-	if err != nil {
-		return fmt.Errorf("failed to fetch secrets from %s : %w", payload.SecretsURL, err)
+	// Workflows without secrets are registered with an empty secrets URL, there is nothing to fetch then.
+	var secrets []byte
+	if payload.SecretsURL != "" {
+		secrets, err = h.fetch(ctx, payload.SecretsURL)
+		if err != nil {
+			return fmt.Errorf("failed to fetch secrets from %s : %w", payload.SecretsURL, err)
+		}
 	}
 
-	// Calculate the hash of the binary and config files
-	hash := workflowID(binary, config, []byte(payload.SecretsURL))
+	// Calculate the hash of the binary and config files, an empty secrets URL does not contribute to it
+	hash := ComputeWorkflowID(binary, config, payload.SecretsURL)
 
 	// Pre-check: verify that the workflowID matches; if it doesn’t abort and log an error via Beholder.
 	if hash != wfID {
 		return fmt.Errorf("workflowID mismatch: %s != %s", hash, wfID)
 	}
 
-	// Save the workflow secrets
-	urlHash, err := h.orm.GetSecretsURLHash(payload.Owner, []byte(payload.SecretsURL))
-	if err != nil {
-		return fmt.Errorf("failed to get secrets URL hash: %w", err)
+	// Enforce the per-owner quota before storing the spec, the workflow is stored as paused if it is exceeded.  The
+	// slot is reserved atomically so that concurrent registrations of the owner cannot exceed the quota, and is
+	// released again unless the engine is started below.
+	owner := hex.EncodeToString(payload.Owner)
+	var quotaErr error
+	if status == job.WorkflowSpecStatusActive && h.maxWorkflowsPerOwner > 0 {
+		if active, ok := h.engineRegistry.TryReserveForOwner(wfID, owner, h.maxWorkflowsPerOwner); ok {
+			defer h.engineRegistry.ReleaseReservation(wfID)
+		} else {
+			status = job.WorkflowSpecStatusPaused
+			quotaErr = fmt.Errorf("%w: owner %s already has %d active workflows", ErrWorkflowQuotaExceeded, owner, active)
+			cma := h.emitter.With(
+				platform.KeyWorkflowID, wfID,
+				platform.KeyWorkflowName, payload.WorkflowName,
+				platform.KeyWorkflowOwner, owner,
+			)
+			logCustMsg(ctx, cma, fmt.Sprintf("workflow stored as paused: owner has reached the quota of %d active workflows", h.maxWorkflowsPerOwner), h.lggr)
+		}
 	}
 
 	// Create a new entry in the workflow_spec table corresponding for the new workflow, with the contents of the binaryURL + configURL in the table
-	status := job.WorkflowSpecStatusActive
-	if payload.Status == 1 {
-		status = job.WorkflowSpecStatusPaused
-	}
-
 	entry := &job.WorkflowSpec{
 		Workflow:      hex.EncodeToString(binary),
 		Config:        string(config),
 		WorkflowID:    wfID,
 		Status:        status,
-		WorkflowOwner: hex.EncodeToString(payload.Owner),
+		DonID:         payload.DonID,
+		WorkflowOwner: owner,
 		WorkflowName:  payload.WorkflowName,
 		SpecType:      job.WASMFile,
 		BinaryURL:     payload.BinaryURL,
 		ConfigURL:     payload.ConfigURL,
 	}
-	if _, err = h.orm.UpsertWorkflowSpecWithSecrets(ctx, entry, payload.SecretsURL, hex.EncodeToString(urlHash), string(secrets)); err != nil {
-		return fmt.Errorf("failed to upsert workflow spec with secrets: %w", err)
+	if payload.SecretsURL == "" {
+		// Without secrets the spec has no secrets_id, which SecretsFor treats as empty secrets (see ErrEmptySecrets)
+		if _, err = h.orm.UpsertWorkflowSpec(ctx, entry); err != nil {
+			return fmt.Errorf("failed to upsert workflow spec: %w", err)
+		}
+	} else {
+		// Save the workflow secrets
+		urlHash, err := h.orm.GetSecretsURLHash(payload.Owner, []byte(payload.SecretsURL))
+		if err != nil {
+			return fmt.Errorf("failed to get secrets URL hash: %w", err)
+		}
+		if _, err = h.orm.UpsertWorkflowSpecWithSecrets(ctx, entry, payload.SecretsURL, hex.EncodeToString(urlHash), string(secrets)); err != nil {
+			return fmt.Errorf("failed to upsert workflow spec with secrets: %w", err)
+		}
 	}
 
 	if status != job.WorkflowSpecStatusActive {
-		return nil
+		return quotaErr
 	}
 
 	// If status == active, start a new WorkflowEngine instance, and add it to local engine registry
@@ -456,7 +850,8 @@ func (h *eventHandler) workflowRegisteredEvent(
 		return fmt.Errorf("failed to start workflow engine: %w", err)
 	}
 
-	h.engineRegistry.Add(wfID, e)
+	h.engineRegistry.AddForOwner(wfID, owner, e)
+	h.engineTransition(wfID, EngineStarted)
 
 	return nil
 }
@@ -523,8 +918,10 @@ func (h *eventHandler) workflowActivatedEvent(
 		return fmt.Errorf("failed to get workflow spec: %w", err)
 	}
 
+	wfID := hex.EncodeToString(payload.WorkflowID[:])
+
 	// Do nothing if the workflow is already active
-	if spec.Status == job.WorkflowSpecStatusActive && h.engineRegistry.IsRunning(hex.EncodeToString(payload.WorkflowID[:])) {
+	if spec.Status == job.WorkflowSpecStatusActive && h.engineRegistry.IsRunning(wfID) {
 		return nil
 	}
 
@@ -534,6 +931,11 @@ func (h *eventHandler) workflowActivatedEvent(
 		return fmt.Errorf("failed to get secrets URL by ID: %w", err)
 	}
 
+	// Pre-check: verify that the stored workflow still matches the activated workflowID, as is done on registration.
+	if err := verifyWorkflowSpecID(spec, secretsURL, wfID); err != nil {
+		return err
+	}
+
 	// start a new workflow engine
 	registeredEvent := WorkflowRegistryWorkflowRegisteredV1{
 		WorkflowID:   payload.WorkflowID,
@@ -577,8 +979,18 @@ func (h *eventHandler) forceUpdateSecretsEvent(
 		return "", fmt.Errorf("failed to get URL by hash %s : %w", hash, err)
 	}
 
+	// The hash is derived from the owner and the URL, so a stored URL which does not hash back to it
+	// was swapped for another one and must not be fetched from
+	urlHash, err := h.orm.GetSecretsURLHash(payload.Owner, []byte(url))
+	if err != nil {
+		return "", fmt.Errorf("failed to get secrets URL hash: %w", err)
+	}
+	if !bytes.Equal(urlHash, payload.SecretsURLHash) {
+		return "", fmt.Errorf("secrets URL %s of owner %x does not match hash %s", url, payload.Owner, hash)
+	}
+
 	// Fetch the contents of the secrets file from the url via the fetcher
-	secrets, err := h.fetcher(ctx, url)
+	secrets, err := h.fetch(ctx, url)
 	if err != nil {
 		return "", err
 	}
@@ -593,6 +1005,123 @@ func (h *eventHandler) forceUpdateSecretsEvent(
 	return string(secrets), nil
 }
 
+// fetch downloads the contents of url with the fetcher, giving up once the handler's fetch timeout elapses
+// so that a hung download does not block the handling of the event, even if the fetcher ignores the context.
+func (h *eventHandler) fetch(ctx context.Context, url string) ([]byte, error) {
+	timeout := h.fetchTimeout
+	if timeout <= 0 {
+		timeout = defaultFetchTimeout
+	}
+	fetchCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	timedOut := func() bool {
+		return ctx.Err() == nil && errors.Is(fetchCtx.Err(), context.DeadlineExceeded)
+	}
+
+	type result struct {
+		body []byte
+		err  error
+	}
+	// buffered so that the fetch goroutine does not leak once it eventually returns
+	resCh := make(chan result, 1)
+	go func() {
+		body, err := h.fetcher.Fetch(fetchCtx, url)
+		resCh <- result{body, err}
+	}()
+
+	select {
+	case res := <-resCh:
+		if res.err != nil && timedOut() {
+			return nil, fmt.Errorf("fetch timed out after %s: %w", timeout, res.err)
+		}
+		return res.body, res.err
+	case <-fetchCtx.Done():
+		if timedOut() {
+			return nil, fmt.Errorf("fetch timed out after %s: %w", timeout, fetchCtx.Err())
+		}
+		return nil, fetchCtx.Err()
+	}
+}
+
+// Close stops all the running workflow engines so that they release their capability resources on shutdown.
+// Each engine is given at most the handler's engine close timeout to stop, and every engine is closed even if
+// some of them fail.  The workflows are only stopped on this node, their status in the registry is unchanged.
+func (h *eventHandler) Close(ctx context.Context) error {
+	return h.closeEngines(ctx, "node shutdown")
+}
+
+// SetDonID sets the DON of the node, so that workflows registered for another DON are skipped.  Until it is set, the
+// workflows of any DON are started.
+func (h *eventHandler) SetDonID(donID uint32) {
+	h.donID.Store(&donID)
+}
+
+// StopWorkflows stops the engines of all the workflows run by the handler, which are no longer assigned to the node
+// once it moved to another DON.  Their specs are kept, and events keep being handled once the engines are stopped,
+// while they are stopped no event is handled so that no engine is started concurrently.
+func (h *eventHandler) StopWorkflows(ctx context.Context) error {
+	defer h.workflowLocks.lockAll()()
+	return h.closeEngines(ctx, "DON change")
+}
+
+// closeEngines closes all engines held by the handler, reporting the reason in the custom messages emitted for them.
+func (h *eventHandler) closeEngines(ctx context.Context, reason string) error {
+	timeout := h.engineCloseTimeout
+	if timeout <= 0 {
+		timeout = defaultEngineCloseTimeout
+	}
+
+	return h.engineRegistry.CloseWith(func(wfID string, e workflowEngine) error {
+		cma := h.emitter.With(platform.KeyWorkflowID, wfID)
+		err := closeEngine(ctx, e, timeout)
+		h.engineTransition(wfID, EngineStopped)
+		if err != nil {
+			logCustMsg(ctx, cma, fmt.Sprintf("failed to close workflow engine on %s: %v", reason, err), h.lggr)
+			return fmt.Errorf("failed to close workflow engine %s: %w", wfID, err)
+		}
+		logCustMsg(ctx, cma, "workflow engine paused due to "+reason, h.lggr)
+		return nil
+	})
+}
+
+// closeEngine closes the engine, giving up once the timeout elapses or the context is done.
+func closeEngine(ctx context.Context, e workflowEngine, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- e.Close() }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("timed out waiting for engine to close: %w", ctx.Err())
+	}
+}
+
+// RunningWorkflow describes a workflow whose engine is held by the handler.
+type RunningWorkflow struct {
+	WorkflowID string
+	StartedAt  time.Time
+}
+
+// RunningWorkflows returns a snapshot of the workflows whose engines are currently held by the handler,
+// sorted by workflow ID.
+func (h *eventHandler) RunningWorkflows() []RunningWorkflow {
+	ids := h.engineRegistry.List()
+	running := make([]RunningWorkflow, 0, len(ids))
+	for _, id := range ids {
+		// the engine may have been removed since listing
+		startedAt, ok := h.engineRegistry.StartedAt(id)
+		if !ok {
+			continue
+		}
+		running = append(running, RunningWorkflow{WorkflowID: id, StartedAt: startedAt})
+	}
+	return running
+}
+
 // tryEngineCleanup attempts to stop the workflow engine for the given workflow ID.  Does nothing if the
 // workflow engine is not running.
 func (h *eventHandler) tryEngineCleanup(wfID string) error {
@@ -604,19 +1133,42 @@ func (h *eventHandler) tryEngineCleanup(wfID string) error {
 		}
 
 		// Stop the engine
-		if err := e.Close(); err != nil {
+		err = e.Close()
+		h.engineTransition(wfID, EngineStopped)
+		if err != nil {
 			return fmt.Errorf("failed to close workflow engine: %w", err)
 		}
 	}
 	return nil
 }
 
-// workflowID returns a hex encoded sha256 hash of the wasm, config and secretsURL.
-func workflowID(wasm, config, secretsURL []byte) string {
+// engineTransition reports the lifecycle transition of the engine to the hook, if any.
+func (h *eventHandler) engineTransition(wfID string, transition EngineTransition) {
+	if h.onEngineTransition != nil {
+		h.onEngineTransition(wfID, transition)
+	}
+}
+
+// verifyWorkflowSpecID checks that the hash of the stored workflow binary, config and secretsURL matches wfID.
+func verifyWorkflowSpecID(spec *job.WorkflowSpec, secretsURL, wfID string) error {
+	binary, err := hex.DecodeString(spec.Workflow)
+	if err != nil {
+		return fmt.Errorf("failed to decode stored workflow binary: %w", err)
+	}
+
+	if hash := ComputeWorkflowID(binary, []byte(spec.Config), secretsURL); hash != wfID {
+		return fmt.Errorf("workflowID mismatch: %s != %s", hash, wfID)
+	}
+	return nil
+}
+
+// ComputeWorkflowID returns the hex encoded sha256 hash of the wasm binary, config and secretsURL.
+// It must match the workflow ID the workflow is registered with on the workflow registry.
+func ComputeWorkflowID(binary, config []byte, secretsURL string) string {
 	sum := sha256.New()
-	sum.Write(wasm)
+	sum.Write(binary)
 	sum.Write(config)
-	sum.Write(secretsURL)
+	sum.Write([]byte(secretsURL))
 	return hex.EncodeToString(sum.Sum(nil))
 }
 
@@ -6,10 +6,14 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	commoncap "github.com/smartcontractkit/chainlink-common/pkg/capabilities"
 	"github.com/smartcontractkit/chainlink-common/pkg/custmsg"
+	"github.com/smartcontractkit/chainlink-common/pkg/types"
+	"github.com/smartcontractkit/chainlink-common/pkg/types/query/primitives"
 	"github.com/smartcontractkit/chainlink-common/pkg/workflows/secrets"
 	"github.com/smartcontractkit/chainlink/v2/core/capabilities"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
@@ -23,8 +27,11 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/utils/crypto"
 	"github.com/smartcontractkit/chainlink/v2/core/utils/matches"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/jonboulle/clockwork"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -45,6 +52,144 @@ func newMockFetcher(m map[string]mockFetchResp) FetcherFunc {
 	return (&mockFetcher{responseMap: m}).Fetch
 }
 
+const (
+	testBinaryURL = "http://example.com/binary"
+	testConfigURL = "http://example.com/config"
+)
+
+// testWorkflows serves the workflows of a test, which share the test binary and an empty config and are told apart by
+// their secrets URLs.
+type testWorkflows struct {
+	db        *sqlx.DB
+	orm       *orm
+	owner     []byte
+	binary    []byte
+	config    []byte
+	responses map[string]mockFetchResp
+	fetcher   FetcherFunc
+}
+
+func newTestWorkflows(t *testing.T) *testWorkflows {
+	var (
+		lggr      = logger.TestLogger(t)
+		db        = pgtest.NewSqlxDB(t)
+		binary    = wasmtest.CreateTestBinary(binaryCmd, binaryLocation, true, t)
+		config    = []byte("")
+		responses = map[string]mockFetchResp{
+			testBinaryURL: {Body: binary, Err: nil},
+			testConfigURL: {Body: config, Err: nil},
+		}
+	)
+	return &testWorkflows{
+		db:        db,
+		orm:       NewWorkflowRegistryDS(db, lggr),
+		owner:     []byte("0xOwner"),
+		binary:    binary,
+		config:    config,
+		responses: responses,
+		fetcher:   newMockFetcher(responses),
+	}
+}
+
+// registered returns the registration of a workflow whose secrets are served from secretsURL, the workflow has no
+// secrets if secretsURL is empty.
+func (w *testWorkflows) registered(t *testing.T, name string, status uint8, donID uint32, secretsURL string) WorkflowRegistryWorkflowRegisteredV1 {
+	if secretsURL != "" {
+		w.responses[secretsURL] = mockFetchResp{Body: []byte("secrets"), Err: nil}
+	}
+	wfID, err := hex.DecodeString(ComputeWorkflowID(w.binary, w.config, secretsURL))
+	require.NoError(t, err)
+	return WorkflowRegistryWorkflowRegisteredV1{
+		Status:       status,
+		WorkflowID:   [32]byte(wfID),
+		Owner:        w.owner,
+		DonID:        donID,
+		WorkflowName: name,
+		BinaryURL:    testBinaryURL,
+		ConfigURL:    testConfigURL,
+		SecretsURL:   secretsURL,
+	}
+}
+
+// newHandler returns an event handler able to start the engines of the workflows.
+func (w *testWorkflows) newHandler(t *testing.T, opts ...func(*eventHandler)) *eventHandler {
+	lggr := logger.TestLogger(t)
+	registry := capabilities.NewRegistry(lggr)
+	registry.SetLocalRegistry(&capabilities.TestMetadataRegistry{})
+	store := wfstore.NewDBStore(w.db, lggr, clockwork.NewFakeClock())
+	return NewEventHandler(lggr, w.orm, w.fetcher, store, registry, custmsg.NewLabeler(), clockwork.NewFakeClock(),
+		workflowkey.Key{}, opts...)
+}
+
+// secretsForOwner owns the workflow of secretsForFixture.
+var secretsForOwner = hex.EncodeToString([]byte("anOwner"))
+
+// secretsForFixture is a stored workflow spec whose secrets are read by SecretsFor.
+type secretsForFixture struct {
+	db           *sqlx.DB
+	orm          *orm
+	workflowName string
+	workflowID   string
+	url          string
+	hash         string
+	fetcher      *mockFetcher
+}
+
+// newSecretsForFixture stores the workflow spec with the secrets payload, which is also served from the secrets URL.
+func newSecretsForFixture(t *testing.T, workflowName string, secretsPayload []byte) *secretsForFixture {
+	lggr := logger.TestLogger(t)
+	db := pgtest.NewSqlxDB(t)
+	f := &secretsForFixture{
+		db:           db,
+		orm:          &orm{ds: db, lggr: lggr},
+		workflowName: workflowName,
+		workflowID:   "anID",
+		url:          "http://example.com",
+	}
+
+	urlHash, err := f.orm.GetSecretsURLHash([]byte("anOwner"), []byte(f.url))
+	require.NoError(t, err)
+	f.hash = hex.EncodeToString(urlHash)
+	secretsID, err := f.orm.Create(testutils.Context(t), f.url, f.hash, string(secretsPayload))
+	require.NoError(t, err)
+
+	_, err = f.orm.UpsertWorkflowSpec(testutils.Context(t), &job.WorkflowSpec{
+		SecretsID:     sql.NullInt64{Int64: secretsID, Valid: true},
+		WorkflowID:    f.workflowID,
+		WorkflowOwner: secretsForOwner,
+		WorkflowName:  workflowName,
+		CreatedAt:     time.Now(),
+		SpecType:      job.DefaultSpecType,
+	})
+	require.NoError(t, err)
+
+	f.fetcher = &mockFetcher{
+		responseMap: map[string]mockFetchResp{
+			f.url: {Body: secretsPayload},
+		},
+	}
+	return f
+}
+
+func (f *secretsForFixture) newHandler(t *testing.T, clock clockwork.Clock, encryptionKey workflowkey.Key, opts ...func(*eventHandler)) *eventHandler {
+	lggr := logger.TestLogger(t)
+	return NewEventHandler(
+		lggr,
+		f.orm,
+		f.fetcher,
+		wfstore.NewDBStore(f.db, lggr, clockwork.NewFakeClock()),
+		capabilities.NewRegistry(lggr),
+		custmsg.NewLabeler(),
+		clock,
+		encryptionKey,
+		opts...,
+	)
+}
+
+func (f *secretsForFixture) secretsFor(t *testing.T, h *eventHandler) (map[string]string, error) {
+	return h.SecretsFor(testutils.Context(t), secretsForOwner, f.workflowName, f.workflowID)
+}
+
 func Test_Handler(t *testing.T) {
 	lggr := logger.TestLogger(t)
 	emitter := custmsg.NewLabeler()
@@ -64,10 +209,11 @@ func Test_Handler(t *testing.T) {
 			},
 		}
 
-		fetcher := func(_ context.Context, _ string) ([]byte, error) {
+		var fetcher FetcherFunc = func(_ context.Context, _ string) ([]byte, error) {
 			return []byte("contents"), nil
 		}
 		mockORM.EXPECT().GetSecretsURLByHash(matches.AnyContext, giveHash).Return(giveURL, nil)
+		mockORM.EXPECT().GetSecretsURLHash([]byte(nil), []byte(giveURL)).Return(giveBytes, nil)
 		mockORM.EXPECT().Update(matches.AnyContext, giveHash, "contents").Return(int64(1), nil)
 		h := NewEventHandler(lggr, mockORM, fetcher, nil, nil, emitter, clockwork.NewFakeClock(), workflowkey.Key{})
 		err = h.Handle(ctx, giveEvent)
@@ -79,7 +225,7 @@ func Test_Handler(t *testing.T) {
 		ctx := testutils.Context(t)
 
 		giveEvent := WorkflowRegistryEvent{}
-		fetcher := func(_ context.Context, _ string) ([]byte, error) {
+		var fetcher FetcherFunc = func(_ context.Context, _ string) ([]byte, error) {
 			return []byte("contents"), nil
 		}
 
@@ -128,10 +274,11 @@ func Test_Handler(t *testing.T) {
 			},
 		}
 
-		fetcher := func(_ context.Context, _ string) ([]byte, error) {
+		var fetcher FetcherFunc = func(_ context.Context, _ string) ([]byte, error) {
 			return nil, assert.AnError
 		}
 		mockORM.EXPECT().GetSecretsURLByHash(matches.AnyContext, giveHash).Return(giveURL, nil)
+		mockORM.EXPECT().GetSecretsURLHash([]byte(nil), []byte(giveURL)).Return(giveBytes, nil)
 		h := NewEventHandler(lggr, mockORM, fetcher, nil, nil, emitter, clockwork.NewFakeClock(), workflowkey.Key{})
 		err = h.Handle(ctx, giveEvent)
 		require.Error(t, err)
@@ -154,16 +301,49 @@ func Test_Handler(t *testing.T) {
 			},
 		}
 
-		fetcher := func(_ context.Context, _ string) ([]byte, error) {
+		var fetcher FetcherFunc = func(_ context.Context, _ string) ([]byte, error) {
 			return []byte("contents"), nil
 		}
 		mockORM.EXPECT().GetSecretsURLByHash(matches.AnyContext, giveHash).Return(giveURL, nil)
+		mockORM.EXPECT().GetSecretsURLHash([]byte(nil), []byte(giveURL)).Return(giveBytes, nil)
 		mockORM.EXPECT().Update(matches.AnyContext, giveHash, "contents").Return(0, assert.AnError)
 		h := NewEventHandler(lggr, mockORM, fetcher, nil, nil, emitter, clockwork.NewFakeClock(), workflowkey.Key{})
 		err = h.Handle(ctx, giveEvent)
 		require.Error(t, err)
 		require.ErrorIs(t, err, assert.AnError)
 	})
+
+	t.Run("fails if the secrets url does not match its hash", func(t *testing.T) {
+		mockORM := mocks.NewORM(t)
+		ctx := testutils.Context(t)
+		giveOwner := []byte("0xOwner")
+		giveURL := "https://original-url.com"
+		swappedURL := "https://swapped-url.com"
+		giveBytes, err := crypto.Keccak256(append(giveOwner, []byte(giveURL)...))
+		require.NoError(t, err)
+		swappedBytes, err := crypto.Keccak256(append(giveOwner, []byte(swappedURL)...))
+		require.NoError(t, err)
+
+		giveHash := hex.EncodeToString(giveBytes)
+
+		giveEvent := WorkflowRegistryEvent{
+			EventType: ForceUpdateSecretsEvent,
+			Data: WorkflowRegistryForceUpdateSecretsRequestedV1{
+				SecretsURLHash: giveBytes,
+				Owner:          giveOwner,
+			},
+		}
+
+		var fetcher FetcherFunc = func(_ context.Context, _ string) ([]byte, error) {
+			return []byte("unexpected contents"), nil
+		}
+		mockORM.EXPECT().GetSecretsURLByHash(matches.AnyContext, giveHash).Return(swappedURL, nil)
+		mockORM.EXPECT().GetSecretsURLHash(giveOwner, []byte(swappedURL)).Return(swappedBytes, nil)
+		h := NewEventHandler(lggr, mockORM, fetcher, nil, nil, emitter, clockwork.NewFakeClock(), workflowkey.Key{})
+		err = h.Handle(ctx, giveEvent)
+		require.ErrorContains(t, err, "does not match hash")
+		mockORM.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
 }
 
 const (
@@ -171,6 +351,92 @@ const (
 	binaryCmd      = "core/capabilities/compute/test/simple/cmd"
 )
 
+func Test_workflowLocks(t *testing.T) {
+	var l workflowLocks
+	owner := []byte("0xOwner")
+
+	unlock := l.lock(owner, "workflow-1")
+	assert.Equal(t, 1, l.len())
+
+	// a second caller waits for the lock, which keeps it alive while the first caller releases it
+	locked := make(chan func())
+	go func() { locked <- l.lock(owner, "workflow-1") }()
+	require.Eventually(t, func() bool {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		return l.locks[workflowLockKey(owner, "workflow-1")].holders == 2
+	}, testutils.WaitTimeout(t), 10*time.Millisecond)
+	unlock()
+	unlock = <-locked
+	assert.Equal(t, 1, l.len())
+
+	// the lock is removed once it is released by its last holder
+	unlock()
+	assert.Equal(t, 0, l.len())
+	l.lock(owner, "workflow-2")()
+	assert.Equal(t, 0, l.len())
+
+	// all the workflows are locked once the workflow being locked is unlocked
+	unlock = l.lock(owner, "workflow-1")
+	lockedAll := make(chan func())
+	go func() { lockedAll <- l.lockAll() }()
+	select {
+	case <-lockedAll:
+		t.Fatal("all the workflows were locked while a workflow was locked")
+	case <-time.After(100 * time.Millisecond):
+	}
+	unlock()
+	(<-lockedAll)()
+	assert.Equal(t, 0, l.len())
+}
+
+func Test_ComputeWorkflowID(t *testing.T) {
+	var (
+		binary     = []byte("wasm-binary")
+		config     = []byte(`{"key":"value"}`)
+		secretsURL = "https://example.com/secrets.json"
+		// sha256 of binary || config || secretsURL
+		wantID = "5713dd25296ebae1cb66a7169f9713b1417dec4d2aefc429cd2809d3188c0ed2"
+	)
+
+	assert.Equal(t, wantID, ComputeWorkflowID(binary, config, secretsURL))
+	// sha256 of no input at all
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", ComputeWorkflowID(nil, nil, ""))
+	assert.NotEqual(t, wantID, ComputeWorkflowID(binary, config, secretsURL+"?v=2"))
+
+	t.Run("registration is rejected when the contents do not match the workflow ID", func(t *testing.T) {
+		var (
+			ctx       = testutils.Context(t)
+			binaryURL = "http://example.com/binary"
+			configURL = "http://example.com/config"
+			tampered  = []byte(`{"key":"other"}`)
+		)
+		h := &eventHandler{
+			lggr: logger.TestLogger(t),
+			fetcher: newMockFetcher(map[string]mockFetchResp{
+				binaryURL:  {Body: binary},
+				configURL:  {Body: tampered},
+				secretsURL: {Body: []byte("secrets")},
+			}),
+			engineRegistry: newEngineRegistry(),
+		}
+		wfID, err := hex.DecodeString(wantID)
+		require.NoError(t, err)
+
+		// the workflow is registered as active, a paused one would first be looked up in the database
+		err = h.workflowRegisteredEvent(ctx, WorkflowRegistryWorkflowRegisteredV1{
+			Status:       uint8(0),
+			WorkflowID:   [32]byte(wfID),
+			Owner:        []byte("0xOwner"),
+			WorkflowName: "workflow-name",
+			BinaryURL:    binaryURL,
+			ConfigURL:    configURL,
+			SecretsURL:   secretsURL,
+		})
+		require.EqualError(t, err, fmt.Sprintf("workflowID mismatch: %s != %s", ComputeWorkflowID(binary, tampered, secretsURL), wantID))
+	})
+}
+
 func Test_workflowRegisteredHandler(t *testing.T) {
 	t.Run("success with paused workflow registered", func(t *testing.T) {
 		var (
@@ -194,7 +460,7 @@ func Test_workflowRegisteredHandler(t *testing.T) {
 			})
 		)
 
-		giveWFID := workflowID(binary, config, []byte(secretsURL))
+		giveWFID := ComputeWorkflowID(binary, config, secretsURL)
 
 		b, err := hex.DecodeString(giveWFID)
 		require.NoError(t, err)
@@ -250,7 +516,7 @@ func Test_workflowRegisteredHandler(t *testing.T) {
 			})
 		)
 
-		giveWFID := workflowID(binary, config, []byte(secretsURL))
+		giveWFID := ComputeWorkflowID(binary, config, secretsURL)
 
 		b, err := hex.DecodeString(giveWFID)
 		require.NoError(t, err)
@@ -296,6 +562,324 @@ func Test_workflowRegisteredHandler(t *testing.T) {
 		err = engine.Ready()
 		require.NoError(t, err)
 	})
+
+	t.Run("success with active workflow registered without secrets", func(t *testing.T) {
+		ctx := testutils.Context(t)
+		// no secrets URL is served, fetching it would fail the registration
+		w := newTestWorkflows(t)
+		active := w.registered(t, "workflow-name", 0, 0, "")
+		giveWFID := hex.EncodeToString(active.WorkflowID[:])
+
+		h := w.newHandler(t)
+		err := h.workflowRegisteredEvent(ctx, active)
+		require.NoError(t, err)
+
+		// Verify the record is stored without secrets
+		dbSpec, err := w.orm.GetWorkflowSpec(ctx, hex.EncodeToString(w.owner), "workflow-name")
+		require.NoError(t, err)
+		require.Equal(t, job.WorkflowSpecStatusActive, dbSpec.Status)
+		require.False(t, dbSpec.SecretsID.Valid)
+
+		secrets, err := h.SecretsFor(ctx, hex.EncodeToString(w.owner), "workflow-name", giveWFID)
+		require.NoError(t, err)
+		require.Empty(t, secrets)
+
+		// Verify the engine is started
+		engine, err := h.engineRegistry.Get(giveWFID)
+		require.NoError(t, err)
+		err = engine.Ready()
+		require.NoError(t, err)
+	})
+
+	t.Run("active workflows beyond the owner quota are stored as paused", func(t *testing.T) {
+		ctx := testutils.Context(t)
+		w := newTestWorkflows(t)
+		quota := 2
+
+		// the secrets URLs differ so that each workflow has its own ID
+		events := make([]WorkflowRegistryWorkflowRegisteredV1, quota+1)
+		for i := range events {
+			events[i] = w.registered(t, fmt.Sprintf("workflow-name-%d", i), 0, 0, fmt.Sprintf("http://example.com/secrets/%d", i))
+		}
+
+		h := w.newHandler(t, WithMaxWorkflowsPerOwner(quota))
+
+		for _, event := range events[:quota] {
+			require.NoError(t, h.workflowRegisteredEvent(ctx, event))
+			require.True(t, h.engineRegistry.IsRunning(hex.EncodeToString(event.WorkflowID[:])))
+		}
+
+		over := events[quota]
+		err := h.workflowRegisteredEvent(ctx, over)
+		require.ErrorIs(t, err, ErrWorkflowQuotaExceeded)
+		require.False(t, h.engineRegistry.IsRunning(hex.EncodeToString(over.WorkflowID[:])))
+		require.Equal(t, quota, h.engineRegistry.CountByOwner(hex.EncodeToString(w.owner)))
+
+		// the workflow is stored as paused so that it can be activated once the owner is below the quota
+		dbSpec, err := w.orm.GetWorkflowSpec(ctx, hex.EncodeToString(w.owner), over.WorkflowName)
+		require.NoError(t, err)
+		require.Equal(t, job.WorkflowSpecStatusPaused, dbSpec.Status)
+	})
+
+	t.Run("concurrent registrations do not exceed the owner quota", func(t *testing.T) {
+		ctx := testutils.Context(t)
+		w := newTestWorkflows(t)
+		quota, registrations := 2, 6
+
+		events := make([]Event, registrations)
+		for i := range events {
+			events[i] = WorkflowRegistryEvent{
+				EventType: WorkflowRegisteredEvent,
+				Data:      w.registered(t, fmt.Sprintf("workflow-name-%d", i), 0, 0, fmt.Sprintf("http://example.com/secrets/%d", i)),
+			}
+		}
+
+		h := w.newHandler(t, WithMaxWorkflowsPerOwner(quota), WithBatchConcurrency(registrations))
+		t.Cleanup(func() { _ = h.Close(testutils.Context(t)) })
+
+		err := h.HandleBatch(ctx, events)
+		require.ErrorIs(t, err, ErrWorkflowQuotaExceeded)
+		require.Len(t, h.engineRegistry.List(), quota)
+		require.Equal(t, quota, h.engineRegistry.CountByOwner(hex.EncodeToString(w.owner)))
+
+		var active, paused int
+		for i := range events {
+			dbSpec, err := w.orm.GetWorkflowSpec(ctx, hex.EncodeToString(w.owner), fmt.Sprintf("workflow-name-%d", i))
+			require.NoError(t, err)
+			switch dbSpec.Status {
+			case job.WorkflowSpecStatusActive:
+				active++
+			case job.WorkflowSpecStatusPaused:
+				paused++
+			}
+		}
+		require.Equal(t, quota, active)
+		require.Equal(t, registrations-quota, paused)
+	})
+
+	t.Run("duplicate active workflow registration runs a single engine", func(t *testing.T) {
+		ctx := testutils.Context(t)
+		w := newTestWorkflows(t)
+		active := w.registered(t, "workflow-name", 0, 0, "http://example.com")
+		giveWFID := hex.EncodeToString(active.WorkflowID[:])
+
+		var fetches int
+		fetch := w.fetcher
+		w.fetcher = func(ctx context.Context, url string) ([]byte, error) {
+			fetches++
+			return fetch(ctx, url)
+		}
+		h := w.newHandler(t)
+
+		// an engine which stopped running is superseded by the registration
+		stopped := &fakeEngine{readyErr: errors.New("stopped")}
+		h.engineRegistry.Add(giveWFID, stopped)
+
+		require.NoError(t, h.workflowRegisteredEvent(ctx, active))
+		assert.True(t, stopped.closed.Load())
+		assert.Equal(t, 3, fetches)
+
+		engine, err := h.engineRegistry.Get(giveWFID)
+		require.NoError(t, err)
+		require.NoError(t, engine.Ready())
+		t.Cleanup(func() { _ = engine.Close() })
+
+		// the replayed registration is a no-op, the original engine keeps running
+		require.NoError(t, h.workflowRegisteredEvent(ctx, active))
+		assert.Equal(t, 3, fetches)
+		assert.Equal(t, []string{giveWFID}, h.engineRegistry.List())
+		duplicate, err := h.engineRegistry.Get(giveWFID)
+		require.NoError(t, err)
+		assert.Same(t, engine, duplicate)
+		require.NoError(t, engine.Ready())
+	})
+}
+
+func Test_workflowRegisteredHandler_StoresDonOfStoredPausedWorkflow(t *testing.T) {
+	ctx := testutils.Context(t)
+	w := newTestWorkflows(t)
+	h := w.newHandler(t)
+
+	// a workflow stored before its DON was recorded has DON 0
+	paused := w.registered(t, "workflow-name", 1, 0, "http://example.com")
+	require.NoError(t, h.workflowRegisteredEvent(ctx, paused))
+	specs, err := w.orm.ListWorkflowSpecsByStatus(ctx, 1, job.WorkflowSpecStatusPaused)
+	require.NoError(t, err)
+	require.Empty(t, specs)
+
+	// loading the workflow again stores its DON
+	paused.DonID = 1
+	require.NoError(t, h.workflowRegisteredEvent(ctx, paused))
+	specs, err = w.orm.ListWorkflowSpecsByStatus(ctx, 1, job.WorkflowSpecStatusPaused)
+	require.NoError(t, err)
+	require.Len(t, specs, 1)
+	assert.Equal(t, hex.EncodeToString(paused.WorkflowID[:]), specs[0].WorkflowID)
+}
+
+func Test_workflowRegisteredHandler_Ordering(t *testing.T) {
+	newHandler := func(t *testing.T) (*eventHandler, *testWorkflows) {
+		w := newTestWorkflows(t)
+		h := w.newHandler(t)
+		t.Cleanup(func() { _ = h.Close(testutils.Context(t)) })
+		return h, w
+	}
+
+	registered := func(t *testing.T, w *testWorkflows, status uint8) Event {
+		return WorkflowRegistryEvent{
+			EventType: WorkflowRegisteredEvent,
+			Data:      w.registered(t, "workflow-name", status, 0, "http://example.com"),
+		}
+	}
+	paused := func(t *testing.T, w *testWorkflows) Event {
+		return WorkflowRegistryEvent{
+			EventType: WorkflowPausedEvent,
+			Data: WorkflowRegistryWorkflowPausedV1{
+				WorkflowID:    w.registered(t, "workflow-name", 1, 0, "http://example.com").WorkflowID,
+				WorkflowOwner: w.owner,
+				WorkflowName:  "workflow-name",
+				DonID:         1,
+			},
+		}
+	}
+
+	requireStatus := func(t *testing.T, h *eventHandler, w *testWorkflows, status job.WorkflowSpecStatus) {
+		dbSpec, err := w.orm.GetWorkflowSpec(testutils.Context(t), hex.EncodeToString(w.owner), "workflow-name")
+		require.NoError(t, err)
+		require.Equal(t, status, dbSpec.Status)
+		require.Equal(t, status == job.WorkflowSpecStatusActive, h.engineRegistry.IsRunning(dbSpec.WorkflowID))
+	}
+
+	t.Run("register then pause", func(t *testing.T) {
+		ctx := testutils.Context(t)
+		h, w := newHandler(t)
+
+		require.NoError(t, h.Handle(ctx, registered(t, w, 0)))
+		requireStatus(t, h, w, job.WorkflowSpecStatusActive)
+
+		require.NoError(t, h.Handle(ctx, paused(t, w)))
+		requireStatus(t, h, w, job.WorkflowSpecStatusPaused)
+
+		// a replayed active registration starts the engine again
+		require.NoError(t, h.Handle(ctx, registered(t, w, 0)))
+		requireStatus(t, h, w, job.WorkflowSpecStatusActive)
+
+		// and a paused registration stops it
+		require.NoError(t, h.Handle(ctx, registered(t, w, 1)))
+		requireStatus(t, h, w, job.WorkflowSpecStatusPaused)
+		assert.Empty(t, h.RunningWorkflows())
+	})
+
+	t.Run("pause then register", func(t *testing.T) {
+		ctx := testutils.Context(t)
+		h, w := newHandler(t)
+
+		// the pause cannot be applied before the workflow is registered
+		require.ErrorContains(t, h.Handle(ctx, paused(t, w)), "failed to get workflow spec")
+
+		require.NoError(t, h.Handle(ctx, registered(t, w, 1)))
+		requireStatus(t, h, w, job.WorkflowSpecStatusPaused)
+
+		require.NoError(t, h.Handle(ctx, paused(t, w)))
+		requireStatus(t, h, w, job.WorkflowSpecStatusPaused)
+
+		// registering the paused workflow as active starts its engine
+		require.NoError(t, h.Handle(ctx, registered(t, w, 0)))
+		requireStatus(t, h, w, job.WorkflowSpecStatusActive)
+		require.Len(t, h.RunningWorkflows(), 1)
+	})
+}
+
+func Test_workflowRegisteredHandler_FetchTimeout(t *testing.T) {
+	var (
+		ctx     = testutils.Context(t)
+		lggr    = logger.TestLogger(t)
+		db      = pgtest.NewSqlxDB(t)
+		orm     = NewWorkflowRegistryDS(db, lggr)
+		wfOwner = []byte("0xOwner")
+		unblock = make(chan struct{})
+	)
+	t.Cleanup(func() { close(unblock) })
+
+	// the fetcher hangs regardless of its context, like a stuck gateway download
+	var fetcher FetcherFunc = func(context.Context, string) ([]byte, error) {
+		<-unblock
+		return nil, errors.New("download finished too late")
+	}
+	h := &eventHandler{
+		lggr:           lggr,
+		orm:            orm,
+		fetcher:        fetcher,
+		emitter:        custmsg.NewLabeler(),
+		engineRegistry: newEngineRegistry(),
+		fetchTimeout:   50 * time.Millisecond,
+	}
+
+	start := time.Now()
+	err := h.workflowRegisteredEvent(ctx, WorkflowRegistryWorkflowRegisteredV1{
+		Status:       uint8(0),
+		WorkflowID:   [32]byte{1},
+		Owner:        wfOwner,
+		WorkflowName: "workflow-name",
+		BinaryURL:    "http://example.com/binary",
+		ConfigURL:    "http://example.com/config",
+		SecretsURL:   "http://example.com",
+	})
+	require.ErrorContains(t, err, "failed to fetch binary from http://example.com/binary : fetch timed out after 50ms")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	// nothing is stored or started for the failed registration
+	_, err = orm.GetWorkflowSpec(ctx, hex.EncodeToString(wfOwner), "workflow-name")
+	require.Error(t, err)
+	assert.Empty(t, h.engineRegistry.List())
+}
+
+func Test_workflowRegisteredHandler_OtherDon(t *testing.T) {
+	var (
+		ctx     = testutils.Context(t)
+		lggr    = logger.TestLogger(t)
+		db      = pgtest.NewSqlxDB(t)
+		orm     = NewWorkflowRegistryDS(db, lggr)
+		wfOwner = []byte("0xOwner")
+		fetched []string
+	)
+
+	var fetcher FetcherFunc = func(_ context.Context, url string) ([]byte, error) {
+		fetched = append(fetched, url)
+		return nil, errors.New("not found")
+	}
+	h := &eventHandler{
+		lggr:           lggr,
+		orm:            orm,
+		fetcher:        fetcher,
+		emitter:        custmsg.NewLabeler(),
+		engineRegistry: newEngineRegistry(),
+	}
+	h.SetDonID(1)
+
+	registered := WorkflowRegistryWorkflowRegisteredV1{
+		Status:       uint8(0),
+		WorkflowID:   [32]byte{1},
+		Owner:        wfOwner,
+		DonID:        2,
+		WorkflowName: "workflow-name",
+		BinaryURL:    "http://example.com/binary",
+		ConfigURL:    "http://example.com/config",
+		SecretsURL:   "http://example.com",
+	}
+	require.NoError(t, h.workflowRegisteredEvent(ctx, registered))
+
+	// nothing is fetched, stored or started for the workflow of the other DON
+	assert.Empty(t, fetched)
+	_, err := orm.GetWorkflowSpec(ctx, hex.EncodeToString(wfOwner), "workflow-name")
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	assert.Empty(t, h.engineRegistry.List())
+
+	// the workflow is handled once the node is in its DON
+	h.SetDonID(2)
+	err = h.workflowRegisteredEvent(ctx, registered)
+	require.ErrorContains(t, err, "failed to fetch binary")
+	assert.Equal(t, []string{"http://example.com/binary"}, fetched)
 }
 
 func Test_workflowDeletedHandler(t *testing.T) {
@@ -321,7 +905,7 @@ func Test_workflowDeletedHandler(t *testing.T) {
 			})
 		)
 
-		giveWFID := workflowID(binary, config, []byte(secretsURL))
+		giveWFID := ComputeWorkflowID(binary, config, secretsURL)
 
 		b, err := hex.DecodeString(giveWFID)
 		require.NoError(t, err)
@@ -380,6 +964,10 @@ func Test_workflowDeletedHandler(t *testing.T) {
 		_, err = orm.GetWorkflowSpec(ctx, hex.EncodeToString(wfOwner), "workflow-name")
 		require.Error(t, err)
 
+		// Verify the secrets of the deleted workflow are deleted with it
+		_, err = orm.GetContents(ctx, secretsURL)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+
 		// Verify the engine is deleted
 		_, err = h.engineRegistry.Get(giveWFID)
 		require.Error(t, err)
@@ -412,8 +1000,8 @@ func Test_workflowPausedActivatedUpdatedHandler(t *testing.T) {
 			})
 		)
 
-		giveWFID := workflowID(binary, config, []byte(secretsURL))
-		updatedWFID := workflowID(binary, updateConfig, []byte(secretsURL))
+		giveWFID := ComputeWorkflowID(binary, config, secretsURL)
+		updatedWFID := ComputeWorkflowID(binary, updateConfig, secretsURL)
 
 		b, err := hex.DecodeString(giveWFID)
 		require.NoError(t, err)
@@ -545,6 +1133,233 @@ func Test_workflowPausedActivatedUpdatedHandler(t *testing.T) {
 	})
 }
 
+type engineTransition struct {
+	wfID       string
+	transition EngineTransition
+}
+
+func Test_workflowUpdatedHandler_EngineTransitions(t *testing.T) {
+	var (
+		ctx          = testutils.Context(t)
+		w            = newTestWorkflows(t)
+		secretsURL   = "http://example.com"
+		updateConfig = []byte("updated")
+		newConfigURL = "http://example.com/new-config"
+
+		transitions []engineTransition
+	)
+
+	registered := w.registered(t, "workflow-name", 0, 0, secretsURL)
+	giveWFID := hex.EncodeToString(registered.WorkflowID[:])
+	w.responses[newConfigURL] = mockFetchResp{Body: updateConfig, Err: nil}
+	updatedWFID := ComputeWorkflowID(w.binary, updateConfig, secretsURL)
+	newWFID, err := hex.DecodeString(updatedWFID)
+	require.NoError(t, err)
+
+	h := w.newHandler(t, WithEngineLifecycleHook(func(wfID string, transition EngineTransition) {
+		transitions = append(transitions, engineTransition{wfID: wfID, transition: transition})
+	}))
+
+	err = h.workflowRegisteredEvent(ctx, registered)
+	require.NoError(t, err)
+	require.Equal(t, []engineTransition{{giveWFID, EngineStarted}}, transitions)
+
+	err = h.workflowUpdatedEvent(ctx, WorkflowRegistryWorkflowUpdatedV1{
+		OldWorkflowID: registered.WorkflowID,
+		NewWorkflowID: [32]byte(newWFID),
+		WorkflowOwner: w.owner,
+		WorkflowName:  "workflow-name",
+		BinaryURL:     testBinaryURL,
+		ConfigURL:     newConfigURL,
+		SecretsURL:    secretsURL,
+		DonID:         1,
+	})
+	require.NoError(t, err)
+
+	// the old engine is stopped before the new one is started
+	require.Equal(t, []engineTransition{
+		{giveWFID, EngineStarted},
+		{giveWFID, EngineStopped},
+		{updatedWFID, EngineStarted},
+	}, transitions)
+}
+
+func Test_HandleBatch_OrdersWorkflowEvents(t *testing.T) {
+	ctx := testutils.Context(t)
+	w := newTestWorkflows(t)
+	registered := w.registered(t, "workflow-name", 1, 1, "http://example.com")
+	giveWFID := hex.EncodeToString(registered.WorkflowID[:])
+
+	h := w.newHandler(t, WithBatchConcurrency(2))
+
+	// the workflow is activated before it is registered as paused, and an unsupported event is mixed in
+	err := h.HandleBatch(ctx, []Event{
+		WorkflowRegistryEvent{
+			EventType: WorkflowActivatedEvent,
+			Data: WorkflowRegistryWorkflowActivatedV1{
+				WorkflowID:    registered.WorkflowID,
+				WorkflowOwner: w.owner,
+				WorkflowName:  "workflow-name",
+				DonID:         1,
+			},
+		},
+		WorkflowRegistryEvent{},
+		WorkflowRegistryEvent{
+			EventType: WorkflowRegisteredEvent,
+			Data:      registered,
+		},
+	})
+	require.ErrorContains(t, err, "event type unsupported")
+	require.NotContains(t, err.Error(), string(WorkflowActivatedEvent))
+	require.NotContains(t, err.Error(), string(WorkflowRegisteredEvent))
+
+	// the registration was handled first, so the workflow ends up active
+	dbSpec, err := w.orm.GetWorkflowSpec(ctx, hex.EncodeToString(w.owner), "workflow-name")
+	require.NoError(t, err)
+	require.Equal(t, job.WorkflowSpecStatusActive, dbSpec.Status)
+
+	engine, err := h.engineRegistry.Get(giveWFID)
+	require.NoError(t, err)
+	require.NoError(t, engine.Ready())
+}
+
+func Test_HandleBatch_DeleteThenRegister(t *testing.T) {
+	ctx := testutils.Context(t)
+	w := newTestWorkflows(t)
+	old := w.registered(t, "workflow-name", 0, 1, "http://example.com/secrets/old")
+	registered := w.registered(t, "workflow-name", 0, 1, "http://example.com/secrets/new")
+
+	h := w.newHandler(t)
+	t.Cleanup(func() { _ = h.Close(testutils.Context(t)) })
+	require.NoError(t, h.Handle(ctx, WorkflowRegistryEvent{EventType: WorkflowRegisteredEvent, Data: old}))
+
+	// the workflow is deleted and registered again under the same name, which must not be undone by the deletion
+	err := h.HandleBatch(ctx, []Event{
+		WorkflowRegistryEvent{
+			EventType: WorkflowDeletedEvent,
+			Data: WorkflowRegistryWorkflowDeletedV1{
+				WorkflowID:    old.WorkflowID,
+				WorkflowOwner: w.owner,
+				WorkflowName:  "workflow-name",
+				DonID:         1,
+			},
+		},
+		WorkflowRegistryEvent{
+			EventType: WorkflowRegisteredEvent,
+			Data:      registered,
+		},
+	})
+	require.NoError(t, err)
+
+	dbSpec, err := w.orm.GetWorkflowSpec(ctx, hex.EncodeToString(w.owner), "workflow-name")
+	require.NoError(t, err)
+	require.Equal(t, hex.EncodeToString(registered.WorkflowID[:]), dbSpec.WorkflowID)
+	require.Equal(t, job.WorkflowSpecStatusActive, dbSpec.Status)
+	assert.False(t, h.engineRegistry.IsRunning(hex.EncodeToString(old.WorkflowID[:])))
+	assert.True(t, h.engineRegistry.IsRunning(hex.EncodeToString(registered.WorkflowID[:])))
+}
+
+func Test_LoadWorkflows_StartsOnlyActiveWorkflows(t *testing.T) {
+	var (
+		ctx     = testutils.Context(t)
+		w       = newTestWorkflows(t)
+		fetched = map[string]int{}
+	)
+
+	// two active and two paused workflows, the secrets URLs differ so that each workflow has its own ID
+	statuses := []uint8{0, 1, 0, 1}
+	workflows := make([]WorkflowRegistryWorkflowRegisteredV1, len(statuses))
+	for i, status := range statuses {
+		workflows[i] = w.registered(t, fmt.Sprintf("workflow-name-%d", i), status, 1, fmt.Sprintf("http://example.com/secrets/%d", i))
+	}
+
+	reader := NewMockContractReader(t)
+	reader.EXPECT().Bind(mock.Anything, mock.Anything).Return(nil)
+	reader.EXPECT().GetLatestValueWithHeadData(mock.Anything, mock.Anything, primitives.Finalized, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, _ primitives.ConfidenceLevel, params any, returnVal any) (*types.Head, error) {
+			p := params.(GetWorkflowMetadataListByDONParams)
+			end := min(p.Start+p.Limit, uint64(len(workflows)))
+			returnVal.(*GetWorkflowMetadataListByDONReturnVal).WorkflowMetadataList = workflows[p.Start:end]
+			return &types.Head{Height: "10"}, nil
+		})
+
+	fetch := w.fetcher
+	w.fetcher = func(ctx context.Context, url string) ([]byte, error) {
+		fetched[url]++
+		return fetch(ctx, url)
+	}
+	h := w.newHandler(t)
+	loader, err := NewWorkflowRegistryContractLoader("0xdeadbeef", MaxWorkflowMetadataPageSize, func(context.Context, []byte) (ContractReader, error) {
+		return reader, nil
+	}, h)
+	require.NoError(t, err)
+
+	assertLoaded := func() {
+		for _, wf := range workflows {
+			wfID := hex.EncodeToString(wf.WorkflowID[:])
+			dbSpec, err := w.orm.GetWorkflowSpec(ctx, hex.EncodeToString(w.owner), wf.WorkflowName)
+			require.NoError(t, err)
+			if wf.Status == 0 {
+				assert.Equal(t, job.WorkflowSpecStatusActive, dbSpec.Status)
+				assert.True(t, h.engineRegistry.IsRunning(wfID), "engine of active workflow %s", wf.WorkflowName)
+			} else {
+				assert.Equal(t, job.WorkflowSpecStatusPaused, dbSpec.Status)
+				_, err := h.engineRegistry.Get(wfID)
+				assert.Error(t, err, "engine of paused workflow %s", wf.WorkflowName)
+			}
+		}
+	}
+
+	_, err = loader.LoadWorkflows(ctx, commoncap.DON{ID: 1})
+	require.NoError(t, err)
+	assertLoaded()
+
+	// loading again, as on a node restart, does not fetch the stored paused workflows again
+	pausedSecretsURL := workflows[1].SecretsURL
+	require.Equal(t, 1, fetched[pausedSecretsURL])
+	_, err = loader.LoadWorkflows(ctx, commoncap.DON{ID: 1})
+	require.NoError(t, err)
+	assertLoaded()
+	require.Equal(t, 1, fetched[pausedSecretsURL])
+}
+
+func Test_workflowActivatedHandler_WorkflowIDMismatch(t *testing.T) {
+	ctx := testutils.Context(t)
+	w := newTestWorkflows(t)
+	paused := w.registered(t, "workflow-name", 1, 0, "http://example.com")
+	giveWFID := hex.EncodeToString(paused.WorkflowID[:])
+
+	h := w.newHandler(t)
+	err := h.workflowRegisteredEvent(ctx, paused)
+	require.NoError(t, err)
+
+	// The stored binary no longer matches the workflow ID
+	dbSpec, err := w.orm.GetWorkflowSpec(ctx, hex.EncodeToString(w.owner), "workflow-name")
+	require.NoError(t, err)
+	dbSpec.Workflow = hex.EncodeToString([]byte("tampered"))
+	_, err = w.orm.UpsertWorkflowSpec(ctx, dbSpec)
+	require.NoError(t, err)
+
+	activatedEvent := WorkflowRegistryWorkflowActivatedV1{
+		WorkflowID:    paused.WorkflowID,
+		WorkflowOwner: w.owner,
+		WorkflowName:  "workflow-name",
+		DonID:         1,
+	}
+	err = h.Handle(ctx, WorkflowRegistryEvent{
+		EventType: WorkflowActivatedEvent,
+		Data:      activatedEvent,
+	})
+	require.ErrorContains(t, err, "workflowID mismatch")
+
+	// Verify the engine is not started and the workflow is still paused
+	_, err = h.engineRegistry.Get(giveWFID)
+	require.Error(t, err)
+	dbSpec, err = w.orm.GetWorkflowSpec(ctx, hex.EncodeToString(w.owner), "workflow-name")
+	require.NoError(t, err)
+	require.Equal(t, job.WorkflowSpecStatusPaused, dbSpec.Status)
+}
+
 func Test_Handler_SecretsFor(t *testing.T) {
 	lggr := logger.TestLogger(t)
 	db := pgtest.NewSqlxDB(t)
@@ -557,7 +1372,9 @@ func Test_Handler_SecretsFor(t *testing.T) {
 	require.NoError(t, err)
 
 	url := "http://example.com"
-	hash := hex.EncodeToString([]byte(url))
+	urlHash, err := orm.GetSecretsURLHash([]byte("anOwner"), []byte(url))
+	require.NoError(t, err)
+	hash := hex.EncodeToString(urlHash)
 	secretsPayload, err := generateSecrets(workflowOwner, map[string][]string{"Foo": []string{"Bar"}}, encryptionKey)
 	require.NoError(t, err)
 	secretsID, err := orm.Create(testutils.Context(t), url, hash, string(secretsPayload))
@@ -585,7 +1402,7 @@ func Test_Handler_SecretsFor(t *testing.T) {
 	h := NewEventHandler(
 		lggr,
 		orm,
-		fetcher.Fetch,
+		fetcher,
 		wfstore.NewDBStore(db, lggr, clockwork.NewFakeClock()),
 		capabilities.NewRegistry(lggr),
 		custmsg.NewLabeler(),
@@ -602,6 +1419,24 @@ func Test_Handler_SecretsFor(t *testing.T) {
 	assert.Equal(t, expectedSecrets, gotSecrets)
 }
 
+func Test_Handler_SecretsFor_NilClock(t *testing.T) {
+	encryptionKey, err := workflowkey.New()
+	require.NoError(t, err)
+	secretsPayload, err := generateSecrets(secretsForOwner, map[string][]string{"Foo": []string{"Bar"}}, encryptionKey)
+	require.NoError(t, err)
+	f := newSecretsForFixture(t, "aName", secretsPayload)
+
+	// a nil clock defaults to the real clock rather than panicking once the secrets are refreshed
+	h := f.newHandler(t, nil, encryptionKey)
+
+	var gotSecrets map[string]string
+	require.NotPanics(t, func() {
+		gotSecrets, err = f.secretsFor(t, h)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Foo": "Bar"}, gotSecrets)
+}
+
 func Test_Handler_SecretsFor_RefreshesSecrets(t *testing.T) {
 	lggr := logger.TestLogger(t)
 	db := pgtest.NewSqlxDB(t)
@@ -617,7 +1452,9 @@ func Test_Handler_SecretsFor_RefreshesSecrets(t *testing.T) {
 	require.NoError(t, err)
 
 	url := "http://example.com"
-	hash := hex.EncodeToString([]byte(url))
+	urlHash, err := orm.GetSecretsURLHash([]byte("anOwner"), []byte(url))
+	require.NoError(t, err)
+	hash := hex.EncodeToString(urlHash)
 
 	secretsID, err := orm.Create(testutils.Context(t), url, hash, string(secretsPayload))
 	require.NoError(t, err)
@@ -646,7 +1483,7 @@ func Test_Handler_SecretsFor_RefreshesSecrets(t *testing.T) {
 	h := NewEventHandler(
 		lggr,
 		orm,
-		fetcher.Fetch,
+		fetcher,
 		wfstore.NewDBStore(db, lggr, clockwork.NewFakeClock()),
 		capabilities.NewRegistry(lggr),
 		custmsg.NewLabeler(),
@@ -678,7 +1515,9 @@ func Test_Handler_SecretsFor_RefreshLogic(t *testing.T) {
 	require.NoError(t, err)
 
 	url := "http://example.com"
-	hash := hex.EncodeToString([]byte(url))
+	urlHash, err := orm.GetSecretsURLHash([]byte("anOwner"), []byte(url))
+	require.NoError(t, err)
+	hash := hex.EncodeToString(urlHash)
 
 	secretsID, err := orm.Create(testutils.Context(t), url, hash, string(secretsPayload))
 	require.NoError(t, err)
@@ -708,7 +1547,7 @@ func Test_Handler_SecretsFor_RefreshLogic(t *testing.T) {
 	h := NewEventHandler(
 		lggr,
 		orm,
-		fetcher.Fetch,
+		fetcher,
 		wfstore.NewDBStore(db, lggr, clockwork.NewFakeClock()),
 		capabilities.NewRegistry(lggr),
 		custmsg.NewLabeler(),
@@ -740,6 +1579,110 @@ func Test_Handler_SecretsFor_RefreshLogic(t *testing.T) {
 	assert.ErrorContains(t, err, "unexpected end of JSON input")
 }
 
+func Test_Handler_SecretsFor_StaleSecretsMetrics(t *testing.T) {
+	encryptionKey, err := workflowkey.New()
+	require.NoError(t, err)
+	secretsPayload, err := generateSecrets(secretsForOwner, map[string][]string{"Foo": []string{"Bar"}}, encryptionKey)
+	require.NoError(t, err)
+	f := newSecretsForFixture(t, "aStaleName", secretsPayload)
+
+	clock := clockwork.NewFakeClock()
+	h := f.newHandler(t, clock, encryptionKey)
+	staleSecretsUsed := promStaleSecretsUsed.WithLabelValues(secretsForOwner, f.workflowName)
+	secondsSinceRefresh := promSecondsSinceSecretsRefresh.WithLabelValues(f.hash)
+	staleBefore := testutil.ToFloat64(staleSecretsUsed)
+
+	expectedSecrets := map[string]string{
+		"Foo": "Bar",
+	}
+	gotSecrets, err := f.secretsFor(t, h)
+	require.NoError(t, err)
+	assert.Equal(t, expectedSecrets, gotSecrets)
+	assert.Equal(t, staleBefore, testutil.ToFloat64(staleSecretsUsed))
+	assert.Equal(t, float64(0), testutil.ToFloat64(secondsSinceRefresh))
+
+	// Past the freshness limit the refresh fails, the workflow still gets the stale secrets
+	f.fetcher.responseMap[f.url] = mockFetchResp{Err: assert.AnError}
+	clock.Advance(48 * time.Hour)
+
+	gotSecrets, err = f.secretsFor(t, h)
+	require.NoError(t, err)
+	assert.Equal(t, expectedSecrets, gotSecrets)
+	assert.Equal(t, staleBefore+1, testutil.ToFloat64(staleSecretsUsed))
+	assert.Equal(t, (48 * time.Hour).Seconds(), testutil.ToFloat64(secondsSinceRefresh))
+}
+
+func Test_Handler_SecretsFor_WithSecretsFreshness(t *testing.T) {
+	encryptionKey, err := workflowkey.New()
+	require.NoError(t, err)
+	secretsPayload, err := generateSecrets(secretsForOwner, map[string][]string{"Foo": []string{"Bar"}}, encryptionKey)
+	require.NoError(t, err)
+	f := newSecretsForFixture(t, "aName", secretsPayload)
+
+	t.Run("non-positive freshness is ignored", func(t *testing.T) {
+		h := f.newHandler(t, clockwork.NewFakeClock(), encryptionKey, WithSecretsFreshness(0))
+		assert.Equal(t, defaultSecretsFreshnessDuration, h.secretsFreshnessDuration)
+		h = f.newHandler(t, clockwork.NewFakeClock(), encryptionKey, WithSecretsFreshness(-time.Minute))
+		assert.Equal(t, defaultSecretsFreshnessDuration, h.secretsFreshnessDuration)
+	})
+
+	t.Run("short freshness refreshes sooner", func(t *testing.T) {
+		f.fetcher.responseMap[f.url] = mockFetchResp{Body: secretsPayload}
+		clock := clockwork.NewFakeClock()
+		h := f.newHandler(t, clock, encryptionKey, WithSecretsFreshness(time.Minute))
+
+		gotSecrets, err := f.secretsFor(t, h)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"Foo": "Bar"}, gotSecrets)
+
+		// Stub out an unparseable response, the secrets are still fresh so they aren't refetched
+		f.fetcher.responseMap[f.url] = mockFetchResp{}
+		clock.Advance(30 * time.Second)
+		_, err = f.secretsFor(t, h)
+		require.NoError(t, err)
+
+		// Well before the default freshness of 24h, the secrets are refetched
+		clock.Advance(time.Minute)
+		_, err = f.secretsFor(t, h)
+		assert.ErrorContains(t, err, "unexpected end of JSON input")
+	})
+}
+
+type stubSecretsDecryptor struct {
+	gotResult secrets.EncryptedSecretsResult
+	gotOwner  string
+	secrets   map[string]string
+}
+
+func (d *stubSecretsDecryptor) Decrypt(result secrets.EncryptedSecretsResult, workflowOwner string) (map[string]string, error) {
+	d.gotResult = result
+	d.gotOwner = workflowOwner
+	return d.secrets, nil
+}
+
+func Test_Handler_SecretsFor_WithSecretsDecryptor(t *testing.T) {
+	// no real key material is needed to exercise SecretsFor
+	encrypted := secrets.EncryptedSecretsResult{
+		EncryptedSecrets: map[string]string{"p2pId": "encrypted"},
+		Metadata: secrets.Metadata{
+			WorkflowOwner:            secretsForOwner,
+			NodePublicEncryptionKeys: map[string]string{"p2pId": "aKey"},
+		},
+	}
+	secretsPayload, err := json.Marshal(encrypted)
+	require.NoError(t, err)
+	f := newSecretsForFixture(t, "aName", secretsPayload)
+
+	decryptor := &stubSecretsDecryptor{secrets: map[string]string{"Foo": "Bar"}}
+	h := f.newHandler(t, clockwork.NewFakeClock(), workflowkey.Key{}, WithSecretsDecryptor(decryptor))
+
+	gotSecrets, err := f.secretsFor(t, h)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Foo": "Bar"}, gotSecrets)
+	assert.Equal(t, encrypted, decryptor.gotResult)
+	assert.Equal(t, secretsForOwner, decryptor.gotOwner)
+}
+
 func generateSecrets(workflowOwner string, secretsMap map[string][]string, encryptionKey workflowkey.Key) ([]byte, error) {
 	sm, secretsEnvVars, err := secrets.EncryptSecretsForNodes(
 		workflowOwner,
@@ -763,3 +1706,40 @@ func generateSecrets(workflowOwner string, secretsMap map[string][]string, encry
 		},
 	})
 }
+
+func Test_Handler_Close(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	ctx := testutils.Context(t)
+
+	er := newEngineRegistry()
+	closeErr := errors.New("failed to close")
+	healthy1, healthy2 := &fakeEngine{}, &fakeEngine{}
+	failing := &fakeEngine{closeErr: closeErr}
+	stuck := &fakeEngine{block: make(chan struct{})}
+	defer close(stuck.block)
+	er.Add("wf-1", healthy1)
+	er.Add("wf-2", failing)
+	er.Add("wf-3", stuck)
+	er.Add("wf-4", healthy2)
+
+	h := &eventHandler{
+		lggr:               lggr,
+		emitter:            custmsg.NewLabeler(),
+		engineRegistry:     er,
+		engineCloseTimeout: 100 * time.Millisecond,
+	}
+
+	err := h.Close(ctx)
+	require.ErrorIs(t, err, closeErr)
+	require.ErrorContains(t, err, "failed to close workflow engine wf-2")
+	require.ErrorContains(t, err, "failed to close workflow engine wf-3: timed out waiting for engine to close")
+	assert.NotContains(t, err.Error(), "wf-1")
+	assert.NotContains(t, err.Error(), "wf-4")
+
+	// every engine was closed, even those after the failing ones
+	assert.True(t, healthy1.closed.Load())
+	assert.True(t, failing.closed.Load())
+	assert.True(t, healthy2.closed.Load())
+	assert.Empty(t, er.List())
+	assert.Empty(t, h.RunningWorkflows())
+}
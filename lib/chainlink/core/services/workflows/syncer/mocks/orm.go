@@ -540,6 +540,66 @@ func (_c *ORM_GetWorkflowSpec_Call) RunAndReturn(run func(context.Context, strin
 	return _c
 }
 
+// ListWorkflowSpecsByStatus provides a mock function with given fields: ctx, donID, status
+func (_m *ORM) ListWorkflowSpecsByStatus(ctx context.Context, donID uint32, status job.WorkflowSpecStatus) ([]*job.WorkflowSpec, error) {
+	ret := _m.Called(ctx, donID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWorkflowSpecsByStatus")
+	}
+
+	var r0 []*job.WorkflowSpec
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint32, job.WorkflowSpecStatus) ([]*job.WorkflowSpec, error)); ok {
+		return rf(ctx, donID, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint32, job.WorkflowSpecStatus) []*job.WorkflowSpec); ok {
+		r0 = rf(ctx, donID, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*job.WorkflowSpec)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint32, job.WorkflowSpecStatus) error); ok {
+		r1 = rf(ctx, donID, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ORM_ListWorkflowSpecsByStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWorkflowSpecsByStatus'
+type ORM_ListWorkflowSpecsByStatus_Call struct {
+	*mock.Call
+}
+
+// ListWorkflowSpecsByStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - donID uint32
+//   - status job.WorkflowSpecStatus
+func (_e *ORM_Expecter) ListWorkflowSpecsByStatus(ctx interface{}, donID interface{}, status interface{}) *ORM_ListWorkflowSpecsByStatus_Call {
+	return &ORM_ListWorkflowSpecsByStatus_Call{Call: _e.mock.On("ListWorkflowSpecsByStatus", ctx, donID, status)}
+}
+
+func (_c *ORM_ListWorkflowSpecsByStatus_Call) Run(run func(ctx context.Context, donID uint32, status job.WorkflowSpecStatus)) *ORM_ListWorkflowSpecsByStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint32), args[2].(job.WorkflowSpecStatus))
+	})
+	return _c
+}
+
+func (_c *ORM_ListWorkflowSpecsByStatus_Call) Return(_a0 []*job.WorkflowSpec, _a1 error) *ORM_ListWorkflowSpecsByStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ORM_ListWorkflowSpecsByStatus_Call) RunAndReturn(run func(context.Context, uint32, job.WorkflowSpecStatus) ([]*job.WorkflowSpec, error)) *ORM_ListWorkflowSpecsByStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function with given fields: ctx, secretsURL, contents
 func (_m *ORM) Update(ctx context.Context, secretsURL string, contents string) (int64, error) {
 	ret := _m.Called(ctx, secretsURL, contents)
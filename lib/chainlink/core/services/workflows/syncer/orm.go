@@ -50,8 +50,13 @@ type WorkflowSpecsDS interface {
 	// GetWorkflowSpec returns the workflow spec for the given owner and name.
 	GetWorkflowSpec(ctx context.Context, owner, name string) (*job.WorkflowSpec, error)
 
-	// DeleteWorkflowSpec deletes the workflow spec for the given owner and name.
+	// DeleteWorkflowSpec deletes the workflow spec for the given owner and name, and its secrets if no other spec
+	// references them.
 	DeleteWorkflowSpec(ctx context.Context, owner, name string) error
+
+	// ListWorkflowSpecsByStatus returns the workflow specs of the given DON with the given status, ordered by ID.
+	// Specs stored before their DON was recorded have DON 0 until their workflow is loaded again by the syncer.
+	ListWorkflowSpecsByStatus(ctx context.Context, donID uint32, status job.WorkflowSpecStatus) ([]*job.WorkflowSpec, error)
 }
 
 type ORM interface {
@@ -215,6 +220,7 @@ func (orm *orm) UpsertWorkflowSpec(ctx context.Context, spec *job.WorkflowSpec)
 			workflow_owner,
 			workflow_name,
 			status,
+			don_id,
 			binary_url,
 			config_url,
 			secrets_id,
@@ -228,6 +234,7 @@ func (orm *orm) UpsertWorkflowSpec(ctx context.Context, spec *job.WorkflowSpec)
 			:workflow_owner,
 			:workflow_name,
 			:status,
+			:don_id,
 			:binary_url,
 			:config_url,
 			:secrets_id,
@@ -242,6 +249,7 @@ func (orm *orm) UpsertWorkflowSpec(ctx context.Context, spec *job.WorkflowSpec)
 			workflow_owner = EXCLUDED.workflow_owner,
 			workflow_name = EXCLUDED.workflow_name,
 			status = EXCLUDED.status,
+			don_id = EXCLUDED.don_id,
 			binary_url = EXCLUDED.binary_url,
 			config_url = EXCLUDED.config_url,
 			secrets_id = EXCLUDED.secrets_id,
@@ -299,6 +307,7 @@ func (orm *orm) UpsertWorkflowSpecWithSecrets(
 				workflow_owner,
 				workflow_name,
 				status,
+				don_id,
 				binary_url,
 				config_url,
 				secrets_id,
@@ -312,6 +321,7 @@ func (orm *orm) UpsertWorkflowSpecWithSecrets(
 				:workflow_owner,
 				:workflow_name,
 				:status,
+				:don_id,
 				:binary_url,
 				:config_url,
 				:secrets_id,
@@ -326,6 +336,7 @@ func (orm *orm) UpsertWorkflowSpecWithSecrets(
 				workflow_owner = EXCLUDED.workflow_owner,
 				workflow_name = EXCLUDED.workflow_name,
 				status = EXCLUDED.status,
+				don_id = EXCLUDED.don_id,
 				binary_url = EXCLUDED.binary_url,
 				config_url = EXCLUDED.config_url,
 				secrets_id = EXCLUDED.secrets_id,
@@ -363,25 +374,53 @@ func (orm *orm) GetWorkflowSpec(ctx context.Context, owner, name string) (*job.W
 	return &spec, nil
 }
 
-func (orm *orm) DeleteWorkflowSpec(ctx context.Context, owner, name string) error {
+func (orm *orm) ListWorkflowSpecsByStatus(ctx context.Context, donID uint32, status job.WorkflowSpecStatus) ([]*job.WorkflowSpec, error) {
 	query := `
-		DELETE FROM workflow_specs
-		WHERE workflow_owner = $1 AND workflow_name = $2
+		SELECT *
+		FROM workflow_specs
+		WHERE don_id = $1 AND status = $2
+		ORDER BY id
 	`
 
-	result, err := orm.ds.ExecContext(ctx, query, owner, name)
-	if err != nil {
-		return err
+	var specs []*job.WorkflowSpec
+	if err := orm.ds.SelectContext(ctx, &specs, query, donID, status); err != nil {
+		return nil, err
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
+	return specs, nil
+}
 
-	if rowsAffected == 0 {
-		return sql.ErrNoRows // No spec deleted
-	}
+func (orm *orm) DeleteWorkflowSpec(ctx context.Context, owner, name string) error {
+	return sqlutil.TransactDataSource(ctx, orm.ds, nil, func(tx sqlutil.DataSource) error {
+		var secretsID sql.NullInt64
+		err := tx.QueryRowxContext(ctx,
+			`DELETE FROM workflow_specs
+			 WHERE workflow_owner = $1 AND workflow_name = $2
+			 RETURNING secrets_id`,
+			owner, name,
+		).Scan(&secretsID)
+		if err != nil {
+			return err // sql.ErrNoRows if no spec was deleted
+		}
+
+		if !secretsID.Valid {
+			return nil
+		}
 
-	return nil
+		// Lock the secrets first, so that concurrent deletes of the specs sharing them cannot both see the other spec
+		// and leave the secrets behind.
+		if _, err = tx.ExecContext(ctx, `SELECT id FROM workflow_secrets WHERE id = $1 FOR UPDATE`, secretsID.Int64); err != nil {
+			return fmt.Errorf("failed to lock workflow secrets: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx,
+			`DELETE FROM workflow_secrets
+			 WHERE id = $1 AND NOT EXISTS (SELECT 1 FROM workflow_specs WHERE secrets_id = $1)`,
+			secretsID.Int64,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to delete orphaned workflow secrets: %w", err)
+		}
+		return nil
+	})
 }
@@ -3,6 +3,7 @@ package syncer
 import (
 	"database/sql"
 	"encoding/hex"
+	"fmt"
 	"testing"
 	"time"
 
@@ -156,6 +157,55 @@ func Test_DeleteWorkflowSpec(t *testing.T) {
 		require.Error(t, err)
 		require.Equal(t, sql.ErrNoRows, err)
 	})
+
+	t.Run("deletes the secrets of the last workflow spec referencing them", func(t *testing.T) {
+		newSpec := func(name string) *job.WorkflowSpec {
+			return &job.WorkflowSpec{
+				Workflow:      "test_workflow",
+				Config:        "test_config",
+				WorkflowID:    "cid-" + name,
+				WorkflowOwner: "owner-123",
+				WorkflowName:  name,
+				Status:        job.WorkflowSpecStatusActive,
+				BinaryURL:     "http://example.com/binary",
+				ConfigURL:     "http://example.com/config",
+				CreatedAt:     time.Now(),
+				SpecType:      job.WASMFile,
+			}
+		}
+		sharedURL, sharedHash := "http://example.com/shared-secrets", "shared-hash"
+		soleURL, soleHash := "http://example.com/sole-secrets", "sole-hash"
+
+		_, err := orm.UpsertWorkflowSpecWithSecrets(ctx, newSpec("shared-1"), sharedURL, sharedHash, "shared secrets")
+		require.NoError(t, err)
+		_, err = orm.UpsertWorkflowSpecWithSecrets(ctx, newSpec("shared-2"), sharedURL, sharedHash, "shared secrets")
+		require.NoError(t, err)
+		_, err = orm.UpsertWorkflowSpecWithSecrets(ctx, newSpec("sole"), soleURL, soleHash, "sole secrets")
+		require.NoError(t, err)
+
+		// the secrets are only referenced by the deleted spec
+		require.NoError(t, orm.DeleteWorkflowSpec(ctx, "owner-123", "sole"))
+		_, err = orm.GetContentsByHash(ctx, soleHash)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+
+		// the secrets are still referenced by another spec
+		require.NoError(t, orm.DeleteWorkflowSpec(ctx, "owner-123", "shared-1"))
+		contents, err := orm.GetContentsByHash(ctx, sharedHash)
+		require.NoError(t, err)
+		require.Equal(t, "shared secrets", contents)
+
+		dbSpec, err := orm.GetWorkflowSpec(ctx, "owner-123", "shared-2")
+		require.NoError(t, err)
+		dbHash, dbContents, err := orm.GetContentsByWorkflowID(ctx, dbSpec.WorkflowID)
+		require.NoError(t, err)
+		require.Equal(t, sharedHash, dbHash)
+		require.Equal(t, "shared secrets", dbContents)
+
+		// the last spec referencing the secrets is deleted
+		require.NoError(t, orm.DeleteWorkflowSpec(ctx, "owner-123", "shared-2"))
+		_, err = orm.GetContentsByHash(ctx, sharedHash)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
 }
 
 func Test_GetWorkflowSpec(t *testing.T) {
@@ -197,6 +247,83 @@ func Test_GetWorkflowSpec(t *testing.T) {
 	})
 }
 
+func Test_ListWorkflowSpecsByStatus(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	ctx := testutils.Context(t)
+	lggr := logger.TestLogger(t)
+	orm := &orm{ds: db, lggr: lggr}
+
+	seed := []struct {
+		name   string
+		donID  uint32
+		status job.WorkflowSpecStatus
+	}{
+		{"active-1", 1, job.WorkflowSpecStatusActive},
+		{"paused-1", 1, job.WorkflowSpecStatusPaused},
+		{"active-2", 1, job.WorkflowSpecStatusActive},
+		{"other-don-active", 2, job.WorkflowSpecStatusActive},
+		{"other-don-paused", 2, job.WorkflowSpecStatusPaused},
+	}
+	for i, s := range seed {
+		_, err := orm.UpsertWorkflowSpec(ctx, &job.WorkflowSpec{
+			Workflow:      "test_workflow",
+			Config:        "test_config",
+			WorkflowID:    fmt.Sprintf("cid-%d", i),
+			WorkflowOwner: "owner-123",
+			WorkflowName:  s.name,
+			Status:        s.status,
+			DonID:         s.donID,
+			BinaryURL:     "http://example.com/binary",
+			ConfigURL:     "http://example.com/config",
+			CreatedAt:     time.Now(),
+			SpecType:      job.WASMFile,
+		})
+		require.NoError(t, err)
+	}
+
+	names := func(specs []*job.WorkflowSpec) []string {
+		var names []string
+		for _, spec := range specs {
+			names = append(names, spec.WorkflowName)
+		}
+		return names
+	}
+
+	t.Run("lists the active workflow specs of the DON", func(t *testing.T) {
+		specs, err := orm.ListWorkflowSpecsByStatus(ctx, 1, job.WorkflowSpecStatusActive)
+		require.NoError(t, err)
+		require.Equal(t, []string{"active-1", "active-2"}, names(specs))
+		for _, spec := range specs {
+			require.Equal(t, uint32(1), spec.DonID)
+			require.Equal(t, job.WorkflowSpecStatusActive, spec.Status)
+		}
+	})
+
+	t.Run("lists the paused workflow specs of the DON", func(t *testing.T) {
+		specs, err := orm.ListWorkflowSpecsByStatus(ctx, 2, job.WorkflowSpecStatusPaused)
+		require.NoError(t, err)
+		require.Equal(t, []string{"other-don-paused"}, names(specs))
+	})
+
+	t.Run("follows status updates", func(t *testing.T) {
+		spec, err := orm.GetWorkflowSpec(ctx, "owner-123", "active-1")
+		require.NoError(t, err)
+		spec.Status = job.WorkflowSpecStatusPaused
+		_, err = orm.UpsertWorkflowSpec(ctx, spec)
+		require.NoError(t, err)
+
+		specs, err := orm.ListWorkflowSpecsByStatus(ctx, 1, job.WorkflowSpecStatusPaused)
+		require.NoError(t, err)
+		require.Equal(t, []string{"active-1", "paused-1"}, names(specs))
+	})
+
+	t.Run("returns no workflow specs for an unknown DON", func(t *testing.T) {
+		specs, err := orm.ListWorkflowSpecsByStatus(ctx, 3, job.WorkflowSpecStatusActive)
+		require.NoError(t, err)
+		require.Empty(t, specs)
+	})
+}
+
 func Test_GetContentsByWorkflowID(t *testing.T) {
 	db := pgtest.NewSqlxDB(t)
 	ctx := testutils.Context(t)
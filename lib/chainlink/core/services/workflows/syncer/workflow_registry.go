@@ -21,6 +21,10 @@ import (
 
 const name = "WorkflowRegistrySyncer"
 
+// MaxWorkflowMetadataPageSize is the pagination limit of the workflow registry contract, the most workflows it returns
+// per getWorkflowMetadataListByDON call.
+const MaxWorkflowMetadataPageSize uint64 = 100
+
 var (
 	defaultTickInterval                    = 12 * time.Second
 	WorkflowRegistryContractName           = "WorkflowRegistry"
@@ -76,9 +80,20 @@ type WorkflowLoadConfig struct {
 	FetchBatchSize int
 }
 
-// FetcherFunc is an abstraction for fetching the contents stored at a URL.
+// Fetcher is an abstraction for fetching the contents stored at a URL, so that the transport
+// used to download workflow artifacts can be swapped.
+type Fetcher interface {
+	Fetch(ctx context.Context, url string) ([]byte, error)
+}
+
+// FetcherFunc is an adapter to allow the use of ordinary functions as a Fetcher.
 type FetcherFunc func(ctx context.Context, url string) ([]byte, error)
 
+// Fetch calls f(ctx, url).
+func (f FetcherFunc) Fetch(ctx context.Context, url string) ([]byte, error) {
+	return f(ctx, url)
+}
+
 type ContractReaderFactory interface {
 	NewContractReader(context.Context, []byte) (types.ContractReader, error)
 }
@@ -113,8 +128,6 @@ type workflowRegistry struct {
 	lggr                    logger.Logger
 	workflowRegistryAddress string
 
-	newContractReaderFn newContractReaderFn
-
 	eventPollerCfg WorkflowEventPollerConfig
 	eventTypes     []WorkflowRegistryEventType
 
@@ -132,7 +145,8 @@ type workflowRegistry struct {
 
 	workflowDonNotifier donNotifier
 
-	reader ContractReader
+	// readers caches the reader bound to the workflow registry, which is only rebound after a failed query.
+	readers *contractReaderCache
 }
 
 // WithTicker allows external callers to provide a ticker to the workflowRegistry.  This is useful
@@ -145,6 +159,8 @@ func WithTicker(ticker <-chan time.Time) func(*workflowRegistry) {
 
 type evtHandler interface {
 	Handle(ctx context.Context, event Event) error
+	// Close releases the resources held by the handler once no more events will be handled.
+	Close(ctx context.Context) error
 }
 
 type initialWorkflowsStateLoader interface {
@@ -157,6 +173,23 @@ type donNotifier interface {
 	WaitForDon(ctx context.Context) (capabilities.DON, error)
 }
 
+// donChangeNotifier is implemented by donNotifiers that also report when the node moves to another DON after its DON
+// was first set.
+type donChangeNotifier interface {
+	// DonChanges returns a channel receiving the new DON of the node each time it changes.
+	DonChanges() <-chan capabilities.DON
+}
+
+// workflowsStopper is implemented by handlers that can stop all the workflows they run, without being closed.
+type workflowsStopper interface {
+	StopWorkflows(ctx context.Context) error
+}
+
+// donIDSetter is implemented by handlers that only run the workflows of the node's DON.
+type donIDSetter interface {
+	SetDonID(donID uint32)
+}
+
 type newContractReaderFn func(context.Context, []byte) (ContractReader, error)
 
 // NewWorkflowRegistry returns a new workflowRegistry.
@@ -174,7 +207,6 @@ func NewWorkflowRegistry(
 	ets := []WorkflowRegistryEventType{ForceUpdateSecretsEvent}
 	wr := &workflowRegistry{
 		lggr:                        lggr.Named(name),
-		newContractReaderFn:         newContractReaderFn,
 		workflowRegistryAddress:     addr,
 		eventPollerCfg:              eventPollerConfig,
 		heap:                        newBlockHeightHeap(),
@@ -185,6 +217,7 @@ func NewWorkflowRegistry(
 		handler:                     handler,
 		initialWorkflowsStateLoader: initialWorkflowsStateLoader,
 		workflowDonNotifier:         workflowDonNotifier,
+		readers:                     newContractReaderCache(newContractReaderFn),
 	}
 
 	for _, opt := range opts {
@@ -209,6 +242,7 @@ func (w *workflowRegistry) Start(_ context.Context) error {
 				w.lggr.Errorf("failed to wait for don: %v", err)
 				return
 			}
+			w.setHandlerDon(don)
 
 			loadWorkflowsHead, err := w.initialWorkflowsStateLoader.LoadWorkflows(ctx, don)
 			if err != nil {
@@ -216,6 +250,14 @@ func (w *workflowRegistry) Start(_ context.Context) error {
 				return
 			}
 
+			if n, ok := w.workflowDonNotifier.(donChangeNotifier); ok {
+				w.wg.Add(1)
+				go func() {
+					defer w.wg.Done()
+					w.donChangesLoop(ctx, don, n.DonChanges())
+				}()
+			}
+
 			w.syncEventsLoop(ctx, loadWorkflowsHead.Height)
 		}()
 
@@ -235,7 +277,8 @@ func (w *workflowRegistry) Close() error {
 	return w.StopOnce(w.Name(), func() error {
 		close(w.stopCh)
 		w.wg.Wait()
-		return nil
+		// the handler loop has exited, so the handler can safely stop the workflow engines.
+		return w.handler.Close(context.Background())
 	})
 }
 
@@ -251,6 +294,44 @@ func (w *workflowRegistry) Name() string {
 	return name
 }
 
+// donChangesLoop resyncs the workflows each time the node moves to another DON: the workflows of the previous DON are
+// stopped and the ones of the new DON are loaded.
+func (w *workflowRegistry) donChangesLoop(ctx context.Context, don capabilities.DON, changes <-chan capabilities.DON) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case newDon := <-changes:
+			if newDon.ID == don.ID {
+				continue
+			}
+
+			w.lggr.Infow("DON changed, resyncing workflows", "oldDonID", don.ID, "newDonID", newDon.ID)
+			// The DON is set first so that the events handled from now on skip the workflows of the previous DON, the
+			// stop then waits for the events being handled and stops any engine they started.
+			w.setHandlerDon(newDon)
+			// a workflow is assigned to a single DON, so none of the running workflows belongs to the new DON
+			if stopper, ok := w.handler.(workflowsStopper); ok {
+				if err := stopper.StopWorkflows(ctx); err != nil {
+					w.lggr.Errorw("failed to stop the workflows of the previous DON", "donID", don.ID, "err", err)
+				}
+			}
+			don = newDon
+
+			if _, err := w.initialWorkflowsStateLoader.LoadWorkflows(ctx, don); err != nil {
+				w.lggr.Errorw("failed to load the workflows of the new DON", "donID", don.ID, "err", err)
+			}
+		}
+	}
+}
+
+// setHandlerDon tells the handler which DON the node belongs to, if it checks the DON of the workflows.
+func (w *workflowRegistry) setHandlerDon(don capabilities.DON) {
+	if setter, ok := w.handler.(donIDSetter); ok {
+		setter.SetDonID(don.ID)
+	}
+}
+
 // handlerLoop handles the events that are emitted by the contract.
 func (w *workflowRegistry) handlerLoop(ctx context.Context) {
 	for {
@@ -296,8 +377,7 @@ func (w *workflowRegistry) syncEventsLoop(ctx context.Context, lastReadBlockNumb
 
 	// critical failure if there is no reader, the loop will exit and the parent context will be
 	// canceled.
-	reader, err := w.getContractReader(ctx)
-	if err != nil {
+	if _, err := w.getContractReader(ctx); err != nil {
 		w.lggr.Criticalf("contract reader unavailable : %s", err)
 		return
 	}
@@ -314,7 +394,7 @@ func (w *workflowRegistry) syncEventsLoop(ctx context.Context, lastReadBlockNumb
 				ctx,
 				signal,
 				w.lggr,
-				reader,
+				w.readers,
 				lastReadBlockNumber,
 				queryEventConfig{
 					ContractName:              WorkflowRegistryContractName,
@@ -402,16 +482,7 @@ func (w *workflowRegistry) getContractReader(ctx context.Context) (ContractReade
 		Address: w.workflowRegistryAddress,
 	}
 
-	if w.reader == nil {
-		reader, err := getWorkflowRegistryEventReader(ctx, w.newContractReaderFn, c)
-		if err != nil {
-			return nil, err
-		}
-
-		w.reader = reader
-	}
-
-	return w.reader, nil
+	return w.readers.Get(ctx, c)
 }
 
 type queryEventConfig struct {
@@ -422,12 +493,13 @@ type queryEventConfig struct {
 
 // queryEvent queries the contract for events of the given type on each tick from the ticker.
 // Sends a batch of event logs to the batch channel.  The batch represents all the
-// event logs read since the last query.  Loops until the context is canceled.
+// event logs read since the last query.  The reader is taken from the readers cache on each tick, and is only rebound
+// after a query failed as the contract was not bound.  Loops until the context is canceled.
 func queryEvent(
 	ctx context.Context,
 	ticker <-chan struct{},
 	lggr logger.Logger,
-	reader ContractReader,
+	readers *contractReaderCache,
 	lastReadBlockNumber string,
 	cfg queryEventConfig,
 	et WorkflowRegistryEventType,
@@ -458,6 +530,12 @@ func queryEvent(
 				limitAndSort.Limit = query.CursorLimit(cursor, query.CursorFollowing, cfg.QueryCount)
 			}
 
+			reader, err := readers.Get(ctx, bc)
+			if err != nil {
+				lggr.Errorw("Contract reader unavailable", "err", err)
+				continue
+			}
+
 			logs, err := reader.QueryKey(
 				ctx,
 				bc,
@@ -473,7 +551,14 @@ func queryEvent(
 			)
 
 			if err != nil {
-				lggr.Errorw("QueryKey failure", "err", err)
+				if !isBindingError(err) {
+					lggr.Errorw("QueryKey failure", "err", err)
+					continue
+				}
+				lggr.Errorw("QueryKey failure, rebinding contract reader on next query", "err", err)
+				if err := readers.Invalidate(ctx, bc, reader); err != nil {
+					lggr.Errorw("Failed to release contract reader", "err", err)
+				}
 				continue
 			}
 
@@ -554,20 +639,46 @@ func (r workflowAsEvent) GetData() any {
 
 type workflowRegistryContractLoader struct {
 	workflowRegistryAddress string
+	pageSize                uint64
 	newContractReaderFn     newContractReaderFn
 	handler                 evtHandler
+
+	// onProgress is called with the number of workflows handled so far after each page is loaded.
+	onProgress func(loaded int)
+}
+
+// WithLoadProgress sets a callback reporting the number of workflows handled so far while the workflows are loaded
+// from the contract, which allows monitoring a long initial sync.  It is called after each page.
+func WithLoadProgress(onProgress func(loaded int)) func(*workflowRegistryContractLoader) {
+	return func(l *workflowRegistryContractLoader) {
+		l.onProgress = onProgress
+	}
 }
 
+// NewWorkflowRegistryContractLoader returns a loader reading the workflows of a DON from the contract, pageSize of them
+// per call.  The page size must be positive and at most MaxWorkflowMetadataPageSize.
 func NewWorkflowRegistryContractLoader(
 	workflowRegistryAddress string,
+	pageSize uint64,
 	newContractReaderFn newContractReaderFn,
 	handler evtHandler,
-) *workflowRegistryContractLoader {
-	return &workflowRegistryContractLoader{
+	opts ...func(*workflowRegistryContractLoader),
+) (*workflowRegistryContractLoader, error) {
+	if pageSize == 0 || pageSize > MaxWorkflowMetadataPageSize {
+		return nil, fmt.Errorf("invalid page size %d: must be between 1 and %d", pageSize, MaxWorkflowMetadataPageSize)
+	}
+	l := &workflowRegistryContractLoader{
 		workflowRegistryAddress: workflowRegistryAddress,
+		pageSize:                pageSize,
 		newContractReaderFn:     newContractReaderFn,
 		handler:                 handler,
+		onProgress:              func(int) {},
+	}
+
+	for _, opt := range opts {
+		opt(l)
 	}
+	return l, nil
 }
 
 func (l *workflowRegistryContractLoader) LoadWorkflows(ctx context.Context, don capabilities.DON) (*types.Head, error) {
@@ -609,7 +720,7 @@ func (l *workflowRegistryContractLoader) LoadWorkflows(ctx context.Context, don
 	params := GetWorkflowMetadataListByDONParams{
 		DonID: don.ID,
 		Start: 0,
-		Limit: 0, // 0 tells the contract to return max pagination limit workflows on each call
+		Limit: l.pageSize,
 	}
 
 	var headAtLastRead *types.Head
@@ -630,11 +741,15 @@ func (l *workflowRegistryContractLoader) LoadWorkflows(ctx context.Context, don
 			}
 		}
 
-		if len(workflows.WorkflowMetadataList) == 0 {
-			break
+		if len(workflows.WorkflowMetadataList) > 0 {
+			params.Start += uint64(len(workflows.WorkflowMetadataList))
+			l.onProgress(int(params.Start))
 		}
 
-		params.Start += uint64(len(workflows.WorkflowMetadataList))
+		// a page short of the page size is the last one
+		if uint64(len(workflows.WorkflowMetadataList)) < l.pageSize {
+			break
+		}
 	}
 
 	return headAtLastRead, nil
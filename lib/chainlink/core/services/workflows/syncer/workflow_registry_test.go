@@ -3,6 +3,8 @@ package syncer
 import (
 	"context"
 	"encoding/hex"
+	"fmt"
+	"slices"
 	"testing"
 	"time"
 
@@ -17,6 +19,7 @@ import (
 	query "github.com/smartcontractkit/chainlink-common/pkg/types/query"
 	"github.com/smartcontractkit/chainlink-common/pkg/types/query/primitives"
 	"github.com/smartcontractkit/chainlink-common/pkg/values"
+	corecapabilities "github.com/smartcontractkit/chainlink/v2/core/capabilities"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
@@ -45,12 +48,13 @@ func Test_Workflow_Registry_Syncer(t *testing.T) {
 			QueryCount: 20,
 		}
 		giveURL       = "http://example.com"
-		giveHash, err = crypto.Keccak256([]byte(giveURL))
+		giveOwner     = []byte("0xowneraddr")
+		giveHash, err = crypto.Keccak256(append(giveOwner, []byte(giveURL)...))
 
 		giveLog = types.Sequence{
 			Data: map[string]any{
 				"SecretsURLHash": giveHash,
-				"Owner":          "0xowneraddr",
+				"Owner":          giveOwner,
 			},
 			Cursor: "cursor",
 		}
@@ -70,27 +74,29 @@ func Test_Workflow_Registry_Syncer(t *testing.T) {
 		}
 		ticker = make(chan time.Time)
 
-		handler = NewEventHandler(lggr, orm, gateway, nil, nil,
+		handler = NewEventHandler(lggr, orm, FetcherFunc(gateway), nil, nil,
 			emitter, clockwork.NewFakeClock(), workflowkey.Key{})
-		loader = NewWorkflowRegistryContractLoader(contractAddress, func(ctx context.Context, bytes []byte) (ContractReader, error) {
-			return reader, nil
-		}, handler)
-
-		worker = NewWorkflowRegistry(lggr, func(ctx context.Context, bytes []byte) (ContractReader, error) {
-			return reader, nil
-		}, contractAddress,
-			WorkflowEventPollerConfig{
-				QueryCount: 20,
-			}, handler, loader,
-			&testDonNotifier{
-				don: capabilities.DON{
-					ID: 1,
-				},
-				err: nil,
-			},
-			WithTicker(ticker))
 	)
 
+	loader, err := NewWorkflowRegistryContractLoader(contractAddress, MaxWorkflowMetadataPageSize, func(ctx context.Context, bytes []byte) (ContractReader, error) {
+		return reader, nil
+	}, handler)
+	require.NoError(t, err)
+
+	worker := NewWorkflowRegistry(lggr, func(ctx context.Context, bytes []byte) (ContractReader, error) {
+		return reader, nil
+	}, contractAddress,
+		WorkflowEventPollerConfig{
+			QueryCount: 20,
+		}, handler, loader,
+		&testDonNotifier{
+			don: capabilities.DON{
+				ID: 1,
+			},
+			err: nil,
+		},
+		WithTicker(ticker))
+
 	// Cleanup the worker
 	defer cancel()
 
@@ -136,3 +142,162 @@ func Test_Workflow_Registry_Syncer(t *testing.T) {
 		return secrets == wantContents
 	}, 5*time.Second, time.Second)
 }
+
+type recordingEvtHandler struct {
+	events []Event
+}
+
+func (h *recordingEvtHandler) Handle(_ context.Context, event Event) error {
+	h.events = append(h.events, event)
+	return nil
+}
+
+func (h *recordingEvtHandler) Close(context.Context) error { return nil }
+
+func Test_NewWorkflowRegistryContractLoader_PageSize(t *testing.T) {
+	newReader := func(context.Context, []byte) (ContractReader, error) { return nil, nil }
+	for _, pageSize := range []uint64{0, MaxWorkflowMetadataPageSize + 1} {
+		_, err := NewWorkflowRegistryContractLoader("0xdeadbeef", pageSize, newReader, &recordingEvtHandler{})
+		require.ErrorContains(t, err, "invalid page size")
+	}
+}
+
+func Test_WorkflowRegistryContractLoader_LoadWorkflows_Paginates(t *testing.T) {
+	var (
+		ctx          = testutils.Context(t)
+		reader       = NewMockContractReader(t)
+		handler      = &recordingEvtHandler{}
+		pageSize     = uint64(3)
+		numWorkflows = 10
+		calls        int
+	)
+
+	registered := make([]WorkflowRegistryWorkflowRegisteredV1, numWorkflows)
+	for i := range registered {
+		registered[i] = WorkflowRegistryWorkflowRegisteredV1{DonID: 1, WorkflowName: fmt.Sprintf("workflow-%d", i)}
+	}
+
+	reader.EXPECT().Bind(mock.Anything, mock.Anything).Return(nil)
+	reader.EXPECT().GetLatestValueWithHeadData(mock.Anything, mock.Anything, primitives.Finalized, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, _ primitives.ConfidenceLevel, params any, returnVal any) (*types.Head, error) {
+			calls++
+			p := params.(GetWorkflowMetadataListByDONParams)
+			require.Equal(t, pageSize, p.Limit)
+			end := min(p.Start+p.Limit, uint64(len(registered)))
+			returnVal.(*GetWorkflowMetadataListByDONReturnVal).WorkflowMetadataList = registered[p.Start:end]
+			return &types.Head{Height: "10"}, nil
+		})
+
+	loader, err := NewWorkflowRegistryContractLoader("0xdeadbeef", pageSize, func(context.Context, []byte) (ContractReader, error) {
+		return reader, nil
+	}, handler)
+	require.NoError(t, err)
+
+	head, err := loader.LoadWorkflows(ctx, capabilities.DON{ID: 1})
+	require.NoError(t, err)
+	require.Equal(t, "10", head.Height)
+
+	// 3 full pages and a last short one
+	require.Equal(t, 4, calls)
+	require.Len(t, handler.events, numWorkflows)
+	for i, event := range handler.events {
+		require.Equal(t, WorkflowRegisteredEvent, event.GetEventType())
+		require.Equal(t, registered[i], event.GetData())
+	}
+}
+
+func Test_WorkflowRegistryContractLoader_LoadWorkflows_ReportsProgress(t *testing.T) {
+	var (
+		ctx          = testutils.Context(t)
+		reader       = NewMockContractReader(t)
+		handler      = &recordingEvtHandler{}
+		numWorkflows = 250
+		progress     []int
+	)
+
+	registered := make([]WorkflowRegistryWorkflowRegisteredV1, numWorkflows)
+	for i := range registered {
+		registered[i] = WorkflowRegistryWorkflowRegisteredV1{DonID: 1, WorkflowName: fmt.Sprintf("workflow-%d", i)}
+	}
+
+	reader.EXPECT().Bind(mock.Anything, mock.Anything).Return(nil)
+	reader.EXPECT().GetLatestValueWithHeadData(mock.Anything, mock.Anything, primitives.Finalized, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, _ primitives.ConfidenceLevel, params any, returnVal any) (*types.Head, error) {
+			p := params.(GetWorkflowMetadataListByDONParams)
+			end := min(p.Start+p.Limit, uint64(len(registered)))
+			returnVal.(*GetWorkflowMetadataListByDONReturnVal).WorkflowMetadataList = registered[p.Start:end]
+			return &types.Head{Height: "10"}, nil
+		})
+
+	loader, err := NewWorkflowRegistryContractLoader("0xdeadbeef", MaxWorkflowMetadataPageSize, func(context.Context, []byte) (ContractReader, error) {
+		return reader, nil
+	}, handler, WithLoadProgress(func(loaded int) {
+		// the workflows reported as loaded have all been handled
+		require.Len(t, handler.events, loaded)
+		progress = append(progress, loaded)
+	}))
+	require.NoError(t, err)
+
+	_, err = loader.LoadWorkflows(ctx, capabilities.DON{ID: 1})
+	require.NoError(t, err)
+	require.Equal(t, []int{100, 200, 250}, progress)
+}
+
+func Test_workflowRegistry_DonChangeResyncsWorkflows(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	w := newTestWorkflows(t)
+
+	// DON 1 runs the first two workflows, DON 2 the third one while the fourth one is paused
+	donIDs := []uint32{1, 1, 2, 2}
+	statuses := []uint8{0, 0, 0, 1}
+	workflows := make([]WorkflowRegistryWorkflowRegisteredV1, len(donIDs))
+	wfIDs := make([]string, len(donIDs))
+	for i := range workflows {
+		workflows[i] = w.registered(t, fmt.Sprintf("workflow-name-%d", i), statuses[i], donIDs[i], fmt.Sprintf("http://example.com/secrets/%d", i))
+		wfIDs[i] = hex.EncodeToString(workflows[i].WorkflowID[:])
+	}
+
+	reader := NewMockContractReader(t)
+	reader.EXPECT().Bind(mock.Anything, mock.Anything).Return(nil)
+	reader.EXPECT().GetLatestValueWithHeadData(mock.Anything, mock.Anything, primitives.Finalized, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, _ primitives.ConfidenceLevel, params any, returnVal any) (*types.Head, error) {
+			p := params.(GetWorkflowMetadataListByDONParams)
+			var page []WorkflowRegistryWorkflowRegisteredV1
+			if p.Start == 0 {
+				for _, wf := range workflows {
+					if wf.DonID == p.DonID {
+						page = append(page, wf)
+					}
+				}
+			}
+			returnVal.(*GetWorkflowMetadataListByDONReturnVal).WorkflowMetadataList = page
+			return &types.Head{Height: "10"}, nil
+		})
+	newReader := func(context.Context, []byte) (ContractReader, error) { return reader, nil }
+
+	h := w.newHandler(t)
+	loader, err := NewWorkflowRegistryContractLoader("0xdeadbeef", MaxWorkflowMetadataPageSize, newReader, h)
+	require.NoError(t, err)
+
+	notifier := corecapabilities.NewDonNotifier()
+	notifier.NotifyDonSet(capabilities.DON{ID: 1})
+	worker := NewWorkflowRegistry(lggr, newReader, "0xdeadbeef", WorkflowEventPollerConfig{QueryCount: 20},
+		h, loader, notifier, WithTicker(make(chan time.Time)))
+	servicetest.Run(t, worker)
+
+	// RunningWorkflows is sorted by workflow ID
+	runs := func(ids ...string) func() bool {
+		return func() bool {
+			var running []string
+			for _, wf := range h.RunningWorkflows() {
+				running = append(running, wf.WorkflowID)
+			}
+			return slices.Equal(slices.Sorted(slices.Values(ids)), running)
+		}
+	}
+	require.Eventually(t, runs(wfIDs[0], wfIDs[1]), 10*time.Second, 100*time.Millisecond)
+
+	// moving to DON 2 stops the workflows of DON 1 and starts the active one of DON 2
+	notifier.NotifyDonSet(capabilities.DON{ID: 2})
+	require.Eventually(t, runs(wfIDs[2]), 10*time.Second, 100*time.Millisecond)
+}
@@ -41,6 +41,11 @@ func (l *ldapAuthenticator) SetLDAPClient(newClient LDAPClient) {
 	l.ldapClient = newClient
 }
 
+// SetLDAPClient likewise lets the ldapauth_test module mock the client of the syncer
+func (l *LDAPServerStateSyncer) SetLDAPClient(newClient LDAPClient) {
+	l.ldapClient = newClient
+}
+
 // Implements config.LDAP
 type TestConfig struct {
 }
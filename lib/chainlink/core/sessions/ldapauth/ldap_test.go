@@ -3,6 +3,8 @@ package ldapauth_test
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -13,6 +15,8 @@ import (
 
 	"github.com/jmoiron/sqlx"
 
+	commonconfig "github.com/smartcontractkit/chainlink-common/pkg/config"
+	"github.com/smartcontractkit/chainlink/v2/core/config"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
@@ -649,3 +653,202 @@ func TestORM_MapSearchGroups(t *testing.T) {
 		})
 	}
 }
+
+// syncTestConfig keeps sessions and API tokens created during the test, and rate limits upstream syncs
+type syncTestConfig struct {
+	ldapauth.TestConfig
+}
+
+func (c *syncTestConfig) SessionTimeout() commonconfig.Duration {
+	return *commonconfig.MustNewDuration(time.Hour)
+}
+
+func (c *syncTestConfig) UserAPITokenDuration() commonconfig.Duration {
+	return *commonconfig.MustNewDuration(time.Hour)
+}
+
+func (c *syncTestConfig) UpstreamSyncRateLimit() commonconfig.Duration {
+	return *commonconfig.MustNewDuration(time.Hour)
+}
+
+// syncSearchResult returns the group members for group queries, and marks every user as active otherwise
+func syncSearchResult(cfg config.LDAP, req *ldap.SearchRequest, groupMembers map[string][]string) *ldap.SearchResult {
+	if !strings.HasPrefix(req.Filter, "(&(cn=") {
+		var entries []*ldap.Entry
+		for _, members := range groupMembers {
+			for _, email := range members {
+				entries = append(entries, &ldap.Entry{Attributes: []*ldap.EntryAttribute{
+					{Name: cfg.ActiveAttribute(), Values: []string{cfg.ActiveAttributeAllowedValue()}},
+					{Name: cfg.BaseUserAttr(), Values: []string{email}},
+				}})
+			}
+		}
+		return &ldap.SearchResult{Entries: entries}
+	}
+
+	cn := strings.TrimSuffix(strings.TrimPrefix(req.Filter, "(&(cn="), "))")
+	var members []string
+	for _, email := range groupMembers[cn] {
+		members = append(members, fmt.Sprintf("uid=%s,ou=users,dc=example,dc=com", email))
+	}
+	return &ldap.SearchResult{Entries: []*ldap.Entry{{
+		DN:         fmt.Sprintf("cn=%s,ou=Groups,dc=example,dc=com", cn),
+		Attributes: []*ldap.EntryAttribute{{Name: ldapauth.UniqueMemberAttribute, Values: members}},
+	}}}
+}
+
+func TestLDAPServerStateSyncer_SyncNow(t *testing.T) {
+	t.Parallel()
+
+	cfg := syncTestConfig{}
+	groupMembers := map[string][]string{
+		ldapauth.NodeAdminsGroupCN:   {"admin@example.com"},
+		ldapauth.NodeEditorsGroupCN:  {},
+		ldapauth.NodeRunnersGroupCN:  {},
+		ldapauth.NodeReadOnlyGroupCN: {},
+	}
+
+	t.Run("purges and updates local sessions", func(t *testing.T) {
+		ctx := testutils.Context(t)
+		db := pgtest.NewSqlxDB(t)
+		syncer := ldapauth.NewLDAPServerStateSyncer(db, &cfg, logger.TestLogger(t))
+		mockLdapClient := mocks.NewLDAPClient(t)
+		mockLdapConnProvider := mocks.NewLDAPConn(t)
+		mockLdapClient.On("CreateEphemeralConnection").Return(mockLdapConnProvider, nil)
+		mockLdapConnProvider.On("Bind", mock.Anything, mock.Anything).Return(nil)
+		mockLdapConnProvider.On("Close").Return(nil)
+		mockLdapConnProvider.EXPECT().Search(mock.Anything).RunAndReturn(func(req *ldap.SearchRequest) (*ldap.SearchResult, error) {
+			return syncSearchResult(&cfg, req, groupMembers), nil
+		})
+		syncer.SetLDAPClient(mockLdapClient)
+
+		insertSession := func(id, email string, role sessions.UserRole) {
+			pgtest.MustExec(t, db, "INSERT INTO ldap_sessions (id, user_email, user_role, localauth_user, created_at) VALUES ($1, $2, $3, false, now())", id, email, role)
+		}
+		sessionRole := func(email string) (role sessions.UserRole) {
+			require.NoError(t, db.GetContext(ctx, &role, "SELECT user_role FROM ldap_sessions WHERE user_email = $1", email))
+			return role
+		}
+
+		// the admin user has been promoted upstream, the removed user is no longer a member of any group
+		insertSession("admin-session", "admin@example.com", sessions.UserRoleView)
+		insertSession("removed-session", "removed@example.com", sessions.UserRoleEdit)
+
+		require.NoError(t, syncer.SyncNow(ctx, false))
+		assert.Equal(t, sessions.UserRoleAdmin, sessionRole("admin@example.com"))
+		assert.Equal(t, 0, pgtest.MustCount(t, db, "SELECT count(*) FROM ldap_sessions WHERE user_email = $1", "removed@example.com"))
+		assert.False(t, syncer.LastSyncTime().IsZero())
+
+		// the next sync is rate limited unless forced
+		insertSession("removed-session", "removed@example.com", sessions.UserRoleEdit)
+		require.ErrorIs(t, syncer.SyncNow(ctx, false), ldapauth.ErrSyncRateLimited)
+		assert.Equal(t, 1, pgtest.MustCount(t, db, "SELECT count(*) FROM ldap_sessions WHERE user_email = $1", "removed@example.com"))
+
+		require.NoError(t, syncer.SyncNow(ctx, true))
+		assert.Equal(t, 0, pgtest.MustCount(t, db, "SELECT count(*) FROM ldap_sessions WHERE user_email = $1", "removed@example.com"))
+	})
+
+	t.Run("is not marked synced on errors", func(t *testing.T) {
+		ctx := testutils.Context(t)
+		db := pgtest.NewSqlxDB(t)
+		syncer := ldapauth.NewLDAPServerStateSyncer(db, &cfg, logger.TestLogger(t))
+		mockLdapClient := mocks.NewLDAPClient(t)
+		mockLdapConnProvider := mocks.NewLDAPConn(t)
+		mockLdapClient.On("CreateEphemeralConnection").Return(mockLdapConnProvider, nil)
+		mockLdapConnProvider.On("Bind", mock.Anything, mock.Anything).Return(errors.New("invalid credentials"))
+		mockLdapConnProvider.On("Close").Return(nil)
+		mockLdapConnProvider.EXPECT().Search(mock.Anything).RunAndReturn(func(req *ldap.SearchRequest) (*ldap.SearchResult, error) {
+			return syncSearchResult(&cfg, req, groupMembers), nil
+		})
+		syncer.SetLDAPClient(mockLdapClient)
+
+		// the local state is still synced, but the sync as a whole failed
+		require.ErrorContains(t, syncer.SyncNow(ctx, true), "invalid credentials")
+		assert.True(t, syncer.LastSyncTime().IsZero())
+	})
+
+	t.Run("returns database errors", func(t *testing.T) {
+		ctx := testutils.Context(t)
+		db := pgtest.NewSqlxDB(t)
+		syncer := ldapauth.NewLDAPServerStateSyncer(db, &cfg, logger.TestLogger(t))
+		mockLdapClient := mocks.NewLDAPClient(t)
+		mockLdapClient.On("CreateEphemeralConnection").Return(nil, errors.New("connection refused"))
+		syncer.SetLDAPClient(mockLdapClient)
+
+		pgtest.MustExec(t, db, "ALTER TABLE ldap_sessions RENAME TO ldap_sessions_renamed")
+
+		err := syncer.SyncNow(ctx, true)
+		require.ErrorContains(t, err, "unable to expire local LDAP sessions")
+		require.ErrorContains(t, err, "connection refused")
+		assert.True(t, syncer.LastSyncTime().IsZero())
+	})
+}
+
+func TestLDAPServerStateSyncer_Work_ConcurrentGroupQueries(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+
+	cfg := ldapauth.TestConfig{}
+	syncer := ldapauth.NewLDAPServerStateSyncer(pgtest.NewSqlxDB(t), &cfg, logger.TestLogger(t))
+	mockLdapClient := mocks.NewLDAPClient(t)
+	mockLdapConnProvider := mocks.NewLDAPConn(t)
+	mockLdapClient.On("CreateEphemeralConnection").Return(mockLdapConnProvider, nil)
+	mockLdapConnProvider.On("Bind", mock.Anything, mock.Anything).Return(nil)
+	mockLdapConnProvider.On("Close").Return(nil)
+	syncer.SetLDAPClient(mockLdapClient)
+
+	// the shared user is a member of both the admin and the view group
+	groupMembers := map[string][]string{
+		ldapauth.NodeAdminsGroupCN:   {"admin@example.com", "shared@example.com"},
+		ldapauth.NodeEditorsGroupCN:  {"edit@example.com"},
+		ldapauth.NodeRunnersGroupCN:  {"run@example.com"},
+		ldapauth.NodeReadOnlyGroupCN: {"shared@example.com", "view@example.com"},
+	}
+
+	var (
+		mu          sync.Mutex
+		inFlight    int
+		maxInFlight int
+		started     int
+		activeQuery string
+	)
+	allStarted := make(chan struct{})
+	mockLdapConnProvider.EXPECT().Search(mock.Anything).RunAndReturn(func(req *ldap.SearchRequest) (*ldap.SearchResult, error) {
+		if !strings.HasPrefix(req.Filter, "(&(cn=") {
+			// validateUsersActive, issued once all the group queries returned
+			mu.Lock()
+			activeQuery = req.Filter
+			mu.Unlock()
+			return syncSearchResult(&cfg, req, groupMembers), nil
+		}
+
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		started++
+		if started == len(groupMembers) {
+			close(allStarted)
+		}
+		mu.Unlock()
+
+		// hold each group query until all of them are in flight
+		select {
+		case <-allStarted:
+		case <-time.After(testutils.WaitTimeout(t)):
+		}
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		return syncSearchResult(&cfg, req, groupMembers), nil
+	})
+
+	syncer.Work(ctx)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, len(groupMembers), maxInFlight, "expected all group queries to overlap")
+	assert.False(t, syncer.LastSyncTime().IsZero())
+	// users are deduped in order of role precedence, keeping the shared user in its admin position
+	assert.Equal(t, "(&(|(uid=admin@example.com)(uid=shared@example.com)(uid=edit@example.com)(uid=run@example.com)(uid=view@example.com)))", activeQuery)
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/go-ldap/ldap/v3"
+	"github.com/jonboulle/clockwork"
 	"github.com/lib/pq"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/services"
 	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
@@ -16,14 +19,29 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/sessions"
 )
 
+// ErrSyncRateLimited is returned by SyncNow when the sync is skipped due to the UpstreamSyncRateLimit
+var ErrSyncRateLimited = errors.New("upstream LDAP sync rate limited")
+
+// defaultStaleSyncIntervals is the number of sync intervals without a successful sync after which the syncer is reported unhealthy
+const defaultStaleSyncIntervals = 3
+
 type LDAPServerStateSyncer struct {
-	ds           sqlutil.DataSource
-	ldapClient   LDAPClient
-	config       config.LDAP
-	lggr         logger.Logger
+	ds                 sqlutil.DataSource
+	ldapClient         LDAPClient
+	config             config.LDAP
+	lggr               logger.Logger
+	clock              clockwork.Clock
+	staleSyncIntervals int
+	done               chan struct{}
+	stopCh             services.StopChan
+
+	// syncMu serializes syncs triggered on the timer and with SyncNow
+	syncMu sync.Mutex
+
+	mu           sync.RWMutex
+	startTime    time.Time
+	lastSyncTime time.Time
 	nextSyncTime time.Time
-	done         chan struct{}
-	stopCh       services.StopChan
 }
 
 // NewLDAPServerStateSyncer creates a reaper that cleans stale sessions from the store.
@@ -31,14 +49,33 @@ func NewLDAPServerStateSyncer(
 	ds sqlutil.DataSource,
 	config config.LDAP,
 	lggr logger.Logger,
+	opts ...func(*LDAPServerStateSyncer),
 ) *LDAPServerStateSyncer {
-	return &LDAPServerStateSyncer{
-		ds:         ds,
-		ldapClient: newLDAPClient(config),
-		config:     config,
-		lggr:       lggr.Named("LDAPServerStateSync"),
-		done:       make(chan struct{}),
-		stopCh:     make(services.StopChan),
+	l := &LDAPServerStateSyncer{
+		ds:                 ds,
+		ldapClient:         newLDAPClient(config),
+		config:             config,
+		lggr:               lggr.Named("LDAPServerStateSync"),
+		clock:              clockwork.NewRealClock(),
+		staleSyncIntervals: defaultStaleSyncIntervals,
+		done:               make(chan struct{}),
+		stopCh:             make(services.StopChan),
+	}
+	for _, o := range opts {
+		o(l)
+	}
+	return l
+}
+
+// WithStaleSyncIntervals sets the number of sync intervals that may pass without a successful sync
+// before the syncer reports itself unhealthy. Non-positive values are ignored.
+func WithStaleSyncIntervals(n int) func(*LDAPServerStateSyncer) {
+	return func(l *LDAPServerStateSyncer) {
+		if n <= 0 {
+			l.lggr.Warnf("Ignoring non-positive stale sync intervals %d, using %d", n, l.staleSyncIntervals)
+			return
+		}
+		l.staleSyncIntervals = n
 	}
 }
 
@@ -48,11 +85,65 @@ func (l *LDAPServerStateSyncer) Name() string {
 
 func (l *LDAPServerStateSyncer) Ready() error { return nil }
 
+// HealthReport reports the syncer unhealthy once no sync has completed successfully for longer than the
+// staleness threshold. Only applies when syncing on a timer, see UpstreamSyncInterval.
 func (l *LDAPServerStateSyncer) HealthReport() map[string]error {
-	return map[string]error{l.Name(): nil}
+	return map[string]error{l.Name(): l.checkStale()}
+}
+
+// LastSyncTime returns the time of the last successful upstream sync, zero if none has completed yet.
+func (l *LDAPServerStateSyncer) LastSyncTime() time.Time {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.lastSyncTime
+}
+
+// NextSyncTime returns the earliest time the next upstream sync is allowed by UpstreamSyncRateLimit.
+func (l *LDAPServerStateSyncer) NextSyncTime() time.Time {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.nextSyncTime
+}
+
+// staleThreshold is the time allowed between successful syncs, scaled from the larger of the sync interval and
+// the rate limit, since a rate limited sync is skipped. Zero if syncing on a timer is disabled.
+func (l *LDAPServerStateSyncer) staleThreshold() time.Duration {
+	interval := l.config.UpstreamSyncInterval().Duration()
+	if interval == 0 {
+		return 0
+	}
+	interval = max(interval, l.config.UpstreamSyncRateLimit().Duration())
+	return time.Duration(l.staleSyncIntervals) * interval
+}
+
+func (l *LDAPServerStateSyncer) checkStale() error {
+	threshold := l.staleThreshold()
+	if threshold == 0 {
+		return nil
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	since := l.lastSyncTime
+	if since.IsZero() {
+		// not synced yet, measure from start
+		since = l.startTime
+	}
+	if since.IsZero() {
+		return nil
+	}
+	if elapsed := l.clock.Since(since); elapsed > threshold {
+		if l.lastSyncTime.IsZero() {
+			return fmt.Errorf("no successful upstream LDAP sync since start %s ago, exceeding threshold %s", elapsed, threshold)
+		}
+		return fmt.Errorf("last successful upstream LDAP sync was %s ago at %s, exceeding threshold %s", elapsed, l.lastSyncTime, threshold)
+	}
+	return nil
 }
 
 func (l *LDAPServerStateSyncer) Start(ctx context.Context) error {
+	l.mu.Lock()
+	l.startTime = l.clock.Now()
+	l.mu.Unlock()
 	// If enabled, start a background task that calls the Sync/Work function on an
 	// interval without needing an auth event to trigger it
 	// Use IsInstant to check 0 value to omit functionality.
@@ -76,89 +167,87 @@ func (l *LDAPServerStateSyncer) run() {
 	defer close(l.done)
 	ctx, cancel := l.stopCh.NewCtx()
 	defer cancel()
-	ticker := time.NewTicker(l.config.UpstreamSyncInterval().Duration())
+	ticker := l.clock.NewTicker(l.config.UpstreamSyncInterval().Duration())
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case <-ticker.C:
+		case <-ticker.Chan():
 			l.Work(ctx)
 		}
 	}
 }
 
+// Work syncs the local LDAP sessions and API tokens with the upstream LDAP server state, respecting the
+// UpstreamSyncRateLimit. Errors are only logged, see SyncNow.
 func (l *LDAPServerStateSyncer) Work(ctx context.Context) {
+	_ = l.sync(ctx, false)
+}
+
+// SyncNow runs a sync immediately and returns any error encountered. Unless force is set, the sync is skipped
+// with ErrSyncRateLimited if the UpstreamSyncRateLimit has not elapsed since the previous sync.
+func (l *LDAPServerStateSyncer) SyncNow(ctx context.Context, force bool) error {
+	return l.sync(ctx, force)
+}
+
+// sync logs every error encountered, and returns them joined.
+func (l *LDAPServerStateSyncer) sync(ctx context.Context, force bool) (errs error) {
+	l.syncMu.Lock()
+	defer l.syncMu.Unlock()
+
 	// Purge expired ldap_sessions and ldap_user_api_tokens
-	recordCreationStaleThreshold := l.config.SessionTimeout().Before(time.Now())
+	recordCreationStaleThreshold := l.config.SessionTimeout().Before(l.clock.Now())
 	err := l.deleteStaleSessions(ctx, recordCreationStaleThreshold)
 	if err != nil {
 		l.lggr.Error("unable to expire local LDAP sessions: ", err)
+		errs = errors.Join(errs, fmt.Errorf("unable to expire local LDAP sessions: %w", err))
 	}
-	recordCreationStaleThreshold = l.config.UserAPITokenDuration().Before(time.Now())
+	recordCreationStaleThreshold = l.config.UserAPITokenDuration().Before(l.clock.Now())
 	err = l.deleteStaleAPITokens(ctx, recordCreationStaleThreshold)
 	if err != nil {
 		l.lggr.Error("unable to expire user API tokens: ", err)
+		errs = errors.Join(errs, fmt.Errorf("unable to expire user API tokens: %w", err))
 	}
 
 	// Optional rate limiting check to limit the amount of upstream LDAP server queries performed
 	if !l.config.UpstreamSyncRateLimit().IsInstant() {
-		if !time.Now().After(l.nextSyncTime) {
-			return
+		now := l.clock.Now()
+		l.mu.Lock()
+		if !force && !now.After(l.nextSyncTime) {
+			next := l.nextSyncTime
+			l.mu.Unlock()
+			return errors.Join(errs, fmt.Errorf("%w until %s", ErrSyncRateLimited, next))
 		}
 
 		// Enough time has elapsed to sync again, store the time for when next sync is allowed and begin sync
-		l.nextSyncTime = time.Now().Add(l.config.UpstreamSyncRateLimit().Duration())
+		l.nextSyncTime = now.Add(l.config.UpstreamSyncRateLimit().Duration())
+		l.mu.Unlock()
 	}
 
 	l.lggr.Info("Begin Upstream LDAP provider state sync after checking time against config UpstreamSyncInterval and UpstreamSyncRateLimit")
 
-	// For each defined role/group, query for the list of group members to gather the full list of possible users
-	users := []sessions.User{}
-
 	conn, err := l.ldapClient.CreateEphemeralConnection()
 	if err != nil {
 		l.lggr.Error("Failed to Dial LDAP Server: ", err)
-		return
+		return errors.Join(errs, fmt.Errorf("failed to dial LDAP server: %w", err))
 	}
 	// Root level root user auth with credentials provided from config
 	bindStr := l.config.BaseUserAttr() + "=" + l.config.ReadOnlyUserLogin() + "," + l.config.BaseDN()
 	if err = conn.Bind(bindStr, l.config.ReadOnlyUserPass()); err != nil {
 		l.lggr.Error("Unable to login as initial root LDAP user: ", err)
+		errs = errors.Join(errs, fmt.Errorf("unable to login as initial root LDAP user: %w", err))
 	}
 	defer conn.Close()
 
-	// Query for list of uniqueMember IDs present in Admin group
-	adminUsers, err := l.ldapGroupMembersListToUser(conn, l.config.AdminUserGroupCN(), sessions.UserRoleAdmin)
-	if err != nil {
-		l.lggr.Error("Error in ldapGroupMembersListToUser: ", err)
-		return
-	}
-	// Query for list of uniqueMember IDs present in Edit group
-	editUsers, err := l.ldapGroupMembersListToUser(conn, l.config.EditUserGroupCN(), sessions.UserRoleEdit)
-	if err != nil {
-		l.lggr.Error("Error in ldapGroupMembersListToUser: ", err)
-		return
-	}
-	// Query for list of uniqueMember IDs present in Edit group
-	runUsers, err := l.ldapGroupMembersListToUser(conn, l.config.RunUserGroupCN(), sessions.UserRoleRun)
-	if err != nil {
-		l.lggr.Error("Error in ldapGroupMembersListToUser: ", err)
-		return
-	}
-	// Query for list of uniqueMember IDs present in Edit group
-	readUsers, err := l.ldapGroupMembersListToUser(conn, l.config.ReadUserGroupCN(), sessions.UserRoleView)
+	// Query the members of each role group concurrently, in order of role precedence
+	users, err := l.groupMembersToUsers()
 	if err != nil {
-		l.lggr.Error("Error in ldapGroupMembersListToUser: ", err)
-		return
+		l.lggr.Error("Error querying LDAP group members: ", err)
+		return errors.Join(errs, fmt.Errorf("error querying LDAP group members: %w", err))
 	}
 
-	users = append(users, adminUsers...)
-	users = append(users, editUsers...)
-	users = append(users, runUsers...)
-	users = append(users, readUsers...)
-
 	// Dedupe preserving order of highest role (sorted)
 	// Preserve members as a map for future lookup
 	upstreamUserStateMap := make(map[string]sessions.User)
@@ -175,6 +264,7 @@ func (l *LDAPServerStateSyncer) Work(ctx context.Context) {
 	usersActiveFlags, err := l.validateUsersActive(dedupedEmails, conn)
 	if err != nil {
 		l.lggr.Error("Error validating supplied user list: ", err)
+		errs = errors.Join(errs, fmt.Errorf("error validating supplied user list: %w", err))
 	}
 	// Remove users in the upstreamUserStateMap source of truth who are part of groups but marked as deactivated/no-active
 	for i, active := range usersActiveFlags {
@@ -242,9 +332,7 @@ func (l *LDAPServerStateSyncer) Work(ctx context.Context) {
 		}
 
 		// For each user session row, update role to match state of user map from upstream source
-		queryWhenClause := ""
-		emailValues := []interface{}{}
-		// Prepare CASE WHEN query statement with parameterized argument $n placeholders and matching role based on index
+		usersToUpdate := []sessions.User{}
 		for email, user := range upstreamUserStateMap {
 			// Only build on SET CASE statement per local session and API token role, not for each upstream user value
 			_, sessionOk := existingSessionsMap[email]
@@ -252,22 +340,25 @@ func (l *LDAPServerStateSyncer) Work(ctx context.Context) {
 			if !sessionOk && !tokenOk {
 				continue
 			}
-			emailValues = append(emailValues, email)
-			queryWhenClause += fmt.Sprintf("WHEN user_email = $%d THEN '%s' ", len(emailValues), user.Role)
+			usersToUpdate = append(usersToUpdate, user)
+		}
+		queryWhenClause, queryValues, err := buildRoleUpdateCaseClause(usersToUpdate)
+		if err != nil {
+			return err
 		}
 
 		// If there are remaining user entries to update
-		if len(emailValues) != 0 {
+		if len(queryValues) != 0 {
 			// Set new role state for all rows in single Exec
 			query := fmt.Sprintf("UPDATE ldap_sessions SET user_role = CASE %s ELSE user_role END", queryWhenClause)
-			_, err = tx.ExecContext(ctx, query, emailValues...)
+			_, err = tx.ExecContext(ctx, query, queryValues...)
 			if err != nil {
 				return err
 			}
 
 			// Update role of API tokens as well
 			query = fmt.Sprintf("UPDATE ldap_user_api_tokens SET user_role = CASE %s ELSE user_role END", queryWhenClause)
-			_, err = tx.ExecContext(ctx, query, emailValues...)
+			_, err = tx.ExecContext(ctx, query, queryValues...)
 			if err != nil {
 				return err
 			}
@@ -278,8 +369,21 @@ func (l *LDAPServerStateSyncer) Work(ctx context.Context) {
 	})
 	if err != nil {
 		l.lggr.Error("Error syncing local database state: ", err)
+		errs = errors.Join(errs, fmt.Errorf("error syncing local database state: %w", err))
+	}
+	// a sync with any error is not successful, even if the local state was updated
+	if errs == nil {
+		l.markSynced()
 	}
 	l.lggr.Info("Upstream LDAP sync complete")
+	return errs
+}
+
+// markSynced records the completion of a successful upstream sync.
+func (l *LDAPServerStateSyncer) markSynced() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lastSyncTime = l.clock.Now()
 }
 
 // deleteStaleSessions deletes all ldap_sessions before the passed time.
@@ -294,6 +398,64 @@ func (l *LDAPServerStateSyncer) deleteStaleAPITokens(ctx context.Context, before
 	return err
 }
 
+// buildRoleUpdateCaseClause prepares the CASE WHEN clause used to set the role of each supplied user. Both the email and
+// the role are bound as $n placeholders, and each role is validated against the known sessions.UserRole values first.
+// Returns the clause and the matching argument list, empty if there are no users to update
+func buildRoleUpdateCaseClause(users []sessions.User) (string, []interface{}, error) {
+	queryWhenClause := ""
+	queryValues := []interface{}{}
+	for _, user := range users {
+		role, err := sessions.GetUserRole(string(user.Role))
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid role for user %s: %w", user.Email, err)
+		}
+		queryValues = append(queryValues, user.Email, string(role))
+		queryWhenClause += fmt.Sprintf("WHEN user_email = $%d THEN $%d::user_roles ", len(queryValues)-1, len(queryValues))
+	}
+	return queryWhenClause, queryValues, nil
+}
+
+// groupMembersToUsers queries the members of the admin, edit, run and view groups concurrently, each on its own
+// ephemeral connection. Users are returned in order of role precedence, highest first, so that deduping by email
+// keeps the highest role of each user.
+func (l *LDAPServerStateSyncer) groupMembersToUsers() ([]sessions.User, error) {
+	groups := []struct {
+		cn   string
+		role sessions.UserRole
+	}{
+		{l.config.AdminUserGroupCN(), sessions.UserRoleAdmin},
+		{l.config.EditUserGroupCN(), sessions.UserRoleEdit},
+		{l.config.RunUserGroupCN(), sessions.UserRoleRun},
+		{l.config.ReadUserGroupCN(), sessions.UserRoleView},
+	}
+	groupUsers := make([][]sessions.User, len(groups))
+	var eg errgroup.Group
+	for i, group := range groups {
+		eg.Go(func() error {
+			conn, err := l.ldapClient.CreateEphemeralConnection()
+			if err != nil {
+				return fmt.Errorf("failed to dial LDAP server: %w", err)
+			}
+			defer conn.Close()
+			bindStr := l.config.BaseUserAttr() + "=" + l.config.ReadOnlyUserLogin() + "," + l.config.BaseDN()
+			if err = conn.Bind(bindStr, l.config.ReadOnlyUserPass()); err != nil {
+				l.lggr.Error("Unable to login as initial root LDAP user: ", err)
+			}
+			groupUsers[i], err = l.ldapGroupMembersListToUser(conn, group.cn, group.role)
+			return err
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	users := []sessions.User{}
+	for _, u := range groupUsers {
+		users = append(users, u...)
+	}
+	return users, nil
+}
+
 // ldapGroupMembersListToUser queries the LDAP server given a conn for a list of uniqueMember who are part of the parameterized group
 func (l *LDAPServerStateSyncer) ldapGroupMembersListToUser(conn LDAPConn, groupNameCN string, roleToAssign sessions.UserRole) ([]sessions.User, error) {
 	users, err := ldapGroupMembersListToUser(
@@ -0,0 +1,87 @@
+package ldapauth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonconfig "github.com/smartcontractkit/chainlink-common/pkg/config"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+)
+
+type intervalTestConfig struct {
+	TestConfig
+	interval time.Duration
+}
+
+func (c *intervalTestConfig) UpstreamSyncInterval() commonconfig.Duration {
+	return *commonconfig.MustNewDuration(c.interval)
+}
+
+func TestLDAPServerStateSyncer_HealthReport(t *testing.T) {
+	t.Parallel()
+
+	clock := clockwork.NewFakeClock()
+	syncer := NewLDAPServerStateSyncer(nil, &intervalTestConfig{interval: time.Minute}, logger.TestLogger(t), WithStaleSyncIntervals(2))
+	syncer.clock = clock
+	require.NoError(t, syncer.Start(testutils.Context(t)))
+	t.Cleanup(func() { assert.NoError(t, syncer.Close()) })
+
+	assert.NoError(t, syncer.HealthReport()[syncer.Name()])
+	assert.True(t, syncer.LastSyncTime().IsZero())
+
+	// never synced since start
+	clock.Advance(2*time.Minute + time.Second)
+	assert.ErrorContains(t, syncer.HealthReport()[syncer.Name()], "no successful upstream LDAP sync since start")
+
+	syncer.markSynced()
+	assert.Equal(t, clock.Now(), syncer.LastSyncTime())
+	assert.NoError(t, syncer.HealthReport()[syncer.Name()])
+
+	clock.Advance(2 * time.Minute)
+	assert.NoError(t, syncer.HealthReport()[syncer.Name()])
+
+	clock.Advance(time.Second)
+	assert.ErrorContains(t, syncer.HealthReport()[syncer.Name()], "exceeding threshold 2m0s")
+}
+
+func TestBuildRoleUpdateCaseClause(t *testing.T) {
+	t.Parallel()
+
+	t.Run("binds emails and roles as parameters", func(t *testing.T) {
+		users := []sessions.User{
+			{Email: "admin@example.com", Role: sessions.UserRoleAdmin},
+			{Email: "viewer@example.com", Role: sessions.UserRoleView},
+		}
+		clause, values, err := buildRoleUpdateCaseClause(users)
+		require.NoError(t, err)
+		assert.Equal(t, "WHEN user_email = $1 THEN $2::user_roles WHEN user_email = $3 THEN $4::user_roles ", clause)
+		assert.Equal(t, []interface{}{"admin@example.com", "admin", "viewer@example.com", "view"}, values)
+	})
+
+	t.Run("no users", func(t *testing.T) {
+		clause, values, err := buildRoleUpdateCaseClause(nil)
+		require.NoError(t, err)
+		assert.Empty(t, clause)
+		assert.Empty(t, values)
+	})
+
+	t.Run("rejects unexpected role", func(t *testing.T) {
+		injectedRole := sessions.UserRole("admin' END; DROP TABLE ldap_sessions; --")
+		users := []sessions.User{
+			{Email: "admin@example.com", Role: sessions.UserRoleAdmin},
+			{Email: "mallory@example.com", Role: injectedRole},
+		}
+		clause, values, err := buildRoleUpdateCaseClause(users)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "mallory@example.com")
+		assert.Empty(t, clause)
+		assert.NotContains(t, clause, string(injectedRole))
+		assert.Nil(t, values)
+	})
+}
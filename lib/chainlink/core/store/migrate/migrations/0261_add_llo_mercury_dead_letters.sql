@@ -0,0 +1,25 @@
+-- +goose Up
+
+CREATE TABLE llo_mercury_dead_letters (
+  id BIGSERIAL PRIMARY KEY,
+  don_id BIGINT NOT NULL,
+  server_url TEXT NOT NULL,
+  config_digest BYTEA NOT NULL,
+  seq_nr BIGINT NOT NULL,
+  report BYTEA NOT NULL,
+  lifecycle_stage TEXT NOT NULL,
+  report_format BIGINT NOT NULL,
+  signatures BYTEA[] NOT NULL,
+  signers SMALLINT[] NOT NULL,
+  transmission_hash BYTEA NOT NULL,
+  payload BYTEA NOT NULL,
+  error_code INT NOT NULL,
+  error TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_llo_mercury_dead_letters_don_id_server_url_created_at ON llo_mercury_dead_letters (don_id, server_url, created_at DESC);
+
+-- +goose Down
+
+DROP TABLE llo_mercury_dead_letters;
@@ -0,0 +1,14 @@
+-- +goose Up
+-- Add a `don_id` column to the `workflow_specs` table, so the workflows of a DON can be listed.
+-- The DON of the existing workflows was not stored so they cannot be backfilled, they are left with DON 0 until the
+-- workflow syncer stores them again on its initial load of the workflows of the node's DON.
+ALTER TABLE workflow_specs
+ADD COLUMN don_id BIGINT DEFAULT 0 NOT NULL;
+
+CREATE INDEX idx_workflow_specs_don_id_status ON workflow_specs (don_id, status);
+
+-- +goose Down
+-- Remove the `don_id` column from the `workflow_specs` table.
+DROP INDEX IF EXISTS idx_workflow_specs_don_id_status;
+ALTER TABLE workflow_specs
+DROP COLUMN don_id;
@@ -0,0 +1,12 @@
+-- +goose Up
+-- Allow the workflows of an owner to share a secrets record, which is keyed on the owner and secrets URL.
+ALTER TABLE workflow_specs
+DROP CONSTRAINT IF EXISTS workflow_specs_secrets_id_key;
+
+CREATE INDEX idx_workflow_specs_secrets_id ON workflow_specs (secrets_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_workflow_specs_secrets_id;
+
+ALTER TABLE workflow_specs
+ADD CONSTRAINT workflow_specs_secrets_id_key UNIQUE (secrets_id);
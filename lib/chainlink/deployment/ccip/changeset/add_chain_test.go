@@ -217,7 +217,10 @@ func TestAddChainInbound(t *testing.T) {
 		assert.Equal(t, common.LeftPadBytes(state.Chains[chain].OnRamp.Address().Bytes(), 32), s.OnRamp)
 	}
 	// Ensure job related logs are up to date.
-	time.Sleep(30 * time.Second)
+	for _, ocrConfig := range ocrConfigs {
+		require.NoError(t, WaitForOCR3ConfigActive(testcontext.Get(t), e.Env.Chains[newChain], state.Chains[newChain].OffRamp,
+			types.PluginType(ocrConfig.OcrPluginType), ocrConfig.ConfigDigest, 30*time.Second))
+	}
 	ReplayLogs(t, e.Env.Offchain, replayBlocks)
 
 	// TODO: Send via all inbound lanes and use parallel helper
@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math/big"
+	"slices"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/timelock"
@@ -107,12 +108,98 @@ func addLanes(e deployment.Environment, cfg AddLanesConfig) error {
 	return nil
 }
 
+// LanePlan describes the wiring AddLane would apply for a single lane: the source router and onramp are pointed at the
+// dest chain, the source fee quoter gets the initial prices and dest chain config, and the dest offramp and router are
+// pointed at the source chain. Token pool rate limits are not part of lane wiring, they are set on the pools.
+type LanePlan struct {
+	LaneConfig
+	IsTestRouter    bool
+	SourceRouter    common.Address
+	SourceOnRamp    common.Address
+	SourceFeeQuoter common.Address
+	DestRouter      common.Address
+	DestOffRamp     common.Address
+}
+
+// PlanLanesForAll returns the lanes AddLanesForAll would add, one per ordered pair of distinct chains in the
+// environment, with the default prices and fee quoter config. It does not send any transaction.
+// The plans are sorted by source and then dest chain selector.
+func PlanLanesForAll(e deployment.Environment, state CCIPOnChainState) ([]LanePlan, error) {
+	selectors := e.AllChainSelectors()
+	slices.Sort(selectors)
+	var plans []LanePlan
+	for _, source := range selectors {
+		for _, dest := range selectors {
+			if source == dest {
+				continue
+			}
+			plan, err := planLane(state, LaneConfig{
+				SourceSelector:        source,
+				DestSelector:          dest,
+				InitialPricesBySource: DefaultInitialPrices,
+				FeeQuoterDestChain:    DefaultFeeQuoterDestChainConfig(),
+			}, false)
+			if err != nil {
+				return nil, err
+			}
+			plans = append(plans, plan)
+		}
+	}
+	return plans, nil
+}
+
+func planLane(state CCIPOnChainState, config LaneConfig, isTestRouter bool) (LanePlan, error) {
+	from, to := config.SourceSelector, config.DestSelector
+	fromState, ok := state.Chains[from]
+	if !ok {
+		return LanePlan{}, fmt.Errorf("no onchain state for source chain %d", from)
+	}
+	toState, ok := state.Chains[to]
+	if !ok {
+		return LanePlan{}, fmt.Errorf("no onchain state for dest chain %d", to)
+	}
+	fromRouter, toRouter := fromState.Router, toState.Router
+	if isTestRouter {
+		fromRouter, toRouter = fromState.TestRouter, toState.TestRouter
+	}
+	if fromRouter == nil || fromState.OnRamp == nil || fromState.FeeQuoter == nil {
+		return LanePlan{}, fmt.Errorf("missing router, onramp or fee quoter on source chain %d", from)
+	}
+	if toRouter == nil || toState.OffRamp == nil {
+		return LanePlan{}, fmt.Errorf("missing router or offramp on dest chain %d", to)
+	}
+	return LanePlan{
+		LaneConfig:      config,
+		IsTestRouter:    isTestRouter,
+		SourceRouter:    fromRouter.Address(),
+		SourceOnRamp:    fromState.OnRamp.Address(),
+		SourceFeeQuoter: fromState.FeeQuoter.Address(),
+		DestRouter:      toRouter.Address(),
+		DestOffRamp:     toState.OffRamp.Address(),
+	}, nil
+}
+
 func AddLaneWithDefaultPricesAndFeeQuoterConfig(e deployment.Environment, state CCIPOnChainState, from, to uint64, isTestRouter bool) error {
+	return AddLaneWithConfig(e, state, from, to, DefaultFeeQuoterDestChainConfig(), isTestRouter)
+}
+
+// AddLaneWithConfig adds a lane with the default initial prices and the given fee quoter dest chain config.
+// Start from DefaultFeeQuoterDestChainConfig to only override some of the fields.
+func AddLaneWithConfig(
+	e deployment.Environment,
+	state CCIPOnChainState,
+	from, to uint64,
+	feeQuoterDestChain fee_quoter.FeeQuoterDestChainConfig,
+	isTestRouter bool,
+) error {
 	cfg := LaneConfig{
 		SourceSelector:        from,
 		DestSelector:          to,
 		InitialPricesBySource: DefaultInitialPrices,
-		FeeQuoterDestChain:    DefaultFeeQuoterDestChainConfig(),
+		FeeQuoterDestChain:    feeQuoterDestChain,
+	}
+	if err := (AddLanesConfig{LaneConfigs: []LaneConfig{cfg}}).Validate(); err != nil {
+		return fmt.Errorf("invalid lane config from %d to %d: %w", from, to, err)
 	}
 	return AddLane(e, state, cfg, isTestRouter)
 }
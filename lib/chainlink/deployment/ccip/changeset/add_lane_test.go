@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
 
 	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/fee_quoter"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/offramp"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
@@ -58,6 +60,81 @@ func TestAddLanesWithTestRouter(t *testing.T) {
 	ConfirmExecWithSeqNrsForAll(t, e.Env, state, expectedSeqNumExec, startBlocks)
 }
 
+func TestPlanLanesForAll(t *testing.T) {
+	ctx := testcontext.Get(t)
+	e := NewMemoryEnvironmentWithJobsAndContracts(t, logger.TestLogger(t), 3, 4, nil)
+	state, err := LoadOnchainState(e.Env)
+	require.NoError(t, err)
+
+	deployerNonces := func() map[uint64]uint64 {
+		nonces := make(map[uint64]uint64)
+		for sel, chain := range e.Env.Chains {
+			nonce, err := chain.Client.PendingNonceAt(ctx, chain.DeployerKey.From)
+			require.NoError(t, err)
+			nonces[sel] = nonce
+		}
+		return nonces
+	}
+	noncesBefore := deployerNonces()
+
+	plans, err := PlanLanesForAll(e.Env, state)
+	require.NoError(t, err)
+
+	selectors := e.Env.AllChainSelectors()
+	require.Len(t, plans, len(selectors)*(len(selectors)-1))
+	planned := make(map[SourceDestPair]struct{})
+	for _, plan := range plans {
+		require.NotEqual(t, plan.SourceSelector, plan.DestSelector)
+		planned[SourceDestPair{SourceChainSelector: plan.SourceSelector, DestChainSelector: plan.DestSelector}] = struct{}{}
+
+		source, dest := state.Chains[plan.SourceSelector], state.Chains[plan.DestSelector]
+		require.False(t, plan.IsTestRouter)
+		require.Equal(t, source.Router.Address(), plan.SourceRouter)
+		require.Equal(t, source.OnRamp.Address(), plan.SourceOnRamp)
+		require.Equal(t, source.FeeQuoter.Address(), plan.SourceFeeQuoter)
+		require.Equal(t, dest.Router.Address(), plan.DestRouter)
+		require.Equal(t, dest.OffRamp.Address(), plan.DestOffRamp)
+		require.Equal(t, DefaultInitialPrices, plan.InitialPricesBySource)
+		require.Equal(t, DefaultFeeQuoterDestChainConfig(), plan.FeeQuoterDestChain)
+	}
+	for _, source := range selectors {
+		for _, dest := range selectors {
+			if source != dest {
+				require.Contains(t, planned, SourceDestPair{SourceChainSelector: source, DestChainSelector: dest})
+			}
+		}
+	}
+
+	// planning does not send any transaction
+	require.Equal(t, noncesBefore, deployerNonces())
+
+	delete(state.Chains, selectors[0])
+	_, err = PlanLanesForAll(e.Env, state)
+	require.ErrorContains(t, err, "no onchain state for")
+}
+
+func TestAddLaneWithConfig(t *testing.T) {
+	e := NewMemoryEnvironmentWithJobsAndContracts(t, logger.TestLogger(t), 2, 4, nil)
+	state, err := LoadOnchainState(e.Env)
+	require.NoError(t, err)
+
+	selectors := e.Env.AllChainSelectors()
+	chain1, chain2 := selectors[0], selectors[1]
+
+	feeQuoterDestChain := DefaultFeeQuoterDestChainConfig()
+	feeQuoterDestChain.GasMultiplierWeiPerEth = 15e17
+	feeQuoterDestChain.NetworkFeeUSDCents = 5
+	require.NoError(t, AddLaneWithConfig(e.Env, state, chain1, chain2, feeQuoterDestChain, false))
+
+	destChainConfig, err := state.Chains[chain1].FeeQuoter.GetDestChainConfig(&bind.CallOpts{Context: testcontext.Get(t)}, chain2)
+	require.NoError(t, err)
+	require.Equal(t, feeQuoterDestChain, destChainConfig)
+	require.NotEqual(t, DefaultFeeQuoterDestChainConfig().GasMultiplierWeiPerEth, destChainConfig.GasMultiplierWeiPerEth)
+
+	err = AddLaneWithConfig(e.Env, state, chain2, chain1, fee_quoter.FeeQuoterDestChainConfig{}, false)
+	require.ErrorContains(t, err, "missing fee quoter dest chain config")
+}
+
 // TestAddLane covers the workflow of adding a lane between two chains and enabling it.
 // It also covers the case where the onRamp is disabled on the OffRamp contract initially and then enabled.
 func TestAddLane(t *testing.T) {
@@ -0,0 +1,226 @@
+package changeset
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/timelock"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/common/proposalutils"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/token_admin_registry"
+)
+
+var _ deployment.ChangeSet[ConfigureTokenAdminRegistryConfig] = ConfigureTokenAdminRegistryChangeset
+
+// TokenAdminRegistryEntry is the desired TokenAdminRegistry configuration of a token.
+type TokenAdminRegistryEntry struct {
+	// Administrator is proposed as the administrator of the token.
+	Administrator common.Address
+	// Pool is set as the pool of the token if non-zero. Only the administrator can set the pool,
+	// so it must be the one executing the calls: the deployer, or the timelock with MCMS.
+	Pool common.Address
+}
+
+type ConfigureTokenAdminRegistryConfig struct {
+	// EntriesByChain is a mapping from chain selector to the entries of the tokens to configure on that chain.
+	EntriesByChain map[uint64]map[common.Address]TokenAdminRegistryEntry
+	// MCMS is nil if the TokenAdminRegistry is owned by the deployer, which then executes the calls directly.
+	// Otherwise a proposal is generated for the timelocks owning the registries.
+	MCMS *MCMSConfig
+}
+
+func (c ConfigureTokenAdminRegistryConfig) Validate() error {
+	if len(c.EntriesByChain) == 0 {
+		return errors.New("no token admin registry entries provided")
+	}
+	for chainSel, entries := range c.EntriesByChain {
+		if err := deployment.IsValidChainSelector(chainSel); err != nil {
+			return fmt.Errorf("invalid chain selector %d: %w", chainSel, err)
+		}
+		if len(entries) == 0 {
+			return fmt.Errorf("no token admin registry entries provided for chain %d", chainSel)
+		}
+		for token, entry := range entries {
+			if token == (common.Address{}) {
+				return fmt.Errorf("token admin registry entry for chain %d has an empty token address", chainSel)
+			}
+			if entry.Administrator == (common.Address{}) {
+				return fmt.Errorf("empty administrator for token %s on chain %d", token.Hex(), chainSel)
+			}
+		}
+	}
+	return nil
+}
+
+// ConfigureTokenAdminRegistryChangeset registers the administrator of each configured token on the TokenAdminRegistry
+// of its chain. If the administrator is the account executing the calls, it also accepts the admin role and sets the
+// pool of the token. Tokens which already have the given administrator and pool are skipped.
+func ConfigureTokenAdminRegistryChangeset(e deployment.Environment, cfg ConfigureTokenAdminRegistryConfig) (deployment.ChangesetOutput, error) {
+	if err := cfg.Validate(); err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("invalid ConfigureTokenAdminRegistryConfig: %w", err)
+	}
+	state, err := LoadOnchainState(e)
+	if err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("failed to load onchain state: %w", err)
+	}
+
+	chainSels := make([]uint64, 0, len(cfg.EntriesByChain))
+	for chainSel := range cfg.EntriesByChain {
+		chainSels = append(chainSels, chainSel)
+	}
+	sort.Slice(chainSels, func(i, j int) bool { return chainSels[i] < chainSels[j] })
+
+	var (
+		timelocksPerChain = make(map[uint64]common.Address)
+		proposerMCMSes    = make(map[uint64]*gethwrappers.ManyChainMultiSig)
+		batches           []timelock.BatchChainOperation
+	)
+	for _, chainSel := range chainSels {
+		chain, ok := e.Chains[chainSel]
+		if !ok {
+			return deployment.ChangesetOutput{}, fmt.Errorf("chain %d not found in environment", chainSel)
+		}
+		chainState, ok := state.Chains[chainSel]
+		if !ok || chainState.TokenAdminRegistry == nil {
+			return deployment.ChangesetOutput{}, fmt.Errorf("TokenAdminRegistry not found for chain %d", chainSel)
+		}
+
+		opts := chain.DeployerKey
+		if cfg.MCMS != nil {
+			if chainState.Timelock == nil || chainState.ProposerMcm == nil {
+				return deployment.ChangesetOutput{}, fmt.Errorf("timelock or proposer MCMS not found for chain %d", chainSel)
+			}
+			opts = deployment.SimTransactOpts()
+			opts.From = chainState.Timelock.Address()
+		}
+
+		var ops []mcms.Operation
+		for _, token := range sortedTokens(cfg.EntriesByChain[chainSel]) {
+			tokenOps, err := configureTokenAdminRegistryEntry(e, chain, chainState.TokenAdminRegistry, opts, cfg.MCMS != nil, token, cfg.EntriesByChain[chainSel][token])
+			if err != nil {
+				return deployment.ChangesetOutput{}, err
+			}
+			ops = append(ops, tokenOps...)
+		}
+		if len(ops) == 0 {
+			continue
+		}
+
+		timelocksPerChain[chainSel] = chainState.Timelock.Address()
+		proposerMCMSes[chainSel] = chainState.ProposerMcm
+		batches = append(batches, timelock.BatchChainOperation{
+			ChainIdentifier: mcms.ChainIdentifier(chainSel),
+			Batch:           ops,
+		})
+	}
+
+	if cfg.MCMS == nil || len(batches) == 0 {
+		return deployment.ChangesetOutput{}, nil
+	}
+	prop, err := proposalutils.BuildProposalFromBatches(
+		timelocksPerChain,
+		proposerMCMSes,
+		batches,
+		"configure TokenAdminRegistry",
+		cfg.MCMS.MinDelay,
+	)
+	if err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("failed to build proposal: %w", err)
+	}
+	return deployment.ChangesetOutput{
+		Proposals: []timelock.MCMSWithTimelockProposal{*prop},
+	}, nil
+}
+
+// configureTokenAdminRegistryEntry brings the token to the entry by proposing the administrator, accepting the admin
+// role and setting the pool, skipping the steps which are already done. Without MCMS the calls are sent and confirmed
+// one by one, as each depends on the previous one. With MCMS they are returned as operations for the proposal instead.
+func configureTokenAdminRegistryEntry(
+	e deployment.Environment,
+	chain deployment.Chain,
+	registry *token_admin_registry.TokenAdminRegistry,
+	opts *bind.TransactOpts,
+	useMCMS bool,
+	token common.Address,
+	entry TokenAdminRegistryEntry,
+) ([]mcms.Operation, error) {
+	current, err := registry.GetTokenConfig(&bind.CallOpts{Context: e.GetContext()}, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get TokenAdminRegistry config of token %s on chain %d: %w", token.Hex(), chain.Selector, err)
+	}
+	if current.Administrator != (common.Address{}) && current.Administrator != entry.Administrator {
+		return nil, fmt.Errorf("token %s on chain %d is already administered by %s", token.Hex(), chain.Selector, current.Administrator.Hex())
+	}
+	isExecutor := entry.Administrator == opts.From
+	if entry.Pool != (common.Address{}) && !isExecutor {
+		return nil, fmt.Errorf("pool of token %s on chain %d can only be set by its administrator %s, not %s",
+			token.Hex(), chain.Selector, entry.Administrator.Hex(), opts.From.Hex())
+	}
+
+	var ops []mcms.Operation
+	apply := func(action string, tx *types.Transaction, err error) error {
+		if useMCMS {
+			if err != nil {
+				return fmt.Errorf("failed to build %s call for token %s on chain %d: %w", action, token.Hex(), chain.Selector, err)
+			}
+			ops = append(ops, mcms.Operation{
+				To:    registry.Address(),
+				Data:  tx.Data(),
+				Value: big.NewInt(0),
+			})
+			return nil
+		}
+		if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+			return fmt.Errorf("failed to %s for token %s on chain %d: %w", action, token.Hex(), chain.Selector, err)
+		}
+		return nil
+	}
+
+	if current.Administrator == (common.Address{}) {
+		if current.PendingAdministrator != entry.Administrator {
+			tx, err := registry.ProposeAdministrator(opts, token, entry.Administrator)
+			if err := apply("propose administrator", tx, err); err != nil {
+				return nil, err
+			}
+		}
+		if isExecutor {
+			tx, err := registry.AcceptAdminRole(opts, token)
+			if err := apply("accept admin role", tx, err); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if entry.Pool != (common.Address{}) && current.TokenPool != entry.Pool {
+		tx, err := registry.SetPool(opts, token, entry.Pool)
+		if err := apply("set pool", tx, err); err != nil {
+			return nil, err
+		}
+	}
+	if !useMCMS {
+		e.Logger.Infow("Configured TokenAdminRegistry entry", "chain", chain.Selector,
+			"token", token.Hex(), "administrator", entry.Administrator.Hex(), "pool", entry.Pool.Hex())
+	}
+	return ops, nil
+}
+
+// sortedTokens returns the tokens of the entries in a deterministic order.
+func sortedTokens(entries map[common.Address]TokenAdminRegistryEntry) []common.Address {
+	tokens := make([]common.Address, 0, len(entries))
+	for token := range entries {
+		tokens = append(tokens, token)
+	}
+	slices.SortFunc(tokens, func(a, b common.Address) int {
+		return bytes.Compare(a.Bytes(), b.Bytes())
+	})
+	return tokens
+}
@@ -0,0 +1,120 @@
+package changeset
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestConfigureTokenAdminRegistryConfig_Validate(t *testing.T) {
+	chainSel := chainsel.TEST_90000001.Selector
+	token := common.HexToAddress("0x1")
+	admin := common.HexToAddress("0x2")
+	tests := []struct {
+		name    string
+		entries map[uint64]map[common.Address]TokenAdminRegistryEntry
+		wantErr string
+	}{
+		{
+			name:    "valid",
+			entries: map[uint64]map[common.Address]TokenAdminRegistryEntry{chainSel: {token: {Administrator: admin}}},
+		},
+		{
+			name:    "no chains",
+			wantErr: "no token admin registry entries provided",
+		},
+		{
+			name:    "no entries for a chain",
+			entries: map[uint64]map[common.Address]TokenAdminRegistryEntry{chainSel: {}},
+			wantErr: "no token admin registry entries provided for chain",
+		},
+		{
+			name:    "invalid chain selector",
+			entries: map[uint64]map[common.Address]TokenAdminRegistryEntry{1: {token: {Administrator: admin}}},
+			wantErr: "invalid chain selector 1",
+		},
+		{
+			name:    "empty token address",
+			entries: map[uint64]map[common.Address]TokenAdminRegistryEntry{chainSel: {{}: {Administrator: admin}}},
+			wantErr: "empty token address",
+		},
+		{
+			name:    "empty administrator",
+			entries: map[uint64]map[common.Address]TokenAdminRegistryEntry{chainSel: {token: {}}},
+			wantErr: "empty administrator",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ConfigureTokenAdminRegistryConfig{EntriesByChain: tc.entries}.Validate()
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestConfigureTokenAdminRegistryChangeset(t *testing.T) {
+	ctx := testcontext.Get(t)
+	lggr := logger.TestLogger(t)
+	e := NewMemoryEnvironmentWithJobsAndContracts(t, lggr, 2, 4, nil)
+	state, err := LoadOnchainState(e.Env)
+	require.NoError(t, err)
+	chainSel := e.Env.AllChainSelectors()[0]
+	chain := e.Env.Chains[chainSel]
+	registry := state.Chains[chainSel].TokenAdminRegistry
+
+	token, pool, err := deployTransferTokenOneEnd(lggr, chain, chain.DeployerKey, e.Env.ExistingAddresses, "TEST")
+	require.NoError(t, err)
+	cfg := ConfigureTokenAdminRegistryConfig{
+		EntriesByChain: map[uint64]map[common.Address]TokenAdminRegistryEntry{
+			chainSel: {token.Address(): {Administrator: chain.DeployerKey.From, Pool: pool.Address()}},
+		},
+	}
+
+	out, err := ConfigureTokenAdminRegistryChangeset(e.Env, cfg)
+	require.NoError(t, err)
+	require.Empty(t, out.Proposals)
+
+	tokenConfig, err := registry.GetTokenConfig(&bind.CallOpts{Context: ctx}, token.Address())
+	require.NoError(t, err)
+	require.Equal(t, chain.DeployerKey.From, tokenConfig.Administrator)
+	require.Equal(t, common.Address{}, tokenConfig.PendingAdministrator)
+	require.Equal(t, pool.Address(), tokenConfig.TokenPool)
+
+	// applying the same entries again is a no-op
+	_, err = ConfigureTokenAdminRegistryChangeset(e.Env, cfg)
+	require.NoError(t, err)
+
+	// a token already administered by someone else is rejected
+	other := common.HexToAddress("0x1234")
+	_, err = ConfigureTokenAdminRegistryChangeset(e.Env, ConfigureTokenAdminRegistryConfig{
+		EntriesByChain: map[uint64]map[common.Address]TokenAdminRegistryEntry{
+			chainSel: {token.Address(): {Administrator: other}},
+		},
+	})
+	require.ErrorContains(t, err, "already administered")
+
+	// with MCMS the timelock registers, accepts and sets the pool in a single batch
+	mcmsToken, mcmsPool, err := deployTransferTokenOneEnd(lggr, chain, chain.DeployerKey, e.Env.ExistingAddresses, "MCMS")
+	require.NoError(t, err)
+	out, err = ConfigureTokenAdminRegistryChangeset(e.Env, ConfigureTokenAdminRegistryConfig{
+		EntriesByChain: map[uint64]map[common.Address]TokenAdminRegistryEntry{
+			chainSel: {mcmsToken.Address(): {Administrator: state.Chains[chainSel].Timelock.Address(), Pool: mcmsPool.Address()}},
+		},
+		MCMS: &MCMSConfig{},
+	})
+	require.NoError(t, err)
+	require.Len(t, out.Proposals, 1)
+	require.Len(t, out.Proposals[0].Transactions, 1)
+	require.Len(t, out.Proposals[0].Transactions[0].Batch, 3)
+}
@@ -51,15 +51,17 @@ var (
 	CapabilitiesRegistry deployment.ContractType = "CapabilitiesRegistry"
 	PriceFeed            deployment.ContractType = "PriceFeed"
 	// Note test router maps to a regular router contract.
-	TestRouter          deployment.ContractType = "TestRouter"
-	Multicall3          deployment.ContractType = "Multicall3"
-	CCIPReceiver        deployment.ContractType = "CCIPReceiver"
-	BurnMintToken       deployment.ContractType = "BurnMintToken"
-	BurnMintTokenPool   deployment.ContractType = "BurnMintTokenPool"
-	USDCToken           deployment.ContractType = "USDCToken"
-	USDCMockTransmitter deployment.ContractType = "USDCMockTransmitter"
-	USDCTokenMessenger  deployment.ContractType = "USDCTokenMessenger"
-	USDCTokenPool       deployment.ContractType = "USDCTokenPool"
+	TestRouter   deployment.ContractType = "TestRouter"
+	Multicall3   deployment.ContractType = "Multicall3"
+	CCIPReceiver deployment.ContractType = "CCIPReceiver"
+	// Note reverting receiver maps to a regular receiver contract, set to revert.
+	RevertingCCIPReceiver deployment.ContractType = "RevertingCCIPReceiver"
+	BurnMintToken         deployment.ContractType = "BurnMintToken"
+	BurnMintTokenPool     deployment.ContractType = "BurnMintTokenPool"
+	USDCToken             deployment.ContractType = "USDCToken"
+	USDCMockTransmitter   deployment.ContractType = "USDCMockTransmitter"
+	USDCTokenMessenger    deployment.ContractType = "USDCTokenMessenger"
+	USDCTokenPool         deployment.ContractType = "USDCTokenPool"
 )
 
 type DeployPrerequisiteContractsOpts struct {
@@ -84,7 +86,7 @@ func WithMulticall3(enabled bool) PrerequisiteOpt {
 func deployPrerequisiteChainContracts(e deployment.Environment, ab deployment.AddressBook, selectors []uint64, opts ...PrerequisiteOpt) error {
 	state, err := LoadOnchainState(e)
 	if err != nil {
-		e.Logger.Errorw("Failed to load existing onchain state", "err")
+		e.Logger.Errorw("Failed to load existing onchain state", "err", err)
 		return err
 	}
 	deployGrp := errgroup.Group{}
@@ -173,6 +175,8 @@ func deployPrerequisiteContracts(e deployment.Environment, ab deployment.Address
 		}
 		lggr.Infow("deployed RMNProxyNew", "addr", rmnProxyContract.Address)
 		rmnProxy = rmnProxyContract.Contract
+	} else {
+		lggr.Infow("rmnProxy already deployed", "addr", rmnProxy.Address())
 	}
 	if tokenAdminReg == nil {
 		tokenAdminRegistry, err := deployment.DeployContract(e.Logger, chain, ab,
@@ -314,10 +318,12 @@ func deployPrerequisiteContracts(e deployment.Environment, ab deployment.Address
 			return err
 		}
 		e.Logger.Infow("deployed ccip multicall", "addr", multicall3Contract.Address)
-	} else {
-		e.Logger.Info("ccip multicall already deployed", "addr", mc3.Address)
+	} else if mc3 != nil {
+		e.Logger.Infow("ccip multicall already deployed", "addr", mc3.Address)
 	}
-	if isUSDC {
+	if isUSDC && chainState.USDCTokenPool != nil {
+		e.Logger.Infow("USDC contracts already deployed", "chainSelector", chain.Selector, "pool", chainState.USDCTokenPool.Address())
+	} else if isUSDC {
 		token, pool, messenger, transmitter, err1 := DeployUSDC(e.Logger, chain, ab, rmnProxy.Address(), r.Address())
 		if err1 != nil {
 			return err1
@@ -0,0 +1,109 @@
+package changeset
+
+import (
+	"fmt"
+
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/timelock"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/maybe_revert_message_receiver"
+)
+
+var _ deployment.ChangeSet[DeployTestReceiverConfig] = DeployTestReceiverChangeset
+
+// TestReceiverMode is the behavior of a test receiver when it receives a message.
+type TestReceiverMode int
+
+const (
+	// TestReceiverModeNormal receivers accept every message. They are loaded into CCIPChainState.Receiver.
+	TestReceiverModeNormal TestReceiverMode = iota
+	// TestReceiverModeReverting receivers revert on every message, so that its execution fails.
+	// They are loaded into CCIPChainState.RevertingReceiver.
+	TestReceiverModeReverting
+)
+
+func (m TestReceiverMode) String() string {
+	switch m {
+	case TestReceiverModeNormal:
+		return "normal"
+	case TestReceiverModeReverting:
+		return "reverting"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(m))
+	}
+}
+
+type DeployTestReceiverConfig struct {
+	ChainSelectors []uint64
+	Mode           TestReceiverMode
+}
+
+func (c DeployTestReceiverConfig) Validate() error {
+	if len(c.ChainSelectors) == 0 {
+		return fmt.Errorf("no chain selectors provided")
+	}
+	for _, cs := range c.ChainSelectors {
+		if err := deployment.IsValidChainSelector(cs); err != nil {
+			return fmt.Errorf("invalid chain selector: %d - %w", cs, err)
+		}
+	}
+	if c.Mode != TestReceiverModeNormal && c.Mode != TestReceiverModeReverting {
+		return fmt.Errorf("invalid test receiver mode %s", c.Mode)
+	}
+	return nil
+}
+
+// DeployTestReceiverChangeset deploys a test receiver with the given mode on each of the given chains,
+// for testing how messages to receivers with that behavior are executed.
+// It is idempotent, a chain which already has a receiver with the mode is skipped.
+// Caller should update the environment's address book with the returned addresses.
+func DeployTestReceiverChangeset(env deployment.Environment, c DeployTestReceiverConfig) (deployment.ChangesetOutput, error) {
+	if err := c.Validate(); err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("invalid DeployTestReceiverConfig: %w", err)
+	}
+	state, err := LoadOnchainState(env)
+	if err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("failed to load onchain state: %w", err)
+	}
+
+	contractType, toRevert := CCIPReceiver, false
+	if c.Mode == TestReceiverModeReverting {
+		contractType, toRevert = RevertingCCIPReceiver, true
+	}
+	newAddresses := deployment.NewMemoryAddressBook()
+	for _, chainSel := range c.ChainSelectors {
+		chain, ok := env.Chains[chainSel]
+		if !ok {
+			return deployment.ChangesetOutput{}, fmt.Errorf("chain %d not found in environment", chainSel)
+		}
+		existing := state.Chains[chainSel].Receiver
+		if c.Mode == TestReceiverModeReverting {
+			existing = state.Chains[chainSel].RevertingReceiver
+		}
+		if existing != nil {
+			env.Logger.Infow("test receiver already deployed", "chain", chainSel, "mode", c.Mode, "addr", existing.Address())
+			continue
+		}
+
+		receiver, err := deployment.DeployContract(env.Logger, chain, newAddresses,
+			func(chain deployment.Chain) deployment.ContractDeploy[*maybe_revert_message_receiver.MaybeRevertMessageReceiver] {
+				receiverAddr, tx, receiver, err2 := maybe_revert_message_receiver.DeployMaybeRevertMessageReceiver(
+					chain.DeployerKey,
+					chain.Client,
+					toRevert,
+				)
+				return deployment.ContractDeploy[*maybe_revert_message_receiver.MaybeRevertMessageReceiver]{
+					receiverAddr, receiver, tx, deployment.NewTypeAndVersion(contractType, deployment.Version1_0_0), err2,
+				}
+			})
+		if err != nil {
+			env.Logger.Errorw("Failed to deploy test receiver", "chain", chainSel, "mode", c.Mode, "err", err)
+			return deployment.ChangesetOutput{AddressBook: newAddresses}, deployment.MaybeDataErr(err)
+		}
+		env.Logger.Infow("deployed test receiver", "chain", chainSel, "mode", c.Mode, "addr", receiver.Address)
+	}
+	return deployment.ChangesetOutput{
+		Proposals:   []timelock.MCMSWithTimelockProposal{},
+		AddressBook: newAddresses,
+	}, nil
+}
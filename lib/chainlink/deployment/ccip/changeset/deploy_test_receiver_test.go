@@ -0,0 +1,79 @@
+package changeset
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestDeployTestReceiverChangeset_Reverting(t *testing.T) {
+	ctx := testcontext.Get(t)
+	tenv := NewMemoryEnvironmentWithJobsAndContracts(t, logger.TestLogger(t), 2, 4, nil)
+	e := tenv.Env
+	state, err := LoadOnchainState(e)
+	require.NoError(t, err)
+	require.NoError(t, AddLanesForAll(e, state))
+	allChains := e.AllChainSelectors()
+	src, dest := allChains[0], allChains[1]
+
+	_, err = DeployTestReceiverChangeset(e, DeployTestReceiverConfig{
+		ChainSelectors: []uint64{dest},
+		Mode:           TestReceiverMode(42),
+	})
+	require.ErrorContains(t, err, "invalid test receiver mode")
+
+	out, err := DeployTestReceiverChangeset(e, DeployTestReceiverConfig{
+		ChainSelectors: []uint64{dest},
+		Mode:           TestReceiverModeReverting,
+	})
+	require.NoError(t, err)
+	require.NoError(t, e.ExistingAddresses.Merge(out.AddressBook))
+	state, err = LoadOnchainState(e)
+	require.NoError(t, err)
+	revertingReceiver := state.Chains[dest].RevertingReceiver
+	require.NotNil(t, revertingReceiver)
+	require.NotEqual(t, state.Chains[dest].Receiver.Address(), revertingReceiver.Address())
+	toRevert, err := revertingReceiver.SToRevert(&bind.CallOpts{Context: ctx})
+	require.NoError(t, err)
+	require.True(t, toRevert)
+
+	// deploying again is a no-op
+	out, err = DeployTestReceiverChangeset(e, DeployTestReceiverConfig{
+		ChainSelectors: []uint64{dest},
+		Mode:           TestReceiverModeReverting,
+	})
+	require.NoError(t, err)
+	addresses, err := out.AddressBook.Addresses()
+	require.NoError(t, err)
+	require.Empty(t, addresses)
+
+	latesthdr, err := e.Chains[dest].Client.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	block := latesthdr.Number.Uint64()
+	startBlocks := map[uint64]*uint64{dest: &block}
+	msgSentEvent := TestSendRequest(t, e, state, src, dest, false, router.ClientEVM2AnyMessage{
+		Receiver:     common.LeftPadBytes(revertingReceiver.Address().Bytes(), 32),
+		Data:         []byte("hello reverting receiver"),
+		TokenAmounts: nil,
+		FeeToken:     common.HexToAddress("0x0"),
+	})
+
+	identifier := SourceDestPair{
+		SourceChainSelector: src,
+		DestChainSelector:   dest,
+	}
+	ConfirmCommitForAllWithExpectedSeqNums(t, e, state, map[SourceDestPair]uint64{
+		identifier: msgSentEvent.SequenceNumber,
+	}, startBlocks)
+	states := ConfirmExecWithSeqNrsForAll(t, e, state, map[SourceDestPair][]uint64{
+		identifier: {msgSentEvent.SequenceNumber},
+	}, startBlocks)
+	require.Equal(t, EXECUTION_STATE_FAILURE, states[identifier][msgSentEvent.SequenceNumber])
+}
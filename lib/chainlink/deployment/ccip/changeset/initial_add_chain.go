@@ -170,20 +170,7 @@ func DefaultOCRParams(
 	tokenInfo map[ccipocr3.UnknownEncodedAddress]pluginconfig.TokenInfo,
 ) CCIPOCRParams {
 	return CCIPOCRParams{
-		OCRParameters: types.OCRParameters{
-			DeltaProgress:                           internal.DeltaProgress,
-			DeltaResend:                             internal.DeltaResend,
-			DeltaInitial:                            internal.DeltaInitial,
-			DeltaRound:                              internal.DeltaRound,
-			DeltaGrace:                              internal.DeltaGrace,
-			DeltaCertifiedCommitRequest:             internal.DeltaCertifiedCommitRequest,
-			DeltaStage:                              internal.DeltaStage,
-			Rmax:                                    internal.Rmax,
-			MaxDurationQuery:                        internal.MaxDurationQuery,
-			MaxDurationObservation:                  internal.MaxDurationObservation,
-			MaxDurationShouldAcceptAttestedReport:   internal.MaxDurationShouldAcceptAttestedReport,
-			MaxDurationShouldTransmitAcceptedReport: internal.MaxDurationShouldTransmitAcceptedReport,
-		},
+		OCRParameters: types.DefaultOCRParameters(),
 		ExecuteOffChainConfig: pluginconfig.ExecuteOffchainConfig{
 			BatchGasLimit:             internal.BatchGasLimit,
 			RelativeBoostPerWaitHour:  internal.RelativeBoostPerWaitHour,
@@ -26,26 +26,14 @@ const (
 	CapabilityLabelledName = "ccip"
 	CapabilityVersion      = "v1.0.0"
 
-	FirstBlockAge                           = 8 * time.Hour
-	RemoteGasPriceBatchWriteFrequency       = 30 * time.Minute
-	TokenPriceBatchWriteFrequency           = 30 * time.Minute
-	BatchGasLimit                           = 6_500_000
-	RelativeBoostPerWaitHour                = 10000.5
-	InflightCacheExpiry                     = 10 * time.Minute
-	RootSnoozeTime                          = 30 * time.Minute
-	BatchingStrategyID                      = 0
-	DeltaProgress                           = 30 * time.Second
-	DeltaResend                             = 10 * time.Second
-	DeltaInitial                            = 20 * time.Second
-	DeltaRound                              = 2 * time.Second
-	DeltaGrace                              = 2 * time.Second
-	DeltaCertifiedCommitRequest             = 10 * time.Second
-	DeltaStage                              = 10 * time.Second
-	Rmax                                    = 3
-	MaxDurationQuery                        = 500 * time.Millisecond
-	MaxDurationObservation                  = 5 * time.Second
-	MaxDurationShouldAcceptAttestedReport   = 10 * time.Second
-	MaxDurationShouldTransmitAcceptedReport = 10 * time.Second
+	FirstBlockAge                     = 8 * time.Hour
+	RemoteGasPriceBatchWriteFrequency = 30 * time.Minute
+	TokenPriceBatchWriteFrequency     = 30 * time.Minute
+	BatchGasLimit                     = 6_500_000
+	RelativeBoostPerWaitHour          = 10000.5
+	InflightCacheExpiry               = 10 * time.Minute
+	RootSnoozeTime                    = 30 * time.Minute
+	BatchingStrategyID                = 0
 )
 
 var (
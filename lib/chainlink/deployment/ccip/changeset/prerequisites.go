@@ -17,6 +17,8 @@ var (
 // DeployPrerequisites deploys the pre-requisite contracts for CCIP
 // pre-requisite contracts are the contracts which can be reused from previous versions of CCIP
 // Or the contracts which are already deployed on the chain ( for example, tokens, feeds, etc)
+// Contracts already present in the environment's address book are skipped, so the returned address book only
+// contains newly deployed contracts and re-running the changeset is a no-op.
 // Caller should update the environment's address book with the returned addresses.
 func DeployPrerequisites(env deployment.Environment, cfg DeployPrerequisiteConfig) (deployment.ChangesetOutput, error) {
 	err := cfg.Validate()
@@ -41,6 +43,7 @@ func DeployPrerequisites(env deployment.Environment, cfg DeployPrerequisiteConfi
 type DeployPrerequisiteConfig struct {
 	ChainSelectors []uint64
 	Opts           []PrerequisiteOpt
+	// Tokens and Feeds are not yet supported, Validate rejects non-empty maps rather than silently ignoring them.
 	// TODO handle tokens and feeds in prerequisite config
 	Tokens map[TokenSymbol]common.Address
 	Feeds  map[TokenSymbol]common.Address
@@ -54,5 +57,11 @@ func (c DeployPrerequisiteConfig) Validate() error {
 			return fmt.Errorf("invalid chain selector: %d - %w", cs, err)
 		}
 	}
+	if len(c.Tokens) > 0 {
+		return fmt.Errorf("tokens are not yet supported in prerequisite config, got %d", len(c.Tokens))
+	}
+	if len(c.Feeds) > 0 {
+		return fmt.Errorf("feeds are not yet supported in prerequisite config, got %d", len(c.Feeds))
+	}
 	return nil
 }
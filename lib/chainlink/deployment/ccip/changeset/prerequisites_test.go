@@ -3,9 +3,12 @@ package changeset
 import (
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
+	chainsel "github.com/smartcontractkit/chain-selectors"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zapcore"
 
+	"github.com/smartcontractkit/chainlink/deployment"
 	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 )
@@ -34,3 +37,77 @@ func TestDeployPrerequisites(t *testing.T) {
 	require.NotNil(t, state.Chains[newChain].RegistryModule)
 	require.NotNil(t, state.Chains[newChain].Router)
 }
+
+func TestDeployPrerequisites_Idempotent(t *testing.T) {
+	t.Parallel()
+	lggr := logger.TestLogger(t)
+	e := memory.NewMemoryEnvironment(t, lggr, zapcore.InfoLevel, memory.MemoryEnvironmentConfig{
+		Bootstraps: 1,
+		Chains:     2,
+		Nodes:      4,
+	})
+	newChain := e.AllChainSelectors()[0]
+	cfg := DeployPrerequisiteConfig{
+		ChainSelectors: []uint64{newChain},
+		Opts: []PrerequisiteOpt{
+			WithUSDCChains([]uint64{newChain}),
+			WithMulticall3(true),
+		},
+	}
+	output, err := DeployPrerequisites(e, cfg)
+	require.NoError(t, err)
+	addrs, err := output.AddressBook.AddressesForChain(newChain)
+	require.NoError(t, err)
+	require.NotEmpty(t, addrs)
+	require.NoError(t, e.ExistingAddresses.Merge(output.AddressBook))
+
+	// everything is already deployed, so the second run deploys nothing
+	output, err = DeployPrerequisites(e, cfg)
+	require.NoError(t, err)
+	_, err = output.AddressBook.AddressesForChain(newChain)
+	require.ErrorIs(t, err, deployment.ErrChainNotFound)
+}
+
+func TestDeployPrerequisiteConfig_Validate(t *testing.T) {
+	t.Parallel()
+	chainSel := chainsel.TEST_90000001.Selector
+	tests := []struct {
+		name   string
+		config DeployPrerequisiteConfig
+		errStr string
+	}{
+		{
+			name: "empty tokens and feeds",
+			config: DeployPrerequisiteConfig{
+				ChainSelectors: []uint64{chainSel},
+				Tokens:         map[TokenSymbol]common.Address{},
+			},
+		},
+		{
+			name: "tokens not supported",
+			config: DeployPrerequisiteConfig{
+				ChainSelectors: []uint64{chainSel},
+				Tokens:         map[TokenSymbol]common.Address{LinkSymbol: common.HexToAddress("0x1")},
+			},
+			errStr: "tokens are not yet supported in prerequisite config, got 1",
+		},
+		{
+			name: "feeds not supported",
+			config: DeployPrerequisiteConfig{
+				ChainSelectors: []uint64{chainSel},
+				Feeds:          map[TokenSymbol]common.Address{WethSymbol: common.HexToAddress("0x2")},
+			},
+			errStr: "feeds are not yet supported in prerequisite config, got 1",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.Validate()
+			if tc.errStr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.errStr)
+		})
+	}
+}
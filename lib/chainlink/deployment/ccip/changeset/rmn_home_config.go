@@ -0,0 +1,298 @@
+package changeset
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/timelock"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/common/proposalutils"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/rmn_home"
+	p2ptypes "github.com/smartcontractkit/chainlink/v2/core/services/p2p/types"
+)
+
+var (
+	_ deployment.ChangeSet[SetRMNHomeCandidateConfig]     = SetRMNHomeCandidateChangeset
+	_ deployment.ChangeSet[PromoteRMNHomeCandidateConfig] = PromoteRMNHomeCandidateChangeset
+)
+
+// MCMSConfig is set on changesets that can either send transactions directly with the
+// deployer key or generate an MCMS proposal for timelock owned contracts.
+type MCMSConfig struct {
+	// MinDelay is the minimum amount of time that must pass before the proposal
+	// can be executed onchain.
+	MinDelay time.Duration
+}
+
+type SetRMNHomeCandidateConfig struct {
+	HomeChainSelector uint64
+	RMNStaticConfig   rmn_home.RMNHomeStaticConfig
+	RMNDynamicConfig  rmn_home.RMNHomeDynamicConfig
+	// MCMS is nil if the RMNHome is owned by the deployer, in which case the candidate is set directly.
+	// Otherwise a proposal is generated for the timelock owning the RMNHome.
+	MCMS *MCMSConfig
+}
+
+func (c SetRMNHomeCandidateConfig) Validate() error {
+	if err := deployment.IsValidChainSelector(c.HomeChainSelector); err != nil {
+		return fmt.Errorf("invalid home chain selector %d: %w", c.HomeChainSelector, err)
+	}
+	if c.RMNStaticConfig.OffchainConfig == nil {
+		return fmt.Errorf("offchain config for RMNHomeStaticConfig must be set")
+	}
+	if c.RMNDynamicConfig.OffchainConfig == nil {
+		return fmt.Errorf("offchain config for RMNHomeDynamicConfig must be set")
+	}
+	seenPeerIDs := make(map[[32]byte]struct{})
+	for _, node := range c.RMNStaticConfig.Nodes {
+		if _, ok := seenPeerIDs[node.PeerId]; ok {
+			return fmt.Errorf("duplicate RMN node peer id %x", node.PeerId)
+		}
+		seenPeerIDs[node.PeerId] = struct{}{}
+	}
+	for _, sourceChain := range c.RMNDynamicConfig.SourceChains {
+		if sourceChain.ObserverNodesBitmap == nil {
+			return fmt.Errorf("observer nodes bitmap for source chain %d must be set", sourceChain.ChainSelector)
+		}
+		if sourceChain.ObserverNodesBitmap.BitLen() > len(c.RMNStaticConfig.Nodes) {
+			return fmt.Errorf("observer nodes bitmap for source chain %d references nodes outside of the %d configured nodes",
+				sourceChain.ChainSelector, len(c.RMNStaticConfig.Nodes))
+		}
+		observers := uint64(0)
+		for i := 0; i < sourceChain.ObserverNodesBitmap.BitLen(); i++ {
+			observers += uint64(sourceChain.ObserverNodesBitmap.Bit(i))
+		}
+		if observers < 2*sourceChain.F+1 {
+			return fmt.Errorf("not enough observers for source chain %d: F %d requires at least %d observers, got %d",
+				sourceChain.ChainSelector, sourceChain.F, 2*sourceChain.F+1, observers)
+		}
+	}
+	return nil
+}
+
+// SetRMNHomeCandidateChangeset sets the given static and dynamic config as the candidate config of the RMNHome,
+// overwriting any existing candidate.
+// The candidate needs to be promoted with PromoteRMNHomeCandidateChangeset before the RMN nodes pick it up.
+func SetRMNHomeCandidateChangeset(e deployment.Environment, cfg SetRMNHomeCandidateConfig) (deployment.ChangesetOutput, error) {
+	if err := cfg.Validate(); err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("invalid SetRMNHomeCandidateConfig: %w", err)
+	}
+	homeChain, homeChainState, err := loadRMNHomeChain(e, cfg.HomeChainSelector, cfg.MCMS)
+	if err != nil {
+		return deployment.ChangesetOutput{}, err
+	}
+	rmnHome := homeChainState.RMNHome
+
+	previousCandidate, err := rmnHome.GetCandidateDigest(&bind.CallOpts{Context: e.GetContext()})
+	if err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("failed to get RMNHome candidate digest: %w", err)
+	}
+
+	if cfg.MCMS != nil {
+		tx, err := rmnHome.SetCandidate(deployment.SimTransactOpts(), cfg.RMNStaticConfig, cfg.RMNDynamicConfig, previousCandidate)
+		if err != nil {
+			return deployment.ChangesetOutput{}, fmt.Errorf("failed to build setCandidate call for RMNHome: %w", err)
+		}
+		return buildRMNHomeProposal(cfg.HomeChainSelector, homeChainState, tx.Data(), "set RMNHome candidate", cfg.MCMS.MinDelay)
+	}
+
+	tx, err := rmnHome.SetCandidate(homeChain.DeployerKey, cfg.RMNStaticConfig, cfg.RMNDynamicConfig, previousCandidate)
+	if _, err := deployment.ConfirmIfNoError(homeChain, tx, err); err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("failed to set RMNHome candidate: %w", err)
+	}
+	newCandidate, err := rmnHome.GetCandidateDigest(&bind.CallOpts{Context: e.GetContext()})
+	if err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("failed to get RMNHome candidate digest: %w", err)
+	}
+	if newCandidate == ([32]byte{}) || newCandidate == previousCandidate {
+		return deployment.ChangesetOutput{}, fmt.Errorf("RMNHome candidate digest was not updated, still %x", newCandidate)
+	}
+	e.Logger.Infow("Set RMNHome candidate", "previousCandidate", fmt.Sprintf("%x", previousCandidate), "candidate", fmt.Sprintf("%x", newCandidate))
+	return deployment.ChangesetOutput{}, nil
+}
+
+type PromoteRMNHomeCandidateConfig struct {
+	HomeChainSelector uint64
+	// DigestToPromote must match the current candidate digest of the RMNHome.
+	// The current active config is revoked.
+	DigestToPromote [32]byte
+	// MCMS is nil if the RMNHome is owned by the deployer, in which case the candidate is promoted directly.
+	// Otherwise a proposal is generated for the timelock owning the RMNHome.
+	MCMS *MCMSConfig
+}
+
+func (c PromoteRMNHomeCandidateConfig) Validate() error {
+	if err := deployment.IsValidChainSelector(c.HomeChainSelector); err != nil {
+		return fmt.Errorf("invalid home chain selector %d: %w", c.HomeChainSelector, err)
+	}
+	if c.DigestToPromote == ([32]byte{}) {
+		return errors.New("digest to promote must be set")
+	}
+	return nil
+}
+
+// PromoteRMNHomeCandidateChangeset promotes the RMNHome candidate config to active, revoking the current active config.
+func PromoteRMNHomeCandidateChangeset(e deployment.Environment, cfg PromoteRMNHomeCandidateConfig) (deployment.ChangesetOutput, error) {
+	if err := cfg.Validate(); err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("invalid PromoteRMNHomeCandidateConfig: %w", err)
+	}
+	homeChain, homeChainState, err := loadRMNHomeChain(e, cfg.HomeChainSelector, cfg.MCMS)
+	if err != nil {
+		return deployment.ChangesetOutput{}, err
+	}
+	rmnHome := homeChainState.RMNHome
+
+	digests, err := rmnHome.GetConfigDigests(&bind.CallOpts{Context: e.GetContext()})
+	if err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("failed to get RMNHome config digests: %w", err)
+	}
+	if digests.CandidateConfigDigest != cfg.DigestToPromote {
+		return deployment.ChangesetOutput{}, fmt.Errorf("digest to promote %x does not match RMNHome candidate digest %x",
+			cfg.DigestToPromote, digests.CandidateConfigDigest)
+	}
+
+	if cfg.MCMS != nil {
+		tx, err := rmnHome.PromoteCandidateAndRevokeActive(deployment.SimTransactOpts(), cfg.DigestToPromote, digests.ActiveConfigDigest)
+		if err != nil {
+			return deployment.ChangesetOutput{}, fmt.Errorf("failed to build promoteCandidateAndRevokeActive call for RMNHome: %w", err)
+		}
+		return buildRMNHomeProposal(cfg.HomeChainSelector, homeChainState, tx.Data(), "promote RMNHome candidate", cfg.MCMS.MinDelay)
+	}
+
+	tx, err := rmnHome.PromoteCandidateAndRevokeActive(homeChain.DeployerKey, cfg.DigestToPromote, digests.ActiveConfigDigest)
+	if _, err := deployment.ConfirmIfNoError(homeChain, tx, err); err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("failed to promote RMNHome candidate: %w", err)
+	}
+	activeDigest, err := rmnHome.GetActiveDigest(&bind.CallOpts{Context: e.GetContext()})
+	if err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("failed to get RMNHome active digest: %w", err)
+	}
+	if activeDigest != cfg.DigestToPromote {
+		return deployment.ChangesetOutput{}, fmt.Errorf("RMNHome active digest %x does not match promoted candidate digest %x",
+			activeDigest, cfg.DigestToPromote)
+	}
+	e.Logger.Infow("Promoted RMNHome candidate", "revoked", fmt.Sprintf("%x", digests.ActiveConfigDigest), "active", fmt.Sprintf("%x", activeDigest))
+	return deployment.ChangesetOutput{}, nil
+}
+
+// NewRMNHomeNode builds the RMNHome configuration of an RMN node from the peer ID of its rageproxy, in the base58
+// form it is printed in, and its ed25519 offchain public key. A misconfigured node is reported here, where it would
+// otherwise only surface as an opaque revert of the RMNHome.
+func NewRMNHomeNode(peerID string, offchainPublicKey []byte) (rmn_home.RMNHomeNode, error) {
+	var id p2ptypes.PeerID
+	if err := id.UnmarshalText([]byte(peerID)); err != nil {
+		return rmn_home.RMNHomeNode{}, fmt.Errorf("malformed RMN node peer id %q: %w", peerID, err)
+	}
+	if id == (p2ptypes.PeerID{}) {
+		return rmn_home.RMNHomeNode{}, fmt.Errorf("RMN node peer id %q is zero", peerID)
+	}
+	if len(offchainPublicKey) != ed25519.PublicKeySize {
+		return rmn_home.RMNHomeNode{}, fmt.Errorf("offchain public key of RMN node %s must be %d bytes, got %d",
+			peerID, ed25519.PublicKeySize, len(offchainPublicKey))
+	}
+	var key [ed25519.PublicKeySize]byte
+	copy(key[:], offchainPublicKey)
+	if key == ([ed25519.PublicKeySize]byte{}) {
+		return rmn_home.RMNHomeNode{}, fmt.Errorf("offchain public key of RMN node %s is zero", peerID)
+	}
+	return rmn_home.RMNHomeNode{
+		PeerId:            id,
+		OffchainPublicKey: key,
+	}, nil
+}
+
+// CreateObserverNodesBitmap builds the RMNHome observer nodes bitmap for the given source chain.
+// observedChainsByNodeIndex maps the index of a node in rmnHomeNodes to the chain selectors it observes.
+// It returns an error if a node observing the chain is not present in rmnHomeNodes.
+func CreateObserverNodesBitmap(
+	chainSel uint64,
+	rmnHomeNodes []rmn_home.RMNHomeNode,
+	observedChainsByNodeIndex map[int][]uint64,
+) (*big.Int, error) {
+	bitmap := new(big.Int)
+	for nodeIndex, observedChains := range observedChainsByNodeIndex {
+		if !slices.Contains(observedChains, chainSel) {
+			continue
+		}
+		if nodeIndex < 0 || nodeIndex >= len(rmnHomeNodes) {
+			return nil, fmt.Errorf("node index %d observing chain %d is out of range, only %d RMNHome nodes are configured",
+				nodeIndex, chainSel, len(rmnHomeNodes))
+		}
+		bitmap.SetBit(bitmap, nodeIndex, 1)
+	}
+	return bitmap, nil
+}
+
+// loadRMNHomeChain returns the home chain and its state, checking that the RMNHome is owned by
+// the timelock if mcmsCfg is set and by the deployer otherwise.
+func loadRMNHomeChain(e deployment.Environment, homeChainSel uint64, mcmsCfg *MCMSConfig) (deployment.Chain, CCIPChainState, error) {
+	homeChain, ok := e.Chains[homeChainSel]
+	if !ok {
+		return deployment.Chain{}, CCIPChainState{}, fmt.Errorf("home chain %d not found in environment", homeChainSel)
+	}
+	state, err := LoadOnchainState(e)
+	if err != nil {
+		return deployment.Chain{}, CCIPChainState{}, fmt.Errorf("failed to load onchain state: %w", err)
+	}
+	homeChainState, ok := state.Chains[homeChainSel]
+	if !ok || homeChainState.RMNHome == nil {
+		return deployment.Chain{}, CCIPChainState{}, fmt.Errorf("RMNHome not found for home chain %d", homeChainSel)
+	}
+	owner, err := homeChainState.RMNHome.Owner(&bind.CallOpts{Context: e.GetContext()})
+	if err != nil {
+		return deployment.Chain{}, CCIPChainState{}, fmt.Errorf("failed to get RMNHome owner: %w", err)
+	}
+	expectedOwner := homeChain.DeployerKey.From
+	if mcmsCfg != nil {
+		if homeChainState.Timelock == nil || homeChainState.ProposerMcm == nil {
+			return deployment.Chain{}, CCIPChainState{}, fmt.Errorf("timelock or proposer MCMS not found for home chain %d", homeChainSel)
+		}
+		expectedOwner = homeChainState.Timelock.Address()
+	}
+	if owner != expectedOwner {
+		return deployment.Chain{}, CCIPChainState{}, fmt.Errorf("RMNHome is owned by %s, expected %s", owner.Hex(), expectedOwner.Hex())
+	}
+	return homeChain, homeChainState, nil
+}
+
+func buildRMNHomeProposal(
+	homeChainSel uint64,
+	homeChainState CCIPChainState,
+	data []byte,
+	description string,
+	minDelay time.Duration,
+) (deployment.ChangesetOutput, error) {
+	prop, err := proposalutils.BuildProposalFromBatches(
+		map[uint64]common.Address{
+			homeChainSel: homeChainState.Timelock.Address(),
+		},
+		map[uint64]*gethwrappers.ManyChainMultiSig{
+			homeChainSel: homeChainState.ProposerMcm,
+		},
+		[]timelock.BatchChainOperation{{
+			ChainIdentifier: mcms.ChainIdentifier(homeChainSel),
+			Batch: []mcms.Operation{{
+				To:    homeChainState.RMNHome.Address(),
+				Data:  data,
+				Value: big.NewInt(0),
+			}},
+		}},
+		description,
+		minDelay,
+	)
+	if err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("failed to build proposal: %w", err)
+	}
+	return deployment.ChangesetOutput{
+		Proposals: []timelock.MCMSWithTimelockProposal{*prop},
+	}, nil
+}
@@ -0,0 +1,227 @@
+package changeset
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/gethwrappers"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/rmn_home"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	p2ptypes "github.com/smartcontractkit/chainlink/v2/core/services/p2p/types"
+)
+
+func TestSetRMNHomeCandidateConfig_Validate(t *testing.T) {
+	nodes := []rmn_home.RMNHomeNode{{PeerId: [32]byte{1}}, {PeerId: [32]byte{2}}, {PeerId: [32]byte{3}}}
+	newCfg := func(sourceChains ...rmn_home.RMNHomeSourceChain) SetRMNHomeCandidateConfig {
+		return SetRMNHomeCandidateConfig{
+			HomeChainSelector: chainsel.TEST_90000001.Selector,
+			RMNStaticConfig:   rmn_home.RMNHomeStaticConfig{Nodes: nodes, OffchainConfig: []byte{}},
+			RMNDynamicConfig:  rmn_home.RMNHomeDynamicConfig{SourceChains: sourceChains, OffchainConfig: []byte{}},
+		}
+	}
+
+	require.NoError(t, newCfg(rmn_home.RMNHomeSourceChain{ChainSelector: 1, F: 1, ObserverNodesBitmap: big.NewInt(0b111)}).Validate())
+
+	cfg := newCfg()
+	cfg.HomeChainSelector = 0
+	require.ErrorContains(t, cfg.Validate(), "invalid home chain selector")
+
+	cfg = newCfg()
+	cfg.RMNStaticConfig.OffchainConfig = nil
+	require.ErrorContains(t, cfg.Validate(), "offchain config for RMNHomeStaticConfig must be set")
+
+	cfg = newCfg()
+	cfg.RMNStaticConfig.Nodes = append(cfg.RMNStaticConfig.Nodes, nodes[0])
+	require.ErrorContains(t, cfg.Validate(), "duplicate RMN node peer id")
+
+	cfg = newCfg(rmn_home.RMNHomeSourceChain{ChainSelector: 1})
+	require.ErrorContains(t, cfg.Validate(), "observer nodes bitmap for source chain 1 must be set")
+
+	cfg = newCfg(rmn_home.RMNHomeSourceChain{ChainSelector: 1, ObserverNodesBitmap: big.NewInt(0b1000)})
+	require.ErrorContains(t, cfg.Validate(), "references nodes outside of the 3 configured nodes")
+
+	cfg = newCfg(rmn_home.RMNHomeSourceChain{ChainSelector: 1, F: 1, ObserverNodesBitmap: big.NewInt(0b011)})
+	require.ErrorContains(t, cfg.Validate(), "not enough observers for source chain 1")
+}
+
+func TestNewRMNHomeNode(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	// the peer id of a node is its rageproxy's public key, any key will do here
+	peerID := p2ptypes.PeerID(pub)
+
+	node, err := NewRMNHomeNode(peerID.String(), pub)
+	require.NoError(t, err)
+	require.Equal(t, [32]byte(peerID), node.PeerId)
+	require.Equal(t, [32]byte(pub), node.OffchainPublicKey)
+
+	_, err = NewRMNHomeNode("not-a-peer-id", pub)
+	require.ErrorContains(t, err, "malformed RMN node peer id")
+
+	// a public key is not a peer id, it lacks the libp2p prefix
+	_, err = NewRMNHomeNode(hex.EncodeToString(pub), pub)
+	require.ErrorContains(t, err, "malformed RMN node peer id")
+
+	_, err = NewRMNHomeNode(p2ptypes.PeerID{}.String(), pub)
+	require.ErrorContains(t, err, "is zero")
+
+	_, err = NewRMNHomeNode(peerID.String(), make([]byte, ed25519.PublicKeySize))
+	require.ErrorContains(t, err, "offchain public key of RMN node "+peerID.String()+" is zero")
+
+	_, err = NewRMNHomeNode(peerID.String(), pub[:16])
+	require.ErrorContains(t, err, "must be 32 bytes, got 16")
+}
+
+func TestCreateObserverNodesBitmap(t *testing.T) {
+	nodes := []rmn_home.RMNHomeNode{{PeerId: [32]byte{1}}, {PeerId: [32]byte{2}}, {PeerId: [32]byte{3}}}
+	const chainA, chainB = uint64(100), uint64(200)
+
+	t.Run("valid", func(t *testing.T) {
+		bitmap, err := CreateObserverNodesBitmap(chainA, nodes, map[int][]uint64{
+			0: {chainA, chainB},
+			1: {chainB},
+			2: {chainA},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "101", bitmap.Text(2))
+	})
+
+	t.Run("out of range node index", func(t *testing.T) {
+		_, err := CreateObserverNodesBitmap(chainA, nodes, map[int][]uint64{
+			0: {chainA},
+			3: {chainA},
+		})
+		require.ErrorContains(t, err, "node index 3 observing chain 100 is out of range, only 3 RMNHome nodes are configured")
+	})
+
+	t.Run("out of range node not observing the chain", func(t *testing.T) {
+		bitmap, err := CreateObserverNodesBitmap(chainA, nodes, map[int][]uint64{
+			1: {chainA},
+			5: {chainB},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "10", bitmap.Text(2))
+	})
+}
+
+func TestRMNHomeCandidateChangesets(t *testing.T) {
+	ctx := testcontext.Get(t)
+	e := NewMemoryEnvironmentWithJobsAndContracts(t, logger.TestLogger(t), 2, 4, nil)
+	state, err := LoadOnchainState(e.Env)
+	require.NoError(t, err)
+	rmnHome := state.Chains[e.HomeChainSel].RMNHome
+
+	staticConfig := rmn_home.RMNHomeStaticConfig{
+		Nodes: []rmn_home.RMNHomeNode{
+			{PeerId: [32]byte{1}, OffchainPublicKey: [32]byte{11}},
+			{PeerId: [32]byte{2}, OffchainPublicKey: [32]byte{12}},
+			{PeerId: [32]byte{3}, OffchainPublicKey: [32]byte{13}},
+		},
+		OffchainConfig: []byte{},
+	}
+	dynamicConfig := rmn_home.RMNHomeDynamicConfig{
+		SourceChains: []rmn_home.RMNHomeSourceChain{
+			{ChainSelector: e.FeedChainSel, F: 1, ObserverNodesBitmap: big.NewInt(0b111)},
+		},
+		OffchainConfig: []byte{},
+	}
+
+	// rotateConfig sets a candidate and promotes it to active.
+	rotateConfig := func(t *testing.T, mcmsCfg *MCMSConfig, timelocks map[uint64]*gethwrappers.RBACTimelock) {
+		previousActive, err := rmnHome.GetActiveDigest(&bind.CallOpts{Context: ctx})
+		require.NoError(t, err)
+
+		_, err = commonchangeset.ApplyChangesets(t, e.Env, timelocks, []commonchangeset.ChangesetApplication{
+			{
+				Changeset: commonchangeset.WrapChangeSet(SetRMNHomeCandidateChangeset),
+				Config: SetRMNHomeCandidateConfig{
+					HomeChainSelector: e.HomeChainSel,
+					RMNStaticConfig:   staticConfig,
+					RMNDynamicConfig:  dynamicConfig,
+					MCMS:              mcmsCfg,
+				},
+			},
+		})
+		require.NoError(t, err)
+		candidate, err := rmnHome.GetCandidateDigest(&bind.CallOpts{Context: ctx})
+		require.NoError(t, err)
+		require.NotEqual(t, [32]byte{}, candidate)
+
+		_, err = commonchangeset.ApplyChangesets(t, e.Env, timelocks, []commonchangeset.ChangesetApplication{
+			{
+				Changeset: commonchangeset.WrapChangeSet(PromoteRMNHomeCandidateChangeset),
+				Config: PromoteRMNHomeCandidateConfig{
+					HomeChainSelector: e.HomeChainSel,
+					DigestToPromote:   candidate,
+					MCMS:              mcmsCfg,
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		digests, err := rmnHome.GetConfigDigests(&bind.CallOpts{Context: ctx})
+		require.NoError(t, err)
+		require.Equal(t, candidate, digests.ActiveConfigDigest)
+		require.Equal(t, [32]byte{}, digests.CandidateConfigDigest)
+		require.NotEqual(t, previousActive, digests.ActiveConfigDigest)
+
+		activeConfig, err := rmnHome.GetConfig(&bind.CallOpts{Context: ctx}, digests.ActiveConfigDigest)
+		require.NoError(t, err)
+		require.True(t, activeConfig.Ok)
+		require.Equal(t, staticConfig.Nodes, activeConfig.VersionedConfig.StaticConfig.Nodes)
+	}
+
+	t.Run("deployer owned", func(t *testing.T) {
+		rotateConfig(t, nil, nil)
+
+		_, err := PromoteRMNHomeCandidateChangeset(e.Env, PromoteRMNHomeCandidateConfig{
+			HomeChainSelector: e.HomeChainSel,
+			DigestToPromote:   [32]byte{1},
+		})
+		require.ErrorContains(t, err, "does not match RMNHome candidate digest")
+	})
+
+	t.Run("timelock owned", func(t *testing.T) {
+		homeState := state.Chains[e.HomeChainSel]
+		timelocks := map[uint64]*gethwrappers.RBACTimelock{e.HomeChainSel: homeState.Timelock}
+		_, err := commonchangeset.ApplyChangesets(t, e.Env, timelocks, []commonchangeset.ChangesetApplication{
+			{
+				Changeset: commonchangeset.WrapChangeSet(commonchangeset.NewTransferOwnershipChangeset),
+				Config: commonchangeset.TransferOwnershipConfig{
+					TimelocksPerChain: map[uint64]common.Address{e.HomeChainSel: homeState.Timelock.Address()},
+					Contracts:         map[uint64][]commonchangeset.OwnershipTransferrer{e.HomeChainSel: {rmnHome}},
+				},
+			},
+			{
+				Changeset: commonchangeset.WrapChangeSet(commonchangeset.NewAcceptOwnershipChangeset),
+				Config: commonchangeset.AcceptOwnershipConfig{
+					TimelocksPerChain: map[uint64]common.Address{e.HomeChainSel: homeState.Timelock.Address()},
+					ProposerMCMSes:    map[uint64]*gethwrappers.ManyChainMultiSig{e.HomeChainSel: homeState.ProposerMcm},
+					Contracts:         map[uint64][]commonchangeset.OwnershipAcceptor{e.HomeChainSel: {rmnHome}},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		// the deployer can no longer update the RMNHome directly.
+		_, err = SetRMNHomeCandidateChangeset(e.Env, SetRMNHomeCandidateConfig{
+			HomeChainSelector: e.HomeChainSel,
+			RMNStaticConfig:   staticConfig,
+			RMNDynamicConfig:  dynamicConfig,
+		})
+		require.ErrorContains(t, err, "RMNHome is owned by "+homeState.Timelock.Address().Hex())
+
+		rotateConfig(t, &MCMSConfig{MinDelay: 0}, timelocks)
+	})
+}
@@ -0,0 +1,190 @@
+package changeset
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/timelock"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/common/proposalutils"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/rmn_remote"
+)
+
+var _ deployment.ChangeSet[RotateRMNSignerConfig] = RotateRMNSignerChangeset
+
+type RotateRMNSignerConfig struct {
+	// NodeIndex is the index of the RMN node whose signer is rotated.
+	NodeIndex uint64
+	// NewOnchainPublicKey replaces the onchain public key of the signer.
+	NewOnchainPublicKey common.Address
+	// ChainSelectors are the chains whose RMNRemote config is updated.
+	ChainSelectors []uint64
+	// MCMS is nil if the RMNRemotes are owned by the deployer, in which case the configs are set directly.
+	// Otherwise a proposal is generated for the timelocks owning the RMNRemotes.
+	MCMS *MCMSConfig
+}
+
+func (c RotateRMNSignerConfig) Validate() error {
+	if c.NewOnchainPublicKey == (common.Address{}) {
+		return errors.New("new onchain public key must be set")
+	}
+	if len(c.ChainSelectors) == 0 {
+		return errors.New("no chain selectors provided")
+	}
+	seen := make(map[uint64]struct{})
+	for _, chainSel := range c.ChainSelectors {
+		if err := deployment.IsValidChainSelector(chainSel); err != nil {
+			return fmt.Errorf("invalid chain selector %d: %w", chainSel, err)
+		}
+		if _, ok := seen[chainSel]; ok {
+			return fmt.Errorf("duplicate chain selector %d", chainSel)
+		}
+		seen[chainSel] = struct{}{}
+	}
+	return nil
+}
+
+// RotateRMNSignerChangeset replaces the onchain public key of a single RMN signer in the RMNRemote config of each
+// given chain, keeping the rest of the current config as is.
+// The RMNHome config only holds the offchain keys of the RMN nodes, so it does not need a new candidate and the
+// RMNRemote configs keep pointing at the same active RMNHome digest.
+func RotateRMNSignerChangeset(e deployment.Environment, cfg RotateRMNSignerConfig) (deployment.ChangesetOutput, error) {
+	if err := cfg.Validate(); err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("invalid RotateRMNSignerConfig: %w", err)
+	}
+	state, err := LoadOnchainState(e)
+	if err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("failed to load onchain state: %w", err)
+	}
+
+	chainSels := slices.Clone(cfg.ChainSelectors)
+	slices.Sort(chainSels)
+
+	// The new configs are built and the owners checked on every chain before any config is set, so that an invalid
+	// chain does not leave the signer rotated on only some of the chains.
+	newConfigs := make(map[uint64]rmn_remote.RMNRemoteConfig, len(chainSels))
+	for _, chainSel := range chainSels {
+		chain, ok := e.Chains[chainSel]
+		if !ok {
+			return deployment.ChangesetOutput{}, fmt.Errorf("chain %d not found in environment", chainSel)
+		}
+		chainState, ok := state.Chains[chainSel]
+		if !ok || chainState.RMNRemote == nil {
+			return deployment.ChangesetOutput{}, fmt.Errorf("RMNRemote not found for chain %d", chainSel)
+		}
+		newConfig, err := rotatedRMNRemoteConfig(e, chainSel, chainState.RMNRemote, cfg.NodeIndex, cfg.NewOnchainPublicKey)
+		if err != nil {
+			return deployment.ChangesetOutput{}, err
+		}
+
+		owner, err := chainState.RMNRemote.Owner(&bind.CallOpts{Context: e.GetContext()})
+		if err != nil {
+			return deployment.ChangesetOutput{}, fmt.Errorf("failed to get RMNRemote owner on chain %d: %w", chainSel, err)
+		}
+		expectedOwner := chain.DeployerKey.From
+		if cfg.MCMS != nil {
+			if chainState.Timelock == nil || chainState.ProposerMcm == nil {
+				return deployment.ChangesetOutput{}, fmt.Errorf("timelock or proposer MCMS not found for chain %d", chainSel)
+			}
+			expectedOwner = chainState.Timelock.Address()
+		}
+		if owner != expectedOwner {
+			return deployment.ChangesetOutput{}, fmt.Errorf("RMNRemote on chain %d is owned by %s, expected %s",
+				chainSel, owner.Hex(), expectedOwner.Hex())
+		}
+		newConfigs[chainSel] = newConfig
+	}
+
+	if cfg.MCMS == nil {
+		for _, chainSel := range chainSels {
+			chain := e.Chains[chainSel]
+			tx, err := state.Chains[chainSel].RMNRemote.SetConfig(chain.DeployerKey, newConfigs[chainSel])
+			if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+				return deployment.ChangesetOutput{}, fmt.Errorf("failed to set RMNRemote config on chain %d: %w", chainSel, err)
+			}
+			e.Logger.Infow("Rotated RMN signer", "chain", chainSel, "nodeIndex", cfg.NodeIndex, "signer", cfg.NewOnchainPublicKey.Hex())
+		}
+		return deployment.ChangesetOutput{}, nil
+	}
+
+	var (
+		timelocksPerChain = make(map[uint64]common.Address)
+		proposerMCMSes    = make(map[uint64]*gethwrappers.ManyChainMultiSig)
+		batches           []timelock.BatchChainOperation
+	)
+	for _, chainSel := range chainSels {
+		chainState := state.Chains[chainSel]
+		tx, err := chainState.RMNRemote.SetConfig(deployment.SimTransactOpts(), newConfigs[chainSel])
+		if err != nil {
+			return deployment.ChangesetOutput{}, fmt.Errorf("failed to build setConfig call for RMNRemote on chain %d: %w", chainSel, err)
+		}
+		timelocksPerChain[chainSel] = chainState.Timelock.Address()
+		proposerMCMSes[chainSel] = chainState.ProposerMcm
+		batches = append(batches, timelock.BatchChainOperation{
+			ChainIdentifier: mcms.ChainIdentifier(chainSel),
+			Batch: []mcms.Operation{{
+				To:    chainState.RMNRemote.Address(),
+				Data:  tx.Data(),
+				Value: big.NewInt(0),
+			}},
+		})
+	}
+
+	prop, err := proposalutils.BuildProposalFromBatches(
+		timelocksPerChain,
+		proposerMCMSes,
+		batches,
+		fmt.Sprintf("rotate RMN signer of node %d", cfg.NodeIndex),
+		cfg.MCMS.MinDelay,
+	)
+	if err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("failed to build proposal: %w", err)
+	}
+	return deployment.ChangesetOutput{
+		Proposals: []timelock.MCMSWithTimelockProposal{*prop},
+	}, nil
+}
+
+// rotatedRMNRemoteConfig returns the current config of the RMNRemote with the onchain public key of the signer
+// of the given node replaced.
+func rotatedRMNRemoteConfig(
+	e deployment.Environment,
+	chainSel uint64,
+	rmnRemote *rmn_remote.RMNRemote,
+	nodeIndex uint64,
+	newOnchainPublicKey common.Address,
+) (rmn_remote.RMNRemoteConfig, error) {
+	current, err := rmnRemote.GetVersionedConfig(&bind.CallOpts{Context: e.GetContext()})
+	if err != nil {
+		return rmn_remote.RMNRemoteConfig{}, fmt.Errorf("failed to get RMNRemote config on chain %d: %w", chainSel, err)
+	}
+	newConfig := current.Config
+	newConfig.Signers = slices.Clone(current.Config.Signers)
+
+	rotated := false
+	for i, signer := range newConfig.Signers {
+		if signer.OnchainPublicKey == newOnchainPublicKey {
+			return rmn_remote.RMNRemoteConfig{}, fmt.Errorf("onchain public key %s is already a signer of node %d on chain %d",
+				newOnchainPublicKey.Hex(), signer.NodeIndex, chainSel)
+		}
+		if signer.NodeIndex == nodeIndex {
+			newConfig.Signers[i].OnchainPublicKey = newOnchainPublicKey
+			rotated = true
+		}
+	}
+	if !rotated {
+		return rmn_remote.RMNRemoteConfig{}, fmt.Errorf("no signer with node index %d on chain %d", nodeIndex, chainSel)
+	}
+	if uint64(len(newConfig.Signers)) < 2*newConfig.F+1 {
+		return rmn_remote.RMNRemoteConfig{}, fmt.Errorf("not enough signers for chain %d: F %d requires at least %d signers, got %d",
+			chainSel, newConfig.F, 2*newConfig.F+1, len(newConfig.Signers))
+	}
+	return newConfig, nil
+}
@@ -0,0 +1,111 @@
+package changeset
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/rmn_remote"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestRotateRMNSignerChangeset(t *testing.T) {
+	ctx := testcontext.Get(t)
+	e := NewMemoryEnvironmentWithJobsAndContracts(t, logger.TestLogger(t), 2, 4, nil)
+	state, err := LoadOnchainState(e.Env)
+	require.NoError(t, err)
+	require.NoError(t, AddLanesForAll(e.Env, state))
+	allChains := e.Env.AllChainSelectors()
+
+	activeDigest, err := state.Chains[e.HomeChainSel].RMNHome.GetActiveDigest(&bind.CallOpts{Context: ctx})
+	require.NoError(t, err)
+	signers := []rmn_remote.RMNRemoteSigner{
+		{OnchainPublicKey: common.HexToAddress("0x1"), NodeIndex: 0},
+		{OnchainPublicKey: common.HexToAddress("0x2"), NodeIndex: 1},
+		{OnchainPublicKey: common.HexToAddress("0x3"), NodeIndex: 2},
+	}
+	setSigners := func(chainSel uint64, signers []rmn_remote.RMNRemoteSigner) {
+		chain := e.Env.Chains[chainSel]
+		tx, err := state.Chains[chainSel].RMNRemote.SetConfig(chain.DeployerKey, rmn_remote.RMNRemoteConfig{
+			RmnHomeContractConfigDigest: activeDigest,
+			Signers:                     signers,
+			F:                           1,
+		})
+		_, err = deployment.ConfirmIfNoError(chain, tx, err)
+		require.NoError(t, err)
+	}
+	for _, chainSel := range allChains {
+		setSigners(chainSel, signers)
+	}
+
+	newKey := common.HexToAddress("0x4")
+	_, err = RotateRMNSignerChangeset(e.Env, RotateRMNSignerConfig{NodeIndex: 3, NewOnchainPublicKey: newKey, ChainSelectors: allChains})
+	require.ErrorContains(t, err, "no signer with node index 3")
+	_, err = RotateRMNSignerChangeset(e.Env, RotateRMNSignerConfig{NodeIndex: 1, NewOnchainPublicKey: signers[2].OnchainPublicKey, ChainSelectors: allChains})
+	require.ErrorContains(t, err, "is already a signer of node 2")
+
+	// the chains are rotated in order, an invalid second chain leaves the first one unchanged
+	sortedChains := slices.Clone(allChains)
+	slices.Sort(sortedChains)
+	setSigners(sortedChains[1], []rmn_remote.RMNRemoteSigner{signers[0], signers[2], {OnchainPublicKey: common.HexToAddress("0x5"), NodeIndex: 3}})
+	_, err = RotateRMNSignerChangeset(e.Env, RotateRMNSignerConfig{NodeIndex: 1, NewOnchainPublicKey: newKey, ChainSelectors: sortedChains[:2]})
+	require.ErrorContains(t, err, "no signer with node index 1")
+	config, err := state.Chains[sortedChains[0]].RMNRemote.GetVersionedConfig(&bind.CallOpts{Context: ctx})
+	require.NoError(t, err)
+	require.Equal(t, signers, config.Config.Signers)
+	setSigners(sortedChains[1], signers)
+
+	_, err = RotateRMNSignerChangeset(e.Env, RotateRMNSignerConfig{NodeIndex: 1, NewOnchainPublicKey: newKey, ChainSelectors: allChains})
+	require.NoError(t, err)
+
+	expectedSigners := []rmn_remote.RMNRemoteSigner{signers[0], {OnchainPublicKey: newKey, NodeIndex: 1}, signers[2]}
+	for _, chainSel := range allChains {
+		config, err := state.Chains[chainSel].RMNRemote.GetVersionedConfig(&bind.CallOpts{Context: ctx})
+		require.NoError(t, err)
+		require.Equal(t, activeDigest, config.Config.RmnHomeContractConfigDigest)
+		require.Equal(t, expectedSigners, config.Config.Signers)
+		require.Equal(t, uint64(1), config.Config.F)
+	}
+
+	// commits keep flowing on the lane after the rotation
+	src, dest := allChains[0], allChains[1]
+	latesthdr, err := e.Env.Chains[dest].Client.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	block := latesthdr.Number.Uint64()
+	msgSentEvent := TestSendRequest(t, e.Env, state, src, dest, false, router.ClientEVM2AnyMessage{
+		Receiver:     common.LeftPadBytes(state.Chains[dest].Receiver.Address().Bytes(), 32),
+		Data:         []byte("hello after rotation"),
+		TokenAmounts: nil,
+		FeeToken:     common.HexToAddress("0x0"),
+		ExtraArgs:    nil,
+	})
+	ConfirmCommitForAllWithExpectedSeqNums(t, e.Env, state, map[SourceDestPair]uint64{
+		{SourceChainSelector: src, DestChainSelector: dest}: msgSentEvent.SequenceNumber,
+	}, map[uint64]*uint64{dest: &block})
+}
+
+func TestRotateRMNSignerConfig_Validate(t *testing.T) {
+	chainSel := chainsel.TEST_90000001.Selector
+	cfg := RotateRMNSignerConfig{NodeIndex: 1, NewOnchainPublicKey: common.HexToAddress("0x4"), ChainSelectors: []uint64{chainSel}}
+	require.NoError(t, cfg.Validate())
+
+	invalid := cfg
+	invalid.NewOnchainPublicKey = common.Address{}
+	require.ErrorContains(t, invalid.Validate(), "new onchain public key must be set")
+
+	invalid = cfg
+	invalid.ChainSelectors = nil
+	require.ErrorContains(t, invalid.Validate(), "no chain selectors provided")
+
+	invalid = cfg
+	invalid.ChainSelectors = []uint64{chainSel, chainSel}
+	require.ErrorContains(t, invalid.Validate(), "duplicate chain selector")
+}
@@ -0,0 +1,160 @@
+package changeset
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/onramp"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/shared/generated/erc20"
+)
+
+// maxSendMessageAttempts bounds how often a message paying its fee in the native token is resent because the fee
+// estimated by the router rose before the message was sent.
+const maxSendMessageAttempts = 3
+
+// SendResult is a CCIP message sent with SendMessage, as parsed from the CCIPMessageSent event of the OnRamp.
+type SendResult struct {
+	TxHash         common.Hash
+	BlockNumber    uint64
+	SequenceNumber uint64
+	MessageID      [32]byte
+	Nonce          uint64
+	FeeToken       common.Address
+	// FeePaid is the fee paid in FeeToken.
+	FeePaid *big.Int
+	Event   *onramp.OnRampCCIPMessageSent
+}
+
+type sendMessageOptions struct {
+	testRouter bool
+}
+
+// WithSendMessageTestRouter sends the message through the test router of the source chain instead of the router.
+func WithSendMessageTestRouter() func(*sendMessageOptions) {
+	return func(o *sendMessageOptions) {
+		o.testRouter = true
+	}
+}
+
+// SendMessage sends the message from the source to the dest chain through the router of the source chain, paying
+// the fee estimated by the router with the deployer key. A fee in the native token is sent along with the message,
+// a fee in an ERC20 token is approved for the router first if its allowance does not cover it.
+// Unlike TestSendRequest it does not depend on a test, so it can be used against any environment.
+func SendMessage(
+	ctx context.Context,
+	e deployment.Environment,
+	state CCIPOnChainState,
+	src, dest uint64,
+	msg router.ClientEVM2AnyMessage,
+	opts ...func(*sendMessageOptions),
+) (SendResult, error) {
+	var o sendMessageOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	chain, ok := e.Chains[src]
+	if !ok {
+		return SendResult{}, fmt.Errorf("source chain %d not found in environment", src)
+	}
+	chainState, ok := state.Chains[src]
+	if !ok || chainState.OnRamp == nil {
+		return SendResult{}, fmt.Errorf("OnRamp not found for source chain %d", src)
+	}
+	r := chainState.Router
+	if o.testRouter {
+		r = chainState.TestRouter
+	}
+	if r == nil {
+		return SendResult{}, fmt.Errorf("router not found for source chain %d, test router %t", src, o.testRouter)
+	}
+
+	// the deployer key is shared, so the fee is set on a copy of it
+	txOpts := *chain.DeployerKey
+	txOpts.Context = ctx
+
+	var (
+		blockNum uint64
+		txHash   common.Hash
+	)
+	for attempt := 1; ; attempt++ {
+		fee, err := r.GetFee(&bind.CallOpts{Context: ctx}, dest, msg)
+		if err != nil {
+			return SendResult{}, fmt.Errorf("failed to get fee: %w", deployment.MaybeDataErr(err))
+		}
+		if msg.FeeToken == (common.Address{}) {
+			txOpts.Value = fee
+		} else if err := approveFee(ctx, chain, msg.FeeToken, r.Address(), fee); err != nil {
+			return SendResult{}, err
+		}
+
+		tx, err := r.CcipSend(&txOpts, dest, msg)
+		blockNum, err = deployment.ConfirmIfNoError(chain, tx, err)
+		if err == nil {
+			txHash = tx.Hash()
+			break
+		}
+		// the fee may rise between its estimation and the message being sent
+		if !strings.Contains(err.Error(), errCodeInsufficientFee) || attempt == maxSendMessageAttempts {
+			return SendResult{}, fmt.Errorf("failed to send CCIP message: %w", deployment.MaybeDataErr(err))
+		}
+	}
+
+	it, err := chainState.OnRamp.FilterCCIPMessageSent(&bind.FilterOpts{
+		Start:   blockNum,
+		End:     &blockNum,
+		Context: ctx,
+	}, []uint64{dest}, nil)
+	if err != nil {
+		return SendResult{}, fmt.Errorf("failed to filter CCIPMessageSent events: %w", err)
+	}
+	defer it.Close()
+	for it.Next() {
+		// other messages may have been sent to dest in the same block, even by the deployer
+		if it.Event.Raw.TxHash != txHash {
+			continue
+		}
+		event := it.Event
+		return SendResult{
+			TxHash:         event.Raw.TxHash,
+			BlockNumber:    blockNum,
+			SequenceNumber: event.SequenceNumber,
+			MessageID:      event.Message.Header.MessageId,
+			Nonce:          event.Message.Header.Nonce,
+			FeeToken:       event.Message.FeeToken,
+			FeePaid:        event.Message.FeeTokenAmount,
+			Event:          event,
+		}, nil
+	}
+	if err := it.Error(); err != nil {
+		return SendResult{}, fmt.Errorf("failed to iterate CCIPMessageSent events: %w", err)
+	}
+	return SendResult{}, fmt.Errorf("no CCIPMessageSent event to chain %d found for tx %s in block %d", dest, txHash.Hex(), blockNum)
+}
+
+// approveFee approves the router to spend the fee in the fee token from the deployer, if it may not already.
+func approveFee(ctx context.Context, chain deployment.Chain, feeToken, routerAddr common.Address, fee *big.Int) error {
+	token, err := erc20.NewERC20(feeToken, chain.Client)
+	if err != nil {
+		return fmt.Errorf("failed to bind fee token %s: %w", feeToken.Hex(), err)
+	}
+	allowance, err := token.Allowance(&bind.CallOpts{Context: ctx}, chain.DeployerKey.From, routerAddr)
+	if err != nil {
+		return fmt.Errorf("failed to get allowance of fee token %s: %w", feeToken.Hex(), err)
+	}
+	if allowance.Cmp(fee) >= 0 {
+		return nil
+	}
+	tx, err := token.Approve(chain.DeployerKey, routerAddr, fee)
+	if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+		return fmt.Errorf("failed to approve fee token %s for router %s: %w", feeToken.Hex(), routerAddr.Hex(), err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package changeset
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/maps"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestSendMessage(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	tenv := NewMemoryEnvironmentWithJobsAndContracts(t, lggr, 2, 4, nil)
+	e := tenv.Env
+	ctx := testcontext.Get(t)
+
+	state, err := LoadOnchainState(e)
+	require.NoError(t, err)
+	require.NoError(t, AddLanesForAll(e, state))
+
+	allChains := maps.Keys(e.Chains)
+	src, dest := allChains[0], allChains[1]
+
+	for i, msg := range []string{"first", "second"} {
+		result, err := SendMessage(ctx, e, state, src, dest, router.ClientEVM2AnyMessage{
+			Receiver:  common.LeftPadBytes(state.Chains[dest].Receiver.Address().Bytes(), 32),
+			Data:      []byte(msg),
+			FeeToken:  common.HexToAddress("0x0"),
+			ExtraArgs: nil,
+		})
+		require.NoError(t, err)
+
+		it, err := state.Chains[src].OnRamp.FilterCCIPMessageSent(&bind.FilterOpts{
+			Start:   result.BlockNumber,
+			End:     &result.BlockNumber,
+			Context: ctx,
+		}, []uint64{dest}, nil)
+		require.NoError(t, err)
+		require.True(t, it.Next())
+		require.Equal(t, it.Event.SequenceNumber, result.SequenceNumber)
+		require.Equal(t, it.Event.Message.Header.MessageId, result.MessageID)
+		require.Equal(t, it.Event.Raw.TxHash, result.TxHash)
+		require.Equal(t, uint64(i+1), result.SequenceNumber)
+		// native fees are paid in the wrapped native token
+		require.Equal(t, state.Chains[src].Weth9.Address(), result.FeeToken)
+		require.Positive(t, result.FeePaid.Sign())
+	}
+}
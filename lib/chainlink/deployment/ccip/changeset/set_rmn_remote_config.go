@@ -0,0 +1,163 @@
+package changeset
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/timelock"
+
+	"github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn/types"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/common/proposalutils"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/rmn_remote"
+)
+
+var _ deployment.ChangeSet[SetRMNRemoteConfig] = SetRMNRemoteConfigChangeset
+
+// RMNRemoteConfig holds the chain specific part of the RMNRemote config.
+type RMNRemoteConfig struct {
+	// F is the maximum number of faulty RMN nodes tolerated on the chain.
+	// At least 2F+1 signers are required.
+	F uint64
+}
+
+type SetRMNRemoteConfig struct {
+	// RMNHomeActiveDigest is the active config digest of the RMNHome contract on the home chain.
+	RMNHomeActiveDigest [32]byte
+	// Signers are the RMN nodes allowed to sign reports, ordered by strictly increasing NodeIndex.
+	Signers []rmn_remote.RMNRemoteSigner
+	// RMNRemoteConfigs is a mapping from chain selector to the config of the RMNRemote on that chain.
+	RMNRemoteConfigs map[uint64]RMNRemoteConfig
+	// MinDelay is the minimum amount of time that must pass before the proposal
+	// can be executed onchain.
+	MinDelay time.Duration
+}
+
+func (c SetRMNRemoteConfig) Validate() error {
+	if c.RMNHomeActiveDigest == [32]byte{} {
+		return errors.New("RMNHome active digest must be set")
+	}
+	if len(c.RMNRemoteConfigs) == 0 {
+		return errors.New("no RMNRemote configs provided")
+	}
+	seenKeys := make(map[common.Address]struct{})
+	for i, signer := range c.Signers {
+		if signer.OnchainPublicKey == (common.Address{}) {
+			return fmt.Errorf("signer %d has an empty onchain public key", i)
+		}
+		if _, ok := seenKeys[signer.OnchainPublicKey]; ok {
+			return fmt.Errorf("duplicate signer onchain public key %s", signer.OnchainPublicKey.Hex())
+		}
+		seenKeys[signer.OnchainPublicKey] = struct{}{}
+		if i > 0 && signer.NodeIndex <= c.Signers[i-1].NodeIndex {
+			return fmt.Errorf("signer node indexes must be strictly increasing, got %d after %d",
+				signer.NodeIndex, c.Signers[i-1].NodeIndex)
+		}
+	}
+	for chainSel, cfg := range c.RMNRemoteConfigs {
+		if err := deployment.IsValidChainSelector(chainSel); err != nil {
+			return fmt.Errorf("invalid chain selector %d: %w", chainSel, err)
+		}
+		if uint64(len(c.Signers)) < 2*cfg.F+1 {
+			return fmt.Errorf("not enough signers for chain %d: F %d requires at least %d signers, got %d",
+				chainSel, cfg.F, 2*cfg.F+1, len(c.Signers))
+		}
+	}
+	return nil
+}
+
+// SetRMNRemoteConfigChangeset generates a proposal to call setConfig on the RMNRemote of each configured chain.
+// It is meant to be used once the RMNRemote contracts are owned by the timelock.
+func SetRMNRemoteConfigChangeset(e deployment.Environment, cfg SetRMNRemoteConfig) (deployment.ChangesetOutput, error) {
+	if err := cfg.Validate(); err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("invalid SetRMNRemoteConfig: %w", err)
+	}
+	state, err := LoadOnchainState(e)
+	if err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("failed to load onchain state: %w", err)
+	}
+
+	chainSels := make([]uint64, 0, len(cfg.RMNRemoteConfigs))
+	for chainSel := range cfg.RMNRemoteConfigs {
+		chainSels = append(chainSels, chainSel)
+	}
+	sort.Slice(chainSels, func(i, j int) bool { return chainSels[i] < chainSels[j] })
+
+	var (
+		timelocksPerChain = make(map[uint64]common.Address)
+		proposerMCMSes    = make(map[uint64]*gethwrappers.ManyChainMultiSig)
+		batches           []timelock.BatchChainOperation
+	)
+	for _, chainSel := range chainSels {
+		chainState, ok := state.Chains[chainSel]
+		if !ok {
+			return deployment.ChangesetOutput{}, fmt.Errorf("chain %d not found in onchain state", chainSel)
+		}
+		if chainState.RMNRemote == nil {
+			return deployment.ChangesetOutput{}, fmt.Errorf("RMNRemote not found for chain %d", chainSel)
+		}
+		if chainState.Timelock == nil || chainState.ProposerMcm == nil {
+			return deployment.ChangesetOutput{}, fmt.Errorf("timelock or proposer MCMS not found for chain %d", chainSel)
+		}
+		tx, err := chainState.RMNRemote.SetConfig(deployment.SimTransactOpts(), rmn_remote.RMNRemoteConfig{
+			RmnHomeContractConfigDigest: cfg.RMNHomeActiveDigest,
+			Signers:                     cfg.Signers,
+			F:                           cfg.RMNRemoteConfigs[chainSel].F,
+		})
+		if err != nil {
+			return deployment.ChangesetOutput{}, fmt.Errorf("failed to build setConfig call for RMNRemote on chain %d: %w", chainSel, err)
+		}
+		timelocksPerChain[chainSel] = chainState.Timelock.Address()
+		proposerMCMSes[chainSel] = chainState.ProposerMcm
+		batches = append(batches, timelock.BatchChainOperation{
+			ChainIdentifier: mcms.ChainIdentifier(chainSel),
+			Batch: []mcms.Operation{{
+				To:    chainState.RMNRemote.Address(),
+				Data:  tx.Data(),
+				Value: big.NewInt(0),
+			}},
+		})
+	}
+
+	prop, err := proposalutils.BuildProposalFromBatches(
+		timelocksPerChain,
+		proposerMCMSes,
+		batches,
+		"set RMNRemote config",
+		cfg.MinDelay,
+	)
+	if err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("failed to build proposal: %w", err)
+	}
+	return deployment.ChangesetOutput{
+		Proposals: []timelock.MCMSWithTimelockProposal{*prop},
+	}, nil
+}
+
+// ChainSelectorToSubject returns the RMNRemote curse subject of a chain, which is the chain selector
+// in the last 8 bytes of the subject, big endian.
+func ChainSelectorToSubject(chainSel uint64) [16]byte {
+	var subject [16]byte
+	binary.BigEndian.PutUint64(subject[8:], chainSel)
+	return subject
+}
+
+// SubjectToChainSelector returns the chain selector of an RMNRemote curse subject.
+// It returns an error for the global curse subject, and any other subject with non zero leading 8 bytes.
+func SubjectToChainSelector(subject [16]byte) (uint64, error) {
+	if subject == types.GlobalCurseSubject {
+		return 0, errors.New("global curse subject does not map to a chain selector")
+	}
+	if binary.BigEndian.Uint64(subject[:8]) != 0 {
+		return 0, fmt.Errorf("subject %x is not a chain subject, leading 8 bytes must be zero", subject)
+	}
+	return binary.BigEndian.Uint64(subject[8:]), nil
+}
@@ -0,0 +1,197 @@
+package changeset
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/gethwrappers"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn/types"
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	commonchangeset "github.com/smartcontractkit/chainlink/deployment/common/changeset"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/rmn_remote"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestSetRMNRemoteConfig_Validate(t *testing.T) {
+	chainSel := chainsel.TEST_90000001.Selector
+	signers := []rmn_remote.RMNRemoteSigner{
+		{OnchainPublicKey: common.HexToAddress("0x1"), NodeIndex: 0},
+		{OnchainPublicKey: common.HexToAddress("0x2"), NodeIndex: 1},
+		{OnchainPublicKey: common.HexToAddress("0x3"), NodeIndex: 2},
+	}
+	tests := []struct {
+		name    string
+		cfg     SetRMNRemoteConfig
+		wantErr string
+	}{
+		{
+			name: "valid",
+			cfg: SetRMNRemoteConfig{
+				RMNHomeActiveDigest: [32]byte{1},
+				Signers:             signers,
+				RMNRemoteConfigs:    map[uint64]RMNRemoteConfig{chainSel: {F: 1}},
+			},
+		},
+		{
+			name: "empty digest",
+			cfg: SetRMNRemoteConfig{
+				Signers:          signers,
+				RMNRemoteConfigs: map[uint64]RMNRemoteConfig{chainSel: {F: 1}},
+			},
+			wantErr: "RMNHome active digest must be set",
+		},
+		{
+			name: "no chains",
+			cfg: SetRMNRemoteConfig{
+				RMNHomeActiveDigest: [32]byte{1},
+				Signers:             signers,
+			},
+			wantErr: "no RMNRemote configs provided",
+		},
+		{
+			name: "unordered node indexes",
+			cfg: SetRMNRemoteConfig{
+				RMNHomeActiveDigest: [32]byte{1},
+				Signers: []rmn_remote.RMNRemoteSigner{
+					{OnchainPublicKey: common.HexToAddress("0x1"), NodeIndex: 1},
+					{OnchainPublicKey: common.HexToAddress("0x2"), NodeIndex: 1},
+				},
+				RMNRemoteConfigs: map[uint64]RMNRemoteConfig{chainSel: {F: 0}},
+			},
+			wantErr: "strictly increasing",
+		},
+		{
+			name: "duplicate signer",
+			cfg: SetRMNRemoteConfig{
+				RMNHomeActiveDigest: [32]byte{1},
+				Signers: []rmn_remote.RMNRemoteSigner{
+					{OnchainPublicKey: common.HexToAddress("0x1"), NodeIndex: 0},
+					{OnchainPublicKey: common.HexToAddress("0x1"), NodeIndex: 1},
+				},
+				RMNRemoteConfigs: map[uint64]RMNRemoteConfig{chainSel: {F: 0}},
+			},
+			wantErr: "duplicate signer",
+		},
+		{
+			name: "F too large for signer count",
+			cfg: SetRMNRemoteConfig{
+				RMNHomeActiveDigest: [32]byte{1},
+				Signers:             signers,
+				RMNRemoteConfigs:    map[uint64]RMNRemoteConfig{chainSel: {F: 2}},
+			},
+			wantErr: "not enough signers",
+		},
+		{
+			name: "invalid chain selector",
+			cfg: SetRMNRemoteConfig{
+				RMNHomeActiveDigest: [32]byte{1},
+				Signers:             signers,
+				RMNRemoteConfigs:    map[uint64]RMNRemoteConfig{0: {F: 1}},
+			},
+			wantErr: "invalid chain selector",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSetRMNRemoteConfigChangeset(t *testing.T) {
+	ctx := testcontext.Get(t)
+	e := NewMemoryEnvironmentWithJobsAndContracts(t, logger.TestLogger(t), 2, 4, nil)
+	state, err := LoadOnchainState(e.Env)
+	require.NoError(t, err)
+	allChains := e.Env.AllChainSelectors()
+
+	// RMNRemote must be owned by the timelock for the proposal to go through.
+	timelocks := make(map[uint64]*gethwrappers.RBACTimelock)
+	transferCfg := commonchangeset.TransferOwnershipConfig{
+		TimelocksPerChain: make(map[uint64]common.Address),
+		Contracts:         make(map[uint64][]commonchangeset.OwnershipTransferrer),
+	}
+	acceptCfg := commonchangeset.AcceptOwnershipConfig{
+		TimelocksPerChain: make(map[uint64]common.Address),
+		ProposerMCMSes:    make(map[uint64]*gethwrappers.ManyChainMultiSig),
+		Contracts:         make(map[uint64][]commonchangeset.OwnershipAcceptor),
+	}
+	for _, chain := range allChains {
+		timelocks[chain] = state.Chains[chain].Timelock
+		transferCfg.TimelocksPerChain[chain] = state.Chains[chain].Timelock.Address()
+		transferCfg.Contracts[chain] = []commonchangeset.OwnershipTransferrer{state.Chains[chain].RMNRemote}
+		acceptCfg.TimelocksPerChain[chain] = state.Chains[chain].Timelock.Address()
+		acceptCfg.ProposerMCMSes[chain] = state.Chains[chain].ProposerMcm
+		acceptCfg.Contracts[chain] = []commonchangeset.OwnershipAcceptor{state.Chains[chain].RMNRemote}
+	}
+
+	activeDigest, err := state.Chains[e.HomeChainSel].RMNHome.GetActiveDigest(&bind.CallOpts{Context: ctx})
+	require.NoError(t, err)
+	signers := []rmn_remote.RMNRemoteSigner{
+		{OnchainPublicKey: common.HexToAddress("0x1"), NodeIndex: 0},
+		{OnchainPublicKey: common.HexToAddress("0x2"), NodeIndex: 1},
+		{OnchainPublicKey: common.HexToAddress("0x3"), NodeIndex: 2},
+	}
+	rmnRemoteConfigs := make(map[uint64]RMNRemoteConfig)
+	for i, chain := range allChains {
+		rmnRemoteConfigs[chain] = RMNRemoteConfig{F: uint64(i % 2)}
+	}
+
+	_, err = commonchangeset.ApplyChangesets(t, e.Env, timelocks, []commonchangeset.ChangesetApplication{
+		{
+			Changeset: commonchangeset.WrapChangeSet(commonchangeset.NewTransferOwnershipChangeset),
+			Config:    transferCfg,
+		},
+		{
+			Changeset: commonchangeset.WrapChangeSet(commonchangeset.NewAcceptOwnershipChangeset),
+			Config:    acceptCfg,
+		},
+		{
+			Changeset: commonchangeset.WrapChangeSet(SetRMNRemoteConfigChangeset),
+			Config: SetRMNRemoteConfig{
+				RMNHomeActiveDigest: activeDigest,
+				Signers:             signers,
+				RMNRemoteConfigs:    rmnRemoteConfigs,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	for _, chain := range allChains {
+		config, err := state.Chains[chain].RMNRemote.GetVersionedConfig(&bind.CallOpts{Context: ctx})
+		require.NoError(t, err)
+		require.Equal(t, activeDigest, config.Config.RmnHomeContractConfigDigest)
+		require.Equal(t, signers, config.Config.Signers)
+		require.Equal(t, rmnRemoteConfigs[chain].F, config.Config.F)
+	}
+}
+
+func TestChainSelectorToSubject(t *testing.T) {
+	for _, chainSel := range []uint64{0, 1, chainsel.TEST_90000001.Selector, chainsel.ETHEREUM_MAINNET.Selector, ^uint64(0)} {
+		subject := ChainSelectorToSubject(chainSel)
+		require.Equal(t, [8]byte{}, [8]byte(subject[:8]))
+		decoded, err := SubjectToChainSelector(subject)
+		require.NoError(t, err)
+		require.Equal(t, chainSel, decoded)
+	}
+	require.Equal(t, [16]byte{15: 1}, ChainSelectorToSubject(1))
+	require.Equal(t, [16]byte{8: 0x01, 15: 0x02}, ChainSelectorToSubject(1<<56|2))
+}
+
+func TestSubjectToChainSelector(t *testing.T) {
+	_, err := SubjectToChainSelector(types.GlobalCurseSubject)
+	require.ErrorContains(t, err, "global curse subject")
+
+	_, err = SubjectToChainSelector([16]byte{7: 1, 15: 1})
+	require.ErrorContains(t, err, "leading 8 bytes must be zero")
+}
@@ -2,6 +2,7 @@ package changeset
 
 import (
 	"fmt"
+	"strings"
 
 	burn_mint_token_pool "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/burn_mint_token_pool_1_4_0"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/shared/generated/erc20"
@@ -10,6 +11,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/mock_usdc_token_transmitter"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/usdc_token_pool"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
@@ -48,6 +50,31 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/generated/aggregator_v3_interface"
 )
 
+// ErrorABIs returns the ABIs of the CCIP contracts, to be set as deployment.Chain.ErrorABIs so that
+// deployment.ConfirmIfNoError decodes the custom errors they raise.
+func ErrorABIs() ([]abi.ABI, error) {
+	var errorABIs []abi.ABI
+	for _, contractABI := range []string{
+		onramp.OnRampABI,
+		offramp.OffRampABI,
+		fee_quoter.FeeQuoterABI,
+		router.RouterABI,
+		nonce_manager.NonceManagerABI,
+		token_admin_registry.TokenAdminRegistryABI,
+		rmn_remote.RMNRemoteABI,
+		rmn_home.RMNHomeABI,
+		ccip_home.CCIPHomeABI,
+		maybe_revert_message_receiver.MaybeRevertMessageReceiverABI,
+	} {
+		parsed, err := abi.JSON(strings.NewReader(contractABI))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ABI: %w", err)
+		}
+		errorABIs = append(errorABIs, parsed)
+	}
+	return errorABIs, nil
+}
+
 // CCIPChainState holds a Go binding for all the currently deployed CCIP contracts
 // on a chain. If a binding is nil, it means here is no such contract on the chain.
 type CCIPChainState struct {
@@ -93,6 +120,7 @@ type CCIPChainState struct {
 
 	// Test contracts
 	Receiver               *maybe_revert_message_receiver.MaybeRevertMessageReceiver
+	RevertingReceiver      *maybe_revert_message_receiver.MaybeRevertMessageReceiver
 	TestRouter             *router.Router
 	USDCTokenPool          *usdc_token_pool.USDCTokenPool
 	MockUSDCTransmitter    *mock_usdc_token_transmitter.MockE2EUSDCTransmitter
@@ -233,16 +261,7 @@ func LoadOnchainState(e deployment.Environment) (CCIPOnChainState, error) {
 		Chains: make(map[uint64]CCIPChainState),
 	}
 	for chainSelector, chain := range e.Chains {
-		addresses, err := e.ExistingAddresses.AddressesForChain(chainSelector)
-		if err != nil {
-			// Chain not found in address book, initialize empty
-			if errors.Is(err, deployment.ErrChainNotFound) {
-				addresses = make(map[string]deployment.TypeAndVersion)
-			} else {
-				return state, err
-			}
-		}
-		chainState, err := LoadChainState(chain, addresses)
+		chainState, err := loadChainStateFromAddressBook(e, chainSelector, chain)
 		if err != nil {
 			return state, err
 		}
@@ -251,6 +270,36 @@ func LoadOnchainState(e deployment.Environment) (CCIPOnChainState, error) {
 	return state, nil
 }
 
+// LoadOnchainStatePartial is a best-effort LoadOnchainState: it loads the state of every chain it can,
+// and returns the errors of the chains it could not load keyed by chain selector, leaving them out of the state.
+func LoadOnchainStatePartial(e deployment.Environment) (CCIPOnChainState, map[uint64]error) {
+	state := CCIPOnChainState{
+		Chains: make(map[uint64]CCIPChainState),
+	}
+	errs := make(map[uint64]error)
+	for chainSelector, chain := range e.Chains {
+		chainState, err := loadChainStateFromAddressBook(e, chainSelector, chain)
+		if err != nil {
+			errs[chainSelector] = err
+			continue
+		}
+		state.Chains[chainSelector] = chainState
+	}
+	return state, errs
+}
+
+func loadChainStateFromAddressBook(e deployment.Environment, chainSelector uint64, chain deployment.Chain) (CCIPChainState, error) {
+	addresses, err := e.ExistingAddresses.AddressesForChain(chainSelector)
+	if err != nil {
+		// Chain not found in address book, initialize empty
+		if !errors.Is(err, deployment.ErrChainNotFound) {
+			return CCIPChainState{}, err
+		}
+		addresses = make(map[string]deployment.TypeAndVersion)
+	}
+	return LoadChainState(chain, addresses)
+}
+
 // LoadChainState Loads all state for a chain into state
 func LoadChainState(chain deployment.Chain, addresses map[string]deployment.TypeAndVersion) (CCIPChainState, error) {
 	var state CCIPChainState
@@ -419,6 +468,12 @@ func LoadChainState(chain deployment.Chain, addresses map[string]deployment.Type
 				return state, err
 			}
 			state.Receiver = mr
+		case deployment.NewTypeAndVersion(RevertingCCIPReceiver, deployment.Version1_0_0).String():
+			mr, err := maybe_revert_message_receiver.NewMaybeRevertMessageReceiver(common.HexToAddress(address), chain.Client)
+			if err != nil {
+				return state, err
+			}
+			state.RevertingReceiver = mr
 		case deployment.NewTypeAndVersion(Multicall3, deployment.Version1_0_0).String():
 			mc, err := multicall3.NewMulticall3(common.HexToAddress(address), chain.Client)
 			if err != nil {
@@ -0,0 +1,172 @@
+package changeset
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	commontypes "github.com/smartcontractkit/chainlink/deployment/common/types"
+)
+
+// StateSnapshot is a serializable snapshot of the contracts in a CCIPOnChainState.
+// It maps a chain selector to the addresses of the contracts on that chain and their type and version,
+// which is enough to rebuild the address book the state was loaded from.
+type StateSnapshot struct {
+	Chains map[uint64]map[string]string `json:"chains"`
+}
+
+// Snapshot returns the addresses and types and versions of all the contracts in the state.
+func (s CCIPOnChainState) Snapshot() StateSnapshot {
+	snapshot := StateSnapshot{Chains: make(map[uint64]map[string]string, len(s.Chains))}
+	for chainSelector, chainState := range s.Chains {
+		contracts := make(map[string]string)
+		for address, tv := range chainState.Addresses() {
+			contracts[address] = tv.String()
+		}
+		snapshot.Chains[chainSelector] = contracts
+	}
+	return snapshot
+}
+
+// MarshalSnapshot returns the snapshot of the state as JSON.
+// Maps are serialized with sorted keys, so snapshots of the same deployment can be diffed.
+func (s CCIPOnChainState) MarshalSnapshot() ([]byte, error) {
+	return json.MarshalIndent(s.Snapshot(), "", "  ")
+}
+
+// LoadAddressBookFromSnapshot rebuilds the address book of a JSON state snapshot produced by MarshalSnapshot,
+// without any onchain calls. The state itself can then be loaded from it with LoadChainState.
+func LoadAddressBookFromSnapshot(data []byte) (*deployment.AddressBookMap, error) {
+	var snapshot StateSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal state snapshot: %w", err)
+	}
+	ab := deployment.NewMemoryAddressBook()
+	for chainSelector, contracts := range snapshot.Chains {
+		for address, tvStr := range contracts {
+			tv, err := deployment.TypeAndVersionFromString(tvStr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid type and version of %s on chain %d: %w", address, chainSelector, err)
+			}
+			if err := ab.Save(chainSelector, address, tv); err != nil {
+				return nil, fmt.Errorf("failed to save %s on chain %d: %w", address, chainSelector, err)
+			}
+		}
+	}
+	return ab, nil
+}
+
+// Addresses returns the addresses of all the contracts in the chain state with the type and version
+// they are stored with in the address book, the reverse of LoadChainState.
+func (c CCIPChainState) Addresses() map[string]deployment.TypeAndVersion {
+	addresses := make(map[string]deployment.TypeAndVersion)
+	add := func(address common.Address, contractType deployment.ContractType, version semver.Version) {
+		addresses[address.Hex()] = deployment.NewTypeAndVersion(contractType, version)
+	}
+
+	if c.Timelock != nil {
+		add(c.Timelock.Address(), commontypes.RBACTimelock, deployment.Version1_0_0)
+	}
+	if c.ProposerMcm != nil {
+		add(c.ProposerMcm.Address(), commontypes.ProposerManyChainMultisig, deployment.Version1_0_0)
+	}
+	if c.CancellerMcm != nil {
+		add(c.CancellerMcm.Address(), commontypes.CancellerManyChainMultisig, deployment.Version1_0_0)
+	}
+	if c.BypasserMcm != nil {
+		add(c.BypasserMcm.Address(), commontypes.BypasserManyChainMultisig, deployment.Version1_0_0)
+	}
+	if c.CapabilityRegistry != nil {
+		add(c.CapabilityRegistry.Address(), CapabilitiesRegistry, deployment.Version1_0_0)
+	}
+	if c.OnRamp != nil {
+		add(c.OnRamp.Address(), OnRamp, deployment.Version1_6_0_dev)
+	}
+	if c.OffRamp != nil {
+		add(c.OffRamp.Address(), OffRamp, deployment.Version1_6_0_dev)
+	}
+	if c.RMNProxyExisting != nil {
+		add(c.RMNProxyExisting.Address(), ARMProxy, deployment.Version1_0_0)
+	}
+	if c.RMNProxyNew != nil {
+		add(c.RMNProxyNew.Address(), ARMProxy, deployment.Version1_6_0_dev)
+	}
+	if c.MockRMN != nil {
+		add(c.MockRMN.Address(), MockRMN, deployment.Version1_0_0)
+	}
+	if c.RMNRemote != nil {
+		add(c.RMNRemote.Address(), RMNRemote, deployment.Version1_6_0_dev)
+	}
+	if c.RMNHome != nil {
+		add(c.RMNHome.Address(), RMNHome, deployment.Version1_6_0_dev)
+	}
+	if c.Weth9 != nil {
+		add(c.Weth9.Address(), WETH9, deployment.Version1_0_0)
+	}
+	if c.NonceManager != nil {
+		add(c.NonceManager.Address(), NonceManager, deployment.Version1_6_0_dev)
+	}
+	if c.CommitStore != nil {
+		add(c.CommitStore.Address(), CommitStore, deployment.Version1_5_0)
+	}
+	if c.TokenAdminRegistry != nil {
+		add(c.TokenAdminRegistry.Address(), TokenAdminRegistry, deployment.Version1_5_0)
+	}
+	if c.RegistryModule != nil {
+		add(c.RegistryModule.Address(), RegistryModule, deployment.Version1_5_0)
+	}
+	if c.Router != nil {
+		add(c.Router.Address(), Router, deployment.Version1_2_0)
+	}
+	if c.TestRouter != nil {
+		add(c.TestRouter.Address(), TestRouter, deployment.Version1_2_0)
+	}
+	if c.FeeQuoter != nil {
+		add(c.FeeQuoter.Address(), FeeQuoter, deployment.Version1_6_0_dev)
+	}
+	if c.LinkToken != nil {
+		add(c.LinkToken.Address(), LinkToken, deployment.Version1_0_0)
+	}
+	for symbol, token := range c.BurnMintTokens677 {
+		// the USDC token is loaded into the burn and mint tokens, see LoadChainState
+		if symbol == USDCSymbol {
+			add(token.Address(), USDCToken, deployment.Version1_0_0)
+			continue
+		}
+		add(token.Address(), BurnMintToken, deployment.Version1_0_0)
+	}
+	for _, pool := range c.BurnMintTokenPools {
+		add(pool.Address(), BurnMintTokenPool, deployment.Version1_5_1)
+	}
+	for _, feed := range c.USDFeeds {
+		add(feed.Address(), PriceFeed, deployment.Version1_0_0)
+	}
+	if c.USDCTokenPool != nil {
+		add(c.USDCTokenPool.Address(), USDCTokenPool, deployment.Version1_0_0)
+	}
+	if c.MockUSDCTransmitter != nil {
+		add(c.MockUSDCTransmitter.Address(), USDCMockTransmitter, deployment.Version1_0_0)
+	}
+	if c.MockUSDCTokenMessenger != nil {
+		add(c.MockUSDCTokenMessenger.Address(), USDCTokenMessenger, deployment.Version1_0_0)
+	}
+	if c.CCIPHome != nil {
+		add(c.CCIPHome.Address(), CCIPHome, deployment.Version1_6_0_dev)
+	}
+	if c.CCIPConfig != nil {
+		add(c.CCIPConfig.Address(), CCIPConfig, deployment.Version1_0_0)
+	}
+	if c.Receiver != nil {
+		add(c.Receiver.Address(), CCIPReceiver, deployment.Version1_0_0)
+	}
+	if c.RevertingReceiver != nil {
+		add(c.RevertingReceiver.Address(), RevertingCCIPReceiver, deployment.Version1_0_0)
+	}
+	if c.Multicall3 != nil {
+		add(c.Multicall3.Address(), Multicall3, deployment.Version1_0_0)
+	}
+	return addresses
+}
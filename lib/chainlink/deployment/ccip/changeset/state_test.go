@@ -0,0 +1,78 @@
+package changeset
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestLoadOnchainStatePartial(t *testing.T) {
+	chains := memory.NewMemoryChains(t, 3)
+	e := deployment.Environment{
+		Name:              "partial",
+		Logger:            logger.TestLogger(t),
+		ExistingAddresses: deployment.NewMemoryAddressBook(),
+		Chains:            chains,
+	}
+	selectors := e.AllChainSelectors()
+	good, bad := selectors[0], selectors[1]
+	weth := common.HexToAddress("0x1")
+	require.NoError(t, e.ExistingAddresses.Save(good, weth.Hex(), deployment.NewTypeAndVersion(WETH9, deployment.Version1_0_0)))
+	// there is no token deployed at this address, so reading its symbol fails
+	require.NoError(t, e.ExistingAddresses.Save(bad, common.HexToAddress("0x2").Hex(), deployment.NewTypeAndVersion(BurnMintToken, deployment.Version1_0_0)))
+
+	_, err := LoadOnchainState(e)
+	require.Error(t, err)
+
+	state, errs := LoadOnchainStatePartial(e)
+	require.Len(t, errs, 1)
+	require.Error(t, errs[bad])
+	require.NotContains(t, state.Chains, bad)
+	require.Len(t, state.Chains, 2)
+	require.NotNil(t, state.Chains[good].Weth9)
+	require.Equal(t, weth, state.Chains[good].Weth9.Address())
+	require.Contains(t, state.Chains, selectors[2])
+}
+
+func TestCCIPOnChainState_Snapshot(t *testing.T) {
+	e := NewMemoryEnvironmentWithJobsAndContracts(t, logger.TestLogger(t), 2, 4, nil)
+	state, err := LoadOnchainState(e.Env)
+	require.NoError(t, err)
+
+	data, err := state.MarshalSnapshot()
+	require.NoError(t, err)
+	ab, err := LoadAddressBookFromSnapshot(data)
+	require.NoError(t, err)
+
+	existing, err := e.Env.ExistingAddresses.Addresses()
+	require.NoError(t, err)
+	snapshotted, err := ab.Addresses()
+	require.NoError(t, err)
+	require.Len(t, snapshotted, len(state.Chains))
+	for chainSelector, chainState := range state.Chains {
+		addresses := chainState.Addresses()
+		require.NotEmpty(t, addresses)
+		require.Len(t, snapshotted[chainSelector], len(addresses))
+		for address, tv := range addresses {
+			require.Contains(t, snapshotted[chainSelector], address)
+			require.True(t, tv.Equal(snapshotted[chainSelector][address]), "%s on chain %d", address, chainSelector)
+			// the snapshot has the same type and version the contract was deployed with
+			require.True(t, tv.Equal(existing[chainSelector][address]), "%s on chain %d", address, chainSelector)
+		}
+		require.Contains(t, snapshotted[chainSelector], chainState.Router.Address().Hex())
+		require.Contains(t, snapshotted[chainSelector], chainState.FeeQuoter.Address().Hex())
+	}
+
+	// the state loaded from the snapshot has the same contracts
+	e.Env.ExistingAddresses = ab
+	reloaded, err := LoadOnchainState(e.Env)
+	require.NoError(t, err)
+	reloadedData, err := reloaded.MarshalSnapshot()
+	require.NoError(t, err)
+	require.JSONEq(t, string(data), string(reloadedData))
+}
@@ -2,6 +2,7 @@ package changeset
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"sync"
@@ -15,14 +16,17 @@ import (
 
 	"github.com/smartcontractkit/chainlink-ccip/pkg/types/ccipocr3"
 	commonutils "github.com/smartcontractkit/chainlink-common/pkg/utils"
-	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
 	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
 
 	"github.com/smartcontractkit/chainlink/deployment"
+	cctypes "github.com/smartcontractkit/chainlink/v2/core/capabilities/ccip/types"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/fee_quoter"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/offramp"
 )
 
+// ErrCommitReportTimeout is returned when the commit reports are not confirmed before the context is done.
+var ErrCommitReportTimeout = errors.New("timed out waiting for commit report")
+
 func ConfirmGasPriceUpdatedForAll(
 	t *testing.T,
 	e deployment.Environment,
@@ -168,7 +172,24 @@ func ConfirmCommitForAllWithExpectedSeqNums(
 	expectedSeqNums map[SourceDestPair]uint64,
 	startBlocks map[uint64]*uint64,
 ) {
-	var wg errgroup.Group
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+	require.NoError(t, ConfirmCommitForAllWithExpectedSeqNumsCtx(ctx, t, e, state, expectedSeqNums, startBlocks),
+		"all commitments did not confirm")
+}
+
+// ConfirmCommitForAllWithExpectedSeqNumsCtx is like ConfirmCommitForAllWithExpectedSeqNums, but waits until ctx is done
+// instead of a fixed timeout, and returns an error rather than failing the test.
+// If ctx is done before all the commits are confirmed, the error wraps ErrCommitReportTimeout and the ctx error.
+func ConfirmCommitForAllWithExpectedSeqNumsCtx(
+	ctx context.Context,
+	t *testing.T,
+	e deployment.Environment,
+	state CCIPOnChainState,
+	expectedSeqNums map[SourceDestPair]uint64,
+	startBlocks map[uint64]*uint64,
+) error {
+	wg, ctx := errgroup.WithContext(ctx)
 	for src, srcChain := range e.Chains {
 		for dest, dstChain := range e.Chains {
 			if src == dest {
@@ -190,7 +211,8 @@ func ConfirmCommitForAllWithExpectedSeqNums(
 					return nil
 				}
 
-				return commonutils.JustError(ConfirmCommitWithExpectedSeqNumRange(
+				return commonutils.JustError(ConfirmCommitWithExpectedSeqNumRangeCtx(
+					ctx,
 					t,
 					srcChain,
 					dstChain,
@@ -203,25 +225,7 @@ func ConfirmCommitForAllWithExpectedSeqNums(
 			})
 		}
 	}
-
-	done := make(chan struct{})
-	go func() {
-		require.NoError(t, wg.Wait())
-		close(done)
-	}()
-
-	require.Eventually(t, func() bool {
-		select {
-		case <-done:
-			return true
-		default:
-			return false
-		}
-	},
-		3*time.Minute,
-		1*time.Second,
-		"all commitments did not confirm",
-	)
+	return wg.Wait()
 }
 
 // ConfirmCommitWithExpectedSeqNumRange waits for a commit report on the destination chain with the expected sequence number range.
@@ -235,26 +239,47 @@ func ConfirmCommitWithExpectedSeqNumRange(
 	startBlock *uint64,
 	expectedSeqNumRange ccipocr3.SeqNumRange,
 ) (*offramp.OffRampCommitReportAccepted, error) {
+	var duration time.Duration
+	deadline, ok := t.Deadline()
+	if ok {
+		// make this timer end a minute before so that we don't hit the deadline
+		duration = deadline.Sub(time.Now().Add(-1 * time.Minute))
+	} else {
+		duration = 5 * time.Minute
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), duration)
+	defer cancel()
+	return ConfirmCommitWithExpectedSeqNumRangeCtx(ctx, t, src, dest, offRamp, startBlock, expectedSeqNumRange)
+}
+
+// ConfirmCommitWithExpectedSeqNumRangeCtx is like ConfirmCommitWithExpectedSeqNumRange, but waits until ctx is done
+// instead of a fixed timeout. If ctx is done first, the error wraps ErrCommitReportTimeout and the ctx error.
+func ConfirmCommitWithExpectedSeqNumRangeCtx(
+	ctx context.Context,
+	t *testing.T,
+	src deployment.Chain,
+	dest deployment.Chain,
+	offRamp *offramp.OffRamp,
+	startBlock *uint64,
+	expectedSeqNumRange ccipocr3.SeqNumRange,
+) (*offramp.OffRampCommitReportAccepted, error) {
+	timeoutErr := func() error {
+		return fmt.Errorf("%w on chain selector %d from source selector %d expected seq nr range %s: %w",
+			ErrCommitReportTimeout, dest.Selector, src.Selector, expectedSeqNumRange.String(), ctx.Err())
+	}
 	sink := make(chan *offramp.OffRampCommitReportAccepted)
 	subscription, err := offRamp.WatchCommitReportAccepted(&bind.WatchOpts{
-		Context: context.Background(),
+		Context: ctx,
 		Start:   startBlock,
 	}, sink)
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, timeoutErr()
+		}
 		return nil, fmt.Errorf("error to subscribe CommitReportAccepted : %w", err)
 	}
 
 	defer subscription.Unsubscribe()
-	var duration time.Duration
-	deadline, ok := t.Deadline()
-	if ok {
-		// make this timer end a minute before so that we don't hit the deadline
-		duration = deadline.Sub(time.Now().Add(-1 * time.Minute))
-	} else {
-		duration = 5 * time.Minute
-	}
-	timer := time.NewTimer(duration)
-	defer timer.Stop()
 	ticker := time.NewTicker(2 * time.Second)
 	defer ticker.Stop()
 	for {
@@ -272,9 +297,14 @@ func ConfirmCommitWithExpectedSeqNumRange(
 
 			// Need to do this because the subscription sometimes fails to get the event.
 			iter, err := offRamp.FilterCommitReportAccepted(&bind.FilterOpts{
-				Context: tests.Context(t),
+				Context: ctx,
 			})
-			require.NoError(t, err)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil, timeoutErr()
+				}
+				return nil, fmt.Errorf("error to filter CommitReportAccepted : %w", err)
+			}
 			for iter.Next() {
 				event := iter.Event
 				if len(event.MerkleRoots) > 0 {
@@ -291,9 +321,8 @@ func ConfirmCommitWithExpectedSeqNumRange(
 			}
 		case subErr := <-subscription.Err():
 			return nil, fmt.Errorf("subscription error: %w", subErr)
-		case <-timer.C:
-			return nil, fmt.Errorf("timed out after waiting %s duration for commit report on chain selector %d from source selector %d expected seq nr range %s",
-				duration.String(), dest.Selector, src.Selector, expectedSeqNumRange.String())
+		case <-ctx.Done():
+			return nil, timeoutErr()
 		case report := <-sink:
 			if len(report.MerkleRoots) > 0 {
 				// Check the interval of sequence numbers and make sure it matches
@@ -477,6 +506,72 @@ func ConfirmNoExecConsistentlyWithSeqNr(
 	}, timeout, 3*time.Second, "Expected no execution state change on chain %d (offramp %s) from chain %d with expected sequence number %d", dest.Selector, offRamp.Address().String(), source.Selector, expectedSeqNr)
 }
 
+// ConfirmNoExecForSeqNums asserts that none of the given sequence numbers is executed within the given duration,
+// i.e. that their execution state on the destination chain OffRamp remains untouched.
+// seqNums is a map of SourceDestPair to a slice of sequence numbers not expected to be executed.
+// It complements ConfirmExecWithSeqNrsForAll for lanes that are expected to be blocked, e.g. cursed.
+func ConfirmNoExecForSeqNums(
+	t *testing.T,
+	e deployment.Environment,
+	state CCIPOnChainState,
+	seqNums map[SourceDestPair][]uint64,
+	within time.Duration,
+) {
+	RequireConsistently(t, func() bool {
+		for pair, seqNrs := range seqNums {
+			source, dest := e.Chains[pair.SourceChainSelector], e.Chains[pair.DestChainSelector]
+			offRamp := state.Chains[pair.DestChainSelector].OffRamp
+			for _, seqNr := range seqNrs {
+				_, executionState := GetExecutionState(t, source, dest, offRamp, seqNr)
+				if executionState != EXECUTION_STATE_UNTOUCHED {
+					t.Logf("Observed %s execution state on chain %d (offramp %s) from chain %d with sequence number %d",
+						executionStateToString(executionState), dest.Selector, offRamp.Address().String(), source.Selector, seqNr)
+					return false
+				}
+			}
+		}
+		return true
+	}, within, 3*time.Second, "Expected no execution state change for sequence numbers %v", seqNums)
+}
+
+// WaitForOCR3ConfigActive polls the OffRamp on chain until the latest OCR3 config of pluginType has the expected
+// digest. It returns an error holding the last seen digest if that does not happen within timeout.
+func WaitForOCR3ConfigActive(
+	ctx context.Context,
+	chain deployment.Chain,
+	offRamp *offramp.OffRamp,
+	pluginType cctypes.PluginType,
+	expectedConfigDigest [32]byte,
+	timeout time.Duration,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	var (
+		lastDigest [32]byte
+		lastErr    error
+	)
+	for {
+		config, err := offRamp.LatestConfigDetails(&bind.CallOpts{Context: ctx}, uint8(pluginType))
+		if err == nil && config.ConfigInfo.ConfigDigest == expectedConfigDigest {
+			return nil
+		}
+		if err == nil {
+			lastDigest = config.ConfigInfo.ConfigDigest
+		}
+		lastErr = err
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return fmt.Errorf("%s OCR3 config digest %x not active on offramp %s on chain %d after %s, last seen digest %x (last error: %v): %w",
+				pluginType, expectedConfigDigest, offRamp.Address(), chain.Selector, timeout, lastDigest, lastErr, ctx.Err())
+		}
+	}
+}
+
 func GetExecutionState(t *testing.T, source, dest deployment.Chain, offRamp *offramp.OffRamp, expectedSeqNr uint64) (offramp.OffRampSourceChainConfig, uint8) {
 	// if it's simulated backend, commit to ensure mining
 	if backend, ok := source.Client.(*memory.Backend); ok {
@@ -0,0 +1,103 @@
+package changeset
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-ccip/commit/merkleroot/rmn/types"
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset/internal"
+	cctypes "github.com/smartcontractkit/chainlink/v2/core/capabilities/ccip/types"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestConfirmNoExecForSeqNums_GlobalCurse(t *testing.T) {
+	e := NewMemoryEnvironmentWithJobsAndContracts(t, logger.TestLogger(t), 2, 4, nil)
+	state, err := LoadOnchainState(e.Env)
+	require.NoError(t, err)
+	require.NoError(t, AddLanesForAll(e.Env, state))
+
+	selectors := e.Env.AllChainSelectors()
+	src, dest := selectors[0], selectors[1]
+
+	// curse the destination chain globally, nothing can be committed or executed on it anymore
+	destChain := e.Env.Chains[dest]
+	tx, err := state.Chains[dest].RMNRemote.Curse(destChain.DeployerKey, types.GlobalCurseSubject)
+	_, err = deployment.ConfirmIfNoError(destChain, tx, err)
+	require.NoError(t, err)
+
+	msgSentEvent := TestSendRequest(t, e.Env, state, src, dest, false, router.ClientEVM2AnyMessage{
+		Receiver:     common.LeftPadBytes(state.Chains[dest].Receiver.Address().Bytes(), 32),
+		Data:         []byte("hello cursed chain"),
+		TokenAmounts: nil,
+		FeeToken:     common.HexToAddress("0x0"),
+		ExtraArgs:    nil,
+	})
+
+	ConfirmNoExecForSeqNums(t, e.Env, state, map[SourceDestPair][]uint64{
+		{SourceChainSelector: src, DestChainSelector: dest}: {msgSentEvent.SequenceNumber},
+	}, 30*time.Second)
+}
+
+func TestConfirmCommitForAllWithExpectedSeqNumsCtx_Cancel(t *testing.T) {
+	e := NewMemoryEnvironmentWithJobsAndContracts(t, logger.TestLogger(t), 2, 4, nil)
+	state, err := LoadOnchainState(e.Env)
+	require.NoError(t, err)
+	require.NoError(t, AddLanesForAll(e.Env, state))
+
+	selectors := e.Env.AllChainSelectors()
+	src, dest := selectors[0], selectors[1]
+
+	// no message is sent, so the commit never arrives
+	ctx, cancel := context.WithCancel(testcontext.Get(t))
+	defer cancel()
+	time.AfterFunc(5*time.Second, cancel)
+
+	start := time.Now()
+	err = ConfirmCommitForAllWithExpectedSeqNumsCtx(ctx, t, e.Env, state, map[SourceDestPair]uint64{
+		{SourceChainSelector: src, DestChainSelector: dest}: 1,
+	}, nil)
+	require.ErrorIs(t, err, ErrCommitReportTimeout)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Less(t, time.Since(start), time.Minute)
+}
+
+func TestWaitForOCR3ConfigActive(t *testing.T) {
+	ctx := testcontext.Get(t)
+	e := NewMemoryEnvironmentWithJobsAndContracts(t, logger.TestLogger(t), 2, 4, nil)
+	state, err := LoadOnchainState(e.Env)
+	require.NoError(t, err)
+
+	chain := e.Env.Chains[e.HomeChainSel]
+	offRamp := state.Chains[e.HomeChainSel].OffRamp
+	commitConfig, err := offRamp.LatestConfigDetails(&bind.CallOpts{Context: ctx}, uint8(cctypes.PluginTypeCCIPCommit))
+	require.NoError(t, err)
+	require.NotEqual(t, [32]byte{}, commitConfig.ConfigInfo.ConfigDigest)
+
+	require.NoError(t, WaitForOCR3ConfigActive(ctx, chain, offRamp, cctypes.PluginTypeCCIPCommit, commitConfig.ConfigInfo.ConfigDigest, 10*time.Second))
+
+	// the exec config has a different digest
+	err = WaitForOCR3ConfigActive(ctx, chain, offRamp, cctypes.PluginTypeCCIPExec, commitConfig.ConfigInfo.ConfigDigest, time.Second)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorContains(t, err, "last seen digest")
+
+	// setting the config again makes the new digest active
+	latestDON, err := internal.LatestCCIPDON(state.Chains[e.HomeChainSel].CapabilityRegistry)
+	require.NoError(t, err)
+	ocrConfigs, err := internal.BuildSetOCR3ConfigArgs(latestDON.Id, state.Chains[e.HomeChainSel].CCIPHome, e.HomeChainSel)
+	require.NoError(t, err)
+	tx, err := offRamp.SetOCR3Configs(chain.DeployerKey, ocrConfigs)
+	_, err = deployment.ConfirmIfNoError(chain, tx, err)
+	require.NoError(t, err)
+	for _, ocrConfig := range ocrConfigs {
+		require.NoError(t, WaitForOCR3ConfigActive(ctx, chain, offRamp, cctypes.PluginType(ocrConfig.OcrPluginType), ocrConfig.ConfigDigest, 10*time.Second))
+	}
+}
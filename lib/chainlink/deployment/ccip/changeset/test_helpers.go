@@ -196,6 +196,12 @@ func NewMemoryEnvironment(
 	require.GreaterOrEqual(t, numNodes, 4, "numNodes must be at least 4")
 	ctx := testcontext.Get(t)
 	chains := memory.NewMemoryChains(t, numChains)
+	errorABIs, err := ErrorABIs()
+	require.NoError(t, err)
+	for sel, chain := range chains {
+		chain.ErrorABIs = errorABIs
+		chains[sel] = chain
+	}
 	homeChainSel, feedSel := allocateCCIPChainSelectors(chains)
 	replayBlocks, err := LatestBlocksByChain(ctx, chains)
 	require.NoError(t, err)
@@ -413,6 +419,15 @@ func CCIPSendRequest(
 		return retryCcipSendUntilNativeFeeIsSufficient(e, r, src, dest, msg)
 	}
 
+	// fee is in an ERC20 token such as LINK, which the router must be allowed to spend
+	fee, err := r.GetFee(&bind.CallOpts{Context: context.Background()}, dest, msg)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get fee: %w", deployment.MaybeDataErr(err))
+	}
+	if err := approveFee(context.Background(), e.Chains[src], msg.FeeToken, r.Address(), fee); err != nil {
+		return nil, 0, err
+	}
+
 	tx, err := r.CcipSend(e.Chains[src].DeployerKey, dest, msg)
 	if err != nil {
 		return nil, 0, errors.Wrap(err, "failed to send CCIP message")
@@ -424,6 +439,9 @@ func CCIPSendRequest(
 	return tx, blockNum, nil
 }
 
+// errCodeInsufficientFee is the selector of the InsufficientFeeTokenAmount error of the router.
+const errCodeInsufficientFee = "0x07da6ee6"
+
 // retryCcipSendUntilNativeFeeIsSufficient sends a CCIP message with a native fee,
 // and retries until the fee is sufficient. This is due to the fact that the fee is not known in advance,
 // and the message will be rejected if the fee is insufficient.
@@ -434,7 +452,6 @@ func retryCcipSendUntilNativeFeeIsSufficient(
 	dest uint64,
 	msg router.ClientEVM2AnyMessage,
 ) (*types.Transaction, uint64, error) {
-	const errCodeInsufficientFee = "0x07da6ee6"
 	defer func() { e.Chains[src].DeployerKey.Value = nil }()
 
 	for {
@@ -518,6 +535,38 @@ func TestSendRequest(
 	return it.Event
 }
 
+// TestSendRequestWithGasLimit is TestSendRequest with the execution gas limit of the message on the destination chain
+// overridden by gasLimit, e.g. for programmable token transfers to receivers with heavier logic than the default gas
+// limit allows for. Any ExtraArgs set on evm2AnyMessage are replaced.
+func TestSendRequestWithGasLimit(
+	t *testing.T,
+	e deployment.Environment,
+	state CCIPOnChainState,
+	src, dest uint64,
+	testRouter bool,
+	evm2AnyMessage router.ClientEVM2AnyMessage,
+	gasLimit uint64,
+) (msgSentEvent *onramp.OnRampCCIPMessageSent) {
+	evm2AnyMessage.ExtraArgs = MakeEVMExtraArgsV2(gasLimit, false)
+	return TestSendRequest(t, e, state, src, dest, testRouter, evm2AnyMessage)
+}
+
+// TestSendRequestWithLinkFee is TestSendRequest paying the fee in the LINK token of the source chain instead of the
+// native token. The fee is estimated and the router approved to spend it, so the deployer must hold enough LINK.
+// Any FeeToken set on evm2AnyMessage is replaced.
+func TestSendRequestWithLinkFee(
+	t *testing.T,
+	e deployment.Environment,
+	state CCIPOnChainState,
+	src, dest uint64,
+	testRouter bool,
+	evm2AnyMessage router.ClientEVM2AnyMessage,
+) (msgSentEvent *onramp.OnRampCCIPMessageSent) {
+	require.NotNil(t, state.Chains[src].LinkToken, "no LINK token on chain %d", src)
+	evm2AnyMessage.FeeToken = state.Chains[src].LinkToken.Address()
+	return TestSendRequest(t, e, state, src, dest, testRouter, evm2AnyMessage)
+}
+
 // MakeEVMExtraArgsV2 creates the extra args for the EVM2Any message that is destined
 // for an EVM chain. The extra args contain the gas limit and allow out of order flag.
 func MakeEVMExtraArgsV2(gasLimit uint64, allowOOO bool) []byte {
@@ -545,15 +594,15 @@ func MakeEVMExtraArgsV2(gasLimit uint64, allowOOO bool) []byte {
 
 // AddLanesForAll adds densely connected lanes for all chains in the environment so that each chain
 // is connected to every other chain except itself.
+// Use PlanLanesForAll to preview the lanes without sending any transaction.
 func AddLanesForAll(e deployment.Environment, state CCIPOnChainState) error {
-	for source := range e.Chains {
-		for dest := range e.Chains {
-			if source != dest {
-				err := AddLaneWithDefaultPricesAndFeeQuoterConfig(e, state, source, dest, false)
-				if err != nil {
-					return err
-				}
-			}
+	plans, err := PlanLanesForAll(e, state)
+	if err != nil {
+		return err
+	}
+	for _, plan := range plans {
+		if err := AddLane(e, state, plan.LaneConfig, plan.IsTestRouter); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -1108,6 +1157,57 @@ func TransferAndWaitForSuccess(
 	data []byte,
 	expectedStatus int,
 ) {
+	transferAndWaitForExecution(ctx, t, env, state, sourceChain, destChain, tokens, receiver, data, expectedStatus)
+}
+
+// TransferAndWaitForFailure sends a message from sourceChain to destChain, waits for it to be executed and
+// asserts that the execution failed. It returns the return data of the failed execution, which holds the revert reason
+// of the receiver if there is one.
+func TransferAndWaitForFailure(
+	ctx context.Context,
+	t *testing.T,
+	env deployment.Environment,
+	state CCIPOnChainState,
+	sourceChain, destChain uint64,
+	tokens []router.ClientEVMTokenAmount,
+	receiver common.Address,
+	data []byte,
+) []byte {
+	seqNr, startBlock := transferAndWaitForExecution(ctx, t, env, state, sourceChain, destChain, tokens, receiver, data, EXECUTION_STATE_FAILURE)
+
+	iter, err := state.Chains[destChain].OffRamp.FilterExecutionStateChanged(&bind.FilterOpts{
+		Context: ctx,
+		Start:   startBlock,
+	}, []uint64{sourceChain}, []uint64{seqNr}, nil)
+	require.NoError(t, err)
+	defer iter.Close()
+
+	var returnData []byte
+	for iter.Next() {
+		if iter.Event.State == EXECUTION_STATE_FAILURE {
+			returnData = iter.Event.ReturnData
+		}
+	}
+	require.NoError(t, iter.Error())
+	t.Logf("Execution of message with sequence number %d from chain %d failed on chain %d with return data %x",
+		seqNr, sourceChain, destChain, returnData)
+	return returnData
+}
+
+// transferAndWaitForExecution sends a message from sourceChain to destChain, waits for it to be committed and executed
+// and requires the execution state to be expectedStatus. It returns the sequence number of the message and the dest
+// chain block the wait started from.
+func transferAndWaitForExecution(
+	ctx context.Context,
+	t *testing.T,
+	env deployment.Environment,
+	state CCIPOnChainState,
+	sourceChain, destChain uint64,
+	tokens []router.ClientEVMTokenAmount,
+	receiver common.Address,
+	data []byte,
+	expectedStatus int,
+) (uint64, uint64) {
 	identifier := SourceDestPair{
 		SourceChainSelector: sourceChain,
 		DestChainSelector:   destChain,
@@ -1138,6 +1238,74 @@ func TransferAndWaitForSuccess(
 	// Wait for all exec reports to land
 	states := ConfirmExecWithSeqNrsForAll(t, env, state, expectedSeqNumExec, startBlocks)
 	require.Equal(t, expectedStatus, states[identifier][msgSentEvent.SequenceNumber])
+	return msgSentEvent.SequenceNumber, block
+}
+
+// TransferSpec describes a single message to send as part of SendAndConfirmMultiSource
+type TransferSpec struct {
+	SourceChain uint64
+	DestChain   uint64
+	Tokens      []router.ClientEVMTokenAmount
+	Receiver    common.Address
+	Data        []byte
+}
+
+// SendAndConfirmMultiSource sends all the given transfers, possibly from several source chains, and waits for all
+// of them to be committed and executed on their destination chains.
+// It returns the execution state of each transfer, in the same order as the specs.
+func SendAndConfirmMultiSource(
+	ctx context.Context,
+	t *testing.T,
+	env deployment.Environment,
+	state CCIPOnChainState,
+	specs []TransferSpec,
+) []int {
+	startBlocks := make(map[uint64]*uint64)
+	for _, spec := range specs {
+		if _, ok := startBlocks[spec.DestChain]; ok {
+			continue
+		}
+		latesthdr, err := env.Chains[spec.DestChain].Client.HeaderByNumber(ctx, nil)
+		require.NoError(t, err)
+		block := latesthdr.Number.Uint64()
+		startBlocks[spec.DestChain] = &block
+	}
+
+	expectedSeqNum := make(map[SourceDestPair]uint64)
+	expectedSeqNumExec := make(map[SourceDestPair][]uint64)
+	seqNrs := make([]uint64, len(specs))
+	for i, spec := range specs {
+		msgSentEvent := TestSendRequest(t, env, state, spec.SourceChain, spec.DestChain, false, router.ClientEVM2AnyMessage{
+			Receiver:     common.LeftPadBytes(spec.Receiver.Bytes(), 32),
+			Data:         spec.Data,
+			TokenAmounts: spec.Tokens,
+			FeeToken:     common.HexToAddress("0x0"),
+			ExtraArgs:    nil,
+		})
+		identifier := SourceDestPair{
+			SourceChainSelector: spec.SourceChain,
+			DestChainSelector:   spec.DestChain,
+		}
+		// commits are in order, so waiting for the latest sequence number of a lane covers the earlier ones
+		expectedSeqNum[identifier] = max(expectedSeqNum[identifier], msgSentEvent.SequenceNumber)
+		expectedSeqNumExec[identifier] = append(expectedSeqNumExec[identifier], msgSentEvent.SequenceNumber)
+		seqNrs[i] = msgSentEvent.SequenceNumber
+	}
+
+	// Wait for all commit reports to land.
+	ConfirmCommitForAllWithExpectedSeqNums(t, env, state, expectedSeqNum, startBlocks)
+
+	// Wait for all exec reports to land
+	states := ConfirmExecWithSeqNrsForAll(t, env, state, expectedSeqNumExec, startBlocks)
+
+	out := make([]int, len(specs))
+	for i, spec := range specs {
+		out[i] = states[SourceDestPair{
+			SourceChainSelector: spec.SourceChain,
+			DestChainSelector:   spec.DestChain,
+		}][seqNrs[i]]
+	}
+	return out
 }
 
 func WaitForTheTokenBalance(
@@ -1166,6 +1334,65 @@ func WaitForTheTokenBalance(
 	}, tests.WaitTimeout(t), 100*time.Millisecond)
 }
 
+// WaitForTheTokenBalanceWithTimeout is WaitForTheTokenBalance bounded by the given timeout. On expiry it fails the test
+// with the last observed balance, the expected balance and the number of polls performed.
+func WaitForTheTokenBalanceWithTimeout(
+	ctx context.Context,
+	t *testing.T,
+	token common.Address,
+	receiver common.Address,
+	chain deployment.Chain,
+	expected *big.Int,
+	timeout time.Duration,
+) {
+	tokenContract, err := burn_mint_erc677.NewBurnMintERC677(token, chain.Client)
+	require.NoError(t, err)
+
+	balanceOf := func(ctx context.Context) (*big.Int, error) {
+		return tokenContract.BalanceOf(&bind.CallOpts{Context: ctx}, receiver)
+	}
+	require.NoError(t, waitForTokenBalance(ctx, balanceOf, token, receiver, expected, timeout, 100*time.Millisecond))
+}
+
+// waitForTokenBalance polls balanceOf every interval until it returns expected or timeout expires.
+func waitForTokenBalance(
+	ctx context.Context,
+	balanceOf func(ctx context.Context) (*big.Int, error),
+	token common.Address,
+	receiver common.Address,
+	expected *big.Int,
+	timeout time.Duration,
+	interval time.Duration,
+) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	tick := time.NewTicker(interval)
+	defer tick.Stop()
+
+	var (
+		lastBalance *big.Int
+		lastErr     error
+		polls       int
+	)
+	for {
+		polls++
+		lastBalance, lastErr = balanceOf(ctx)
+		if lastErr == nil && lastBalance.Cmp(expected) == 0 {
+			return nil
+		}
+
+		select {
+		case <-tick.C:
+		case <-timer.C:
+			return fmt.Errorf("timed out after %s waiting for balance of token %s for receiver %s: expected %s, last observed %v after %d polls (last error: %v)",
+				timeout, token, receiver, expected, lastBalance, polls, lastErr)
+		case <-ctx.Done():
+			return fmt.Errorf("context done waiting for balance of token %s for receiver %s: expected %s, last observed %v after %d polls: %w",
+				token, receiver, expected, lastBalance, polls, ctx.Err())
+		}
+	}
+}
+
 func GetTokenBalance(
 	ctx context.Context,
 	t *testing.T,
@@ -1188,6 +1415,70 @@ func GetTokenBalance(
 	return balance
 }
 
+// PoolLiquidity is a snapshot of the token accounting of a token pool. Lock/release pools move their own Balance,
+// while burn/mint pools move the TotalSupply of the token.
+type PoolLiquidity struct {
+	Token       common.Address
+	Balance     *big.Int
+	TotalSupply *big.Int
+}
+
+// LockedOrBurned returns the amount of tokens locked or burned by the pool between the before and after snapshots.
+func (before PoolLiquidity) LockedOrBurned(after PoolLiquidity) *big.Int {
+	locked := new(big.Int).Sub(after.Balance, before.Balance)
+	burned := new(big.Int).Sub(before.TotalSupply, after.TotalSupply)
+	return locked.Add(locked, burned)
+}
+
+// ReleasedOrMinted returns the amount of tokens released or minted by the pool between the before and after snapshots.
+func (before PoolLiquidity) ReleasedOrMinted(after PoolLiquidity) *big.Int {
+	released := new(big.Int).Sub(before.Balance, after.Balance)
+	minted := new(big.Int).Sub(after.TotalSupply, before.TotalSupply)
+	return released.Add(released, minted)
+}
+
+// GetPoolLiquidity returns the balance of the token pool at address pool and the total supply of its token.
+func GetPoolLiquidity(ctx context.Context, chain deployment.Chain, pool common.Address) (PoolLiquidity, error) {
+	tokenPool, err := burn_mint_token_pool.NewBurnMintTokenPool(pool, chain.Client)
+	if err != nil {
+		return PoolLiquidity{}, err
+	}
+	callOpts := &bind.CallOpts{Context: ctx}
+	token, err := tokenPool.GetToken(callOpts)
+	if err != nil {
+		return PoolLiquidity{}, fmt.Errorf("failed to get token of pool %s: %w", pool, err)
+	}
+	tokenContract, err := burn_mint_erc677.NewBurnMintERC677(token, chain.Client)
+	if err != nil {
+		return PoolLiquidity{}, err
+	}
+	balance, err := tokenContract.BalanceOf(callOpts, pool)
+	if err != nil {
+		return PoolLiquidity{}, fmt.Errorf("failed to get balance of pool %s: %w", pool, err)
+	}
+	totalSupply, err := tokenContract.TotalSupply(callOpts)
+	if err != nil {
+		return PoolLiquidity{}, fmt.Errorf("failed to get total supply of token %s: %w", token, err)
+	}
+	return PoolLiquidity{Token: token, Balance: balance, TotalSupply: totalSupply}, nil
+}
+
+// RequirePoolLiquidityMoved requires that the source pool locked or burned, and the dest pool released or minted,
+// exactly amount tokens between the before and after snapshots taken around a transfer.
+func RequirePoolLiquidityMoved(
+	t *testing.T,
+	srcBefore, srcAfter PoolLiquidity,
+	destBefore, destAfter PoolLiquidity,
+	amount *big.Int,
+) {
+	lockedOrBurned := srcBefore.LockedOrBurned(srcAfter)
+	require.Zero(t, amount.Cmp(lockedOrBurned),
+		"source pool of token %s locked or burned %s, expected %s", srcBefore.Token, lockedOrBurned, amount)
+	releasedOrMinted := destBefore.ReleasedOrMinted(destAfter)
+	require.Zero(t, amount.Cmp(releasedOrMinted),
+		"dest pool of token %s released or minted %s, expected %s", destBefore.Token, releasedOrMinted, amount)
+}
+
 func DefaultRouterMessage(receiverAddress common.Address) router.ClientEVM2AnyMessage {
 	return router.ClientEVM2AnyMessage{
 		Receiver:     common.LeftPadBytes(receiverAddress.Bytes(), 32),
@@ -0,0 +1,139 @@
+package changeset
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/maps"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestSendRequest_GasLimitOverride(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	tenv := NewMemoryEnvironmentWithJobsAndContracts(t, lggr, 2, 4, nil)
+	e := tenv.Env
+
+	state, err := LoadOnchainState(e)
+	require.NoError(t, err)
+	require.NoError(t, AddLanesForAll(e, state))
+
+	allChains := maps.Keys(e.Chains)
+	src, dest := allChains[0], allChains[1]
+
+	latesthdr, err := e.Chains[dest].Client.HeaderByNumber(testcontext.Get(t), nil)
+	require.NoError(t, err)
+	block := latesthdr.Number.Uint64()
+	startBlocks := map[uint64]*uint64{dest: &block}
+
+	// well above the default gas limit applied when no extra args are provided
+	msgSentEvent := TestSendRequestWithGasLimit(t, e, state, src, dest, false, router.ClientEVM2AnyMessage{
+		Receiver:     common.LeftPadBytes(state.Chains[dest].Receiver.Address().Bytes(), 32),
+		Data:         []byte("hello with gas limit"),
+		TokenAmounts: nil,
+		FeeToken:     common.HexToAddress("0x0"),
+	}, 500_000)
+
+	identifier := SourceDestPair{
+		SourceChainSelector: src,
+		DestChainSelector:   dest,
+	}
+	ConfirmCommitForAllWithExpectedSeqNums(t, e, state, map[SourceDestPair]uint64{
+		identifier: msgSentEvent.SequenceNumber,
+	}, startBlocks)
+	states := ConfirmExecWithSeqNrsForAll(t, e, state, map[SourceDestPair][]uint64{
+		identifier: {msgSentEvent.SequenceNumber},
+	}, startBlocks)
+	require.Equal(t, EXECUTION_STATE_SUCCESS, states[identifier][msgSentEvent.SequenceNumber])
+}
+
+func TestSendRequest_LinkFee(t *testing.T) {
+	lggr := logger.TestLogger(t)
+	tenv := NewMemoryEnvironmentWithJobsAndContracts(t, lggr, 2, 4, nil)
+	e := tenv.Env
+	ctx := testcontext.Get(t)
+
+	state, err := LoadOnchainState(e)
+	require.NoError(t, err)
+	require.NoError(t, AddLanesForAll(e, state))
+
+	allChains := maps.Keys(e.Chains)
+	src, dest := allChains[0], allChains[1]
+
+	// fund the deployer with LINK to pay the fee with
+	srcChain := e.Chains[src]
+	linkToken := state.Chains[src].LinkToken
+	tx, err := linkToken.GrantMintRole(srcChain.DeployerKey, srcChain.DeployerKey.From)
+	_, err = deployment.ConfirmIfNoError(srcChain, tx, err)
+	require.NoError(t, err)
+	funds := new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18))
+	tx, err = linkToken.Mint(srcChain.DeployerKey, srcChain.DeployerKey.From, funds)
+	_, err = deployment.ConfirmIfNoError(srcChain, tx, err)
+	require.NoError(t, err)
+
+	latesthdr, err := e.Chains[dest].Client.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	block := latesthdr.Number.Uint64()
+	startBlocks := map[uint64]*uint64{dest: &block}
+
+	msgSentEvent := TestSendRequestWithLinkFee(t, e, state, src, dest, false, router.ClientEVM2AnyMessage{
+		Receiver:     common.LeftPadBytes(state.Chains[dest].Receiver.Address().Bytes(), 32),
+		Data:         []byte("hello paid in link"),
+		TokenAmounts: nil,
+	})
+	require.Equal(t, linkToken.Address(), msgSentEvent.Message.FeeToken)
+	require.Positive(t, msgSentEvent.Message.FeeTokenAmount.Sign())
+
+	balance, err := linkToken.BalanceOf(&bind.CallOpts{Context: ctx}, srcChain.DeployerKey.From)
+	require.NoError(t, err)
+	require.Equal(t, new(big.Int).Sub(funds, msgSentEvent.Message.FeeTokenAmount), balance)
+
+	identifier := SourceDestPair{
+		SourceChainSelector: src,
+		DestChainSelector:   dest,
+	}
+	ConfirmCommitForAllWithExpectedSeqNums(t, e, state, map[SourceDestPair]uint64{
+		identifier: msgSentEvent.SequenceNumber,
+	}, startBlocks)
+	states := ConfirmExecWithSeqNrsForAll(t, e, state, map[SourceDestPair][]uint64{
+		identifier: {msgSentEvent.SequenceNumber},
+	}, startBlocks)
+	require.Equal(t, EXECUTION_STATE_SUCCESS, states[identifier][msgSentEvent.SequenceNumber])
+}
+
+func Test_waitForTokenBalance(t *testing.T) {
+	token := common.HexToAddress("0x1")
+	receiver := common.HexToAddress("0x2")
+
+	t.Run("balance arrives", func(t *testing.T) {
+		var polls int
+		balanceOf := func(ctx context.Context) (*big.Int, error) {
+			polls++
+			return big.NewInt(int64(polls)), nil
+		}
+		err := waitForTokenBalance(testcontext.Get(t), balanceOf, token, receiver, big.NewInt(3), time.Minute, time.Millisecond)
+		require.NoError(t, err)
+		require.Equal(t, 3, polls)
+	})
+
+	t.Run("balance never arrives", func(t *testing.T) {
+		balanceOf := func(ctx context.Context) (*big.Int, error) {
+			return big.NewInt(1), nil
+		}
+		err := waitForTokenBalance(testcontext.Get(t), balanceOf, token, receiver, big.NewInt(2), 50*time.Millisecond, 10*time.Millisecond)
+		require.Error(t, err)
+		require.ErrorContains(t, err, "timed out after 50ms")
+		require.ErrorContains(t, err, token.String())
+		require.ErrorContains(t, err, "expected 2, last observed 1")
+		require.Regexp(t, `after \d+ polls`, err.Error())
+	})
+}
@@ -1,6 +1,7 @@
 package changeset
 
 import (
+	"fmt"
 	"math/big"
 
 	"golang.org/x/sync/errgroup"
@@ -103,6 +104,82 @@ func configureSingleChain(
 	}
 }
 
+// USDCRateLimits holds the rate limiter configs of a USDC token pool for a single remote chain.
+// A nil Capacity or Rate is treated as zero, which is what a disabled rate limiter expects.
+type USDCRateLimits struct {
+	Outbound usdc_token_pool.RateLimiterConfig
+	Inbound  usdc_token_pool.RateLimiterConfig
+}
+
+func (r USDCRateLimits) Validate() error {
+	if err := validateRateLimiterConfig(r.Outbound); err != nil {
+		return fmt.Errorf("invalid outbound rate limiter config: %w", err)
+	}
+	if err := validateRateLimiterConfig(r.Inbound); err != nil {
+		return fmt.Errorf("invalid inbound rate limiter config: %w", err)
+	}
+	return nil
+}
+
+// validateRateLimiterConfig mirrors the checks of the on-chain RateLimiter, so that a bad config is reported
+// before sending a transaction that would revert.
+func validateRateLimiterConfig(cfg usdc_token_pool.RateLimiterConfig) error {
+	capacity, rate := bigOrZero(cfg.Capacity), bigOrZero(cfg.Rate)
+	if capacity.Sign() < 0 || rate.Sign() < 0 {
+		return fmt.Errorf("capacity %s and rate %s must be non-negative", capacity, rate)
+	}
+	if !cfg.IsEnabled {
+		if capacity.Sign() != 0 || rate.Sign() != 0 {
+			return fmt.Errorf("capacity and rate must be zero when the rate limiter is disabled, got capacity %s and rate %s", capacity, rate)
+		}
+		return nil
+	}
+	if rate.Sign() == 0 || rate.Cmp(capacity) >= 0 {
+		return fmt.Errorf("rate %s must be positive and lower than capacity %s", rate, capacity)
+	}
+	return nil
+}
+
+func bigOrZero(i *big.Int) *big.Int {
+	if i == nil {
+		return big.NewInt(0)
+	}
+	return i
+}
+
+// SetUSDCTokenPoolRateLimits sets the outbound and inbound rate limiters of the USDC token pool on chain for
+// transfers to and from remoteChainSel. The remote chain must already be configured on the pool,
+// see ConfigureUSDCTokenPools.
+func SetUSDCTokenPoolRateLimits(
+	lggr logger.Logger,
+	chain deployment.Chain,
+	pool *usdc_token_pool.USDCTokenPool,
+	remoteChainSel uint64,
+	limits USDCRateLimits,
+) error {
+	if err := limits.Validate(); err != nil {
+		return err
+	}
+	outbound := usdc_token_pool.RateLimiterConfig{
+		IsEnabled: limits.Outbound.IsEnabled,
+		Capacity:  bigOrZero(limits.Outbound.Capacity),
+		Rate:      bigOrZero(limits.Outbound.Rate),
+	}
+	inbound := usdc_token_pool.RateLimiterConfig{
+		IsEnabled: limits.Inbound.IsEnabled,
+		Capacity:  bigOrZero(limits.Inbound.Capacity),
+		Rate:      bigOrZero(limits.Inbound.Rate),
+	}
+	tx, err := pool.SetChainRateLimiterConfig(chain.DeployerKey, remoteChainSel, outbound, inbound)
+	if err != nil {
+		lggr.Errorw("Failed to set rate limiter config", "err", err, "pool", pool.Address(), "remoteChain", remoteChainSel)
+		return fmt.Errorf("failed to set rate limiter config on USDC token pool %s for chain %d: %w",
+			pool.Address(), remoteChainSel, deployment.MaybeDataErr(err))
+	}
+	_, err = chain.Confirm(tx)
+	return err
+}
+
 func UpdateFeeQuoterForUSDC(
 	lggr logger.Logger,
 	chain deployment.Chain,
@@ -0,0 +1,108 @@
+package changeset
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/utils"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/usdc_token_pool"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/shared/generated/burn_mint_erc677"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestUSDCRateLimits_Validate(t *testing.T) {
+	limits := func(enabled bool, capacity, rate int64) USDCRateLimits {
+		return USDCRateLimits{Outbound: usdc_token_pool.RateLimiterConfig{
+			IsEnabled: enabled,
+			Capacity:  big.NewInt(capacity),
+			Rate:      big.NewInt(rate),
+		}}
+	}
+
+	require.NoError(t, USDCRateLimits{}.Validate())
+	require.NoError(t, limits(true, 100, 1).Validate())
+	require.ErrorContains(t, limits(false, 100, 1).Validate(), "capacity and rate must be zero when the rate limiter is disabled")
+	require.ErrorContains(t, limits(true, 100, 0).Validate(), "rate 0 must be positive and lower than capacity 100")
+	require.ErrorContains(t, limits(true, 100, 100).Validate(), "rate 100 must be positive and lower than capacity 100")
+	require.ErrorContains(t, limits(true, -1, 1).Validate(), "must be non-negative")
+
+	inbound := USDCRateLimits{Inbound: usdc_token_pool.RateLimiterConfig{IsEnabled: true}}
+	require.ErrorContains(t, inbound.Validate(), "invalid inbound rate limiter config")
+}
+
+func TestSetUSDCTokenPoolRateLimits(t *testing.T) {
+	ctx := testcontext.Get(t)
+	lggr := logger.TestLogger(t)
+	tenv := NewMemoryEnvironmentWithJobsAndContracts(t, lggr, 2, 4, &TestConfigs{IsUSDC: true})
+	e := tenv.Env
+	state, err := LoadOnchainState(e)
+	require.NoError(t, err)
+
+	allChains := e.AllChainSelectors()
+	src, dst := allChains[0], allChains[1]
+	srcUSDC, dstUSDC, err := ConfigureUSDCTokenPools(lggr, e.Chains, src, dst, state)
+	require.NoError(t, err)
+	require.NoError(t, AddLanesForAll(e, state))
+	MintAndAllow(t, e, state,
+		map[uint64]*bind.TransactOpts{src: e.Chains[src].DeployerKey, dst: e.Chains[dst].DeployerKey},
+		map[uint64][]*burn_mint_erc677.BurnMintERC677{src: {srcUSDC}, dst: {dstUSDC}},
+	)
+	require.NoError(t, UpdateFeeQuoterForUSDC(lggr, e.Chains[src], state.Chains[src], dst, srcUSDC))
+
+	srcPool := state.Chains[src].USDCTokenPool
+	capacity := big.NewInt(100)
+	require.NoError(t, SetUSDCTokenPoolRateLimits(lggr, e.Chains[src], srcPool, dst, USDCRateLimits{
+		Outbound: usdc_token_pool.RateLimiterConfig{IsEnabled: true, Capacity: capacity, Rate: big.NewInt(1)},
+	}))
+
+	outbound, err := srcPool.GetCurrentOutboundRateLimiterState(&bind.CallOpts{Context: ctx}, dst)
+	require.NoError(t, err)
+	require.True(t, outbound.IsEnabled)
+	require.Equal(t, capacity, outbound.Capacity)
+	inbound, err := srcPool.GetCurrentInboundRateLimiterState(&bind.CallOpts{Context: ctx}, dst)
+	require.NoError(t, err)
+	require.False(t, inbound.IsEnabled)
+
+	send := func(amount *big.Int) error {
+		_, _, err := CCIPSendRequest(e, state, src, dst, false, router.ClientEVM2AnyMessage{
+			Receiver:     common.LeftPadBytes(utils.RandomAddress().Bytes(), 32),
+			TokenAmounts: []router.ClientEVMTokenAmount{{Token: srcUSDC.Address(), Amount: amount}},
+			FeeToken:     common.HexToAddress("0x0"),
+		})
+		return err
+	}
+
+	// a transfer within the capacity goes through
+	require.NoError(t, send(big.NewInt(10)))
+
+	// a transfer exceeding the capacity is rejected by the pool
+	poolABI, err := usdc_token_pool.USDCTokenPoolMetaData.GetAbi()
+	require.NoError(t, err)
+	errID := poolABI.Errors["TokenMaxCapacityExceeded"].ID
+	selector := hexutil.Encode(errID[:4])
+	err = send(new(big.Int).Add(capacity, big.NewInt(1)))
+	require.Error(t, err)
+	var dataErr rpc.DataError
+	require.ErrorAs(t, err, &dataErr)
+	require.Contains(t, fmt.Sprint(dataErr.ErrorData()), selector)
+
+	// an invalid config is rejected before sending a transaction
+	err = SetUSDCTokenPoolRateLimits(lggr, e.Chains[src], srcPool, dst, USDCRateLimits{
+		Outbound: usdc_token_pool.RateLimiterConfig{IsEnabled: true, Capacity: capacity, Rate: capacity},
+	})
+	require.ErrorContains(t, err, "invalid outbound rate limiter config")
+
+	// disabling the rate limiter lifts the limit
+	require.NoError(t, SetUSDCTokenPoolRateLimits(lggr, e.Chains[src], srcPool, dst, USDCRateLimits{}))
+	require.NoError(t, send(new(big.Int).Add(capacity, big.NewInt(1))))
+}
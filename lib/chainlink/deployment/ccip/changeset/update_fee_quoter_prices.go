@@ -0,0 +1,173 @@
+package changeset
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/gethwrappers"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/mcms"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/proposal/timelock"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/common/proposalutils"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/fee_quoter"
+)
+
+var _ deployment.ChangeSet[UpdateFeeQuoterPricesConfig] = UpdateFeeQuoterPricesChangeset
+
+// FeeQuoterPrices holds the prices to set on the FeeQuoter of a chain.
+type FeeQuoterPrices struct {
+	// TokenPrices maps a token on the chain to its USD price, with 18 decimals per 1e18 of its smallest unit.
+	TokenPrices map[common.Address]*big.Int
+	// GasPrices maps a destination chain selector to its USD price per unit of gas.
+	GasPrices map[uint64]*big.Int
+}
+
+type UpdateFeeQuoterPricesConfig struct {
+	// PricesByChain is a mapping from chain selector to the prices to set on the FeeQuoter of that chain.
+	PricesByChain map[uint64]FeeQuoterPrices
+	// MCMS is nil if the prices are updated directly by the deployer, which must be an authorized price updater.
+	// Otherwise a proposal is generated for the timelocks, which must be authorized price updaters instead.
+	MCMS *MCMSConfig
+}
+
+func (c UpdateFeeQuoterPricesConfig) Validate() error {
+	if len(c.PricesByChain) == 0 {
+		return errors.New("no prices provided")
+	}
+	for chainSel, prices := range c.PricesByChain {
+		if err := deployment.IsValidChainSelector(chainSel); err != nil {
+			return fmt.Errorf("invalid chain selector %d: %w", chainSel, err)
+		}
+		if len(prices.TokenPrices) == 0 && len(prices.GasPrices) == 0 {
+			return fmt.Errorf("no prices provided for chain %d", chainSel)
+		}
+		for token, price := range prices.TokenPrices {
+			if token == (common.Address{}) {
+				return fmt.Errorf("token price for chain %d has an empty token address", chainSel)
+			}
+			if price == nil || price.Sign() < 0 {
+				return fmt.Errorf("token price %v of %s on chain %d must be non-negative", price, token.Hex(), chainSel)
+			}
+		}
+		for destChainSel, price := range prices.GasPrices {
+			if err := deployment.IsValidChainSelector(destChainSel); err != nil {
+				return fmt.Errorf("invalid gas price dest chain selector %d on chain %d: %w", destChainSel, chainSel, err)
+			}
+			if price == nil || price.Sign() < 0 {
+				return fmt.Errorf("gas price %v for dest chain %d on chain %d must be non-negative", price, destChainSel, chainSel)
+			}
+		}
+	}
+	return nil
+}
+
+// UpdateFeeQuoterPricesChangeset sets the given token and gas prices on the FeeQuoter of each configured chain,
+// with a single updatePrices call per chain.
+func UpdateFeeQuoterPricesChangeset(e deployment.Environment, cfg UpdateFeeQuoterPricesConfig) (deployment.ChangesetOutput, error) {
+	if err := cfg.Validate(); err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("invalid UpdateFeeQuoterPricesConfig: %w", err)
+	}
+	state, err := LoadOnchainState(e)
+	if err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("failed to load onchain state: %w", err)
+	}
+
+	chainSels := make([]uint64, 0, len(cfg.PricesByChain))
+	for chainSel := range cfg.PricesByChain {
+		chainSels = append(chainSels, chainSel)
+	}
+	sort.Slice(chainSels, func(i, j int) bool { return chainSels[i] < chainSels[j] })
+
+	var (
+		timelocksPerChain = make(map[uint64]common.Address)
+		proposerMCMSes    = make(map[uint64]*gethwrappers.ManyChainMultiSig)
+		batches           []timelock.BatchChainOperation
+	)
+	for _, chainSel := range chainSels {
+		chain, ok := e.Chains[chainSel]
+		if !ok {
+			return deployment.ChangesetOutput{}, fmt.Errorf("chain %d not found in environment", chainSel)
+		}
+		chainState, ok := state.Chains[chainSel]
+		if !ok || chainState.FeeQuoter == nil {
+			return deployment.ChangesetOutput{}, fmt.Errorf("FeeQuoter not found for chain %d", chainSel)
+		}
+		updates := feeQuoterPriceUpdates(cfg.PricesByChain[chainSel])
+
+		if cfg.MCMS == nil {
+			tx, err := chainState.FeeQuoter.UpdatePrices(chain.DeployerKey, updates)
+			if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+				return deployment.ChangesetOutput{}, fmt.Errorf("failed to update FeeQuoter prices on chain %d: %w", chainSel, err)
+			}
+			e.Logger.Infow("Updated FeeQuoter prices", "chain", chainSel,
+				"tokenPrices", len(updates.TokenPriceUpdates), "gasPrices", len(updates.GasPriceUpdates))
+			continue
+		}
+
+		if chainState.Timelock == nil || chainState.ProposerMcm == nil {
+			return deployment.ChangesetOutput{}, fmt.Errorf("timelock or proposer MCMS not found for chain %d", chainSel)
+		}
+		tx, err := chainState.FeeQuoter.UpdatePrices(deployment.SimTransactOpts(), updates)
+		if err != nil {
+			return deployment.ChangesetOutput{}, fmt.Errorf("failed to build updatePrices call for FeeQuoter on chain %d: %w", chainSel, err)
+		}
+		timelocksPerChain[chainSel] = chainState.Timelock.Address()
+		proposerMCMSes[chainSel] = chainState.ProposerMcm
+		batches = append(batches, timelock.BatchChainOperation{
+			ChainIdentifier: mcms.ChainIdentifier(chainSel),
+			Batch: []mcms.Operation{{
+				To:    chainState.FeeQuoter.Address(),
+				Data:  tx.Data(),
+				Value: big.NewInt(0),
+			}},
+		})
+	}
+
+	if cfg.MCMS == nil {
+		return deployment.ChangesetOutput{}, nil
+	}
+	prop, err := proposalutils.BuildProposalFromBatches(
+		timelocksPerChain,
+		proposerMCMSes,
+		batches,
+		"update FeeQuoter prices",
+		cfg.MCMS.MinDelay,
+	)
+	if err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("failed to build proposal: %w", err)
+	}
+	return deployment.ChangesetOutput{
+		Proposals: []timelock.MCMSWithTimelockProposal{*prop},
+	}, nil
+}
+
+// feeQuoterPriceUpdates returns the prices as FeeQuoter price updates, sorted so that the call data is deterministic.
+func feeQuoterPriceUpdates(prices FeeQuoterPrices) fee_quoter.InternalPriceUpdates {
+	var updates fee_quoter.InternalPriceUpdates
+	for token, price := range prices.TokenPrices {
+		updates.TokenPriceUpdates = append(updates.TokenPriceUpdates, fee_quoter.InternalTokenPriceUpdate{
+			SourceToken: token,
+			UsdPerToken: price,
+		})
+	}
+	slices.SortFunc(updates.TokenPriceUpdates, func(a, b fee_quoter.InternalTokenPriceUpdate) int {
+		return bytes.Compare(a.SourceToken.Bytes(), b.SourceToken.Bytes())
+	})
+	for destChainSel, price := range prices.GasPrices {
+		updates.GasPriceUpdates = append(updates.GasPriceUpdates, fee_quoter.InternalGasPriceUpdate{
+			DestChainSelector: destChainSel,
+			UsdPerUnitGas:     price,
+		})
+	}
+	slices.SortFunc(updates.GasPriceUpdates, func(a, b fee_quoter.InternalGasPriceUpdate) int {
+		return cmp.Compare(a.DestChainSelector, b.DestChainSelector)
+	})
+	return updates
+}
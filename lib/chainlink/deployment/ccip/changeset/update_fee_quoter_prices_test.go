@@ -0,0 +1,131 @@
+package changeset
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+func TestUpdateFeeQuoterPricesConfig_Validate(t *testing.T) {
+	chainSel := chainsel.TEST_90000001.Selector
+	destChainSel := chainsel.TEST_90000002.Selector
+	token := common.HexToAddress("0x1")
+	tests := []struct {
+		name    string
+		prices  map[uint64]FeeQuoterPrices
+		wantErr string
+	}{
+		{
+			name: "valid",
+			prices: map[uint64]FeeQuoterPrices{chainSel: {
+				TokenPrices: map[common.Address]*big.Int{token: big.NewInt(0)},
+				GasPrices:   map[uint64]*big.Int{destChainSel: big.NewInt(1)},
+			}},
+		},
+		{
+			name:    "no chains",
+			wantErr: "no prices provided",
+		},
+		{
+			name:    "no prices for a chain",
+			prices:  map[uint64]FeeQuoterPrices{chainSel: {}},
+			wantErr: "no prices provided for chain",
+		},
+		{
+			name:    "invalid chain selector",
+			prices:  map[uint64]FeeQuoterPrices{1: {TokenPrices: map[common.Address]*big.Int{token: big.NewInt(1)}}},
+			wantErr: "invalid chain selector 1",
+		},
+		{
+			name:    "negative token price",
+			prices:  map[uint64]FeeQuoterPrices{chainSel: {TokenPrices: map[common.Address]*big.Int{token: big.NewInt(-1)}}},
+			wantErr: "must be non-negative",
+		},
+		{
+			name:    "missing token price",
+			prices:  map[uint64]FeeQuoterPrices{chainSel: {TokenPrices: map[common.Address]*big.Int{token: nil}}},
+			wantErr: "must be non-negative",
+		},
+		{
+			name:    "empty token address",
+			prices:  map[uint64]FeeQuoterPrices{chainSel: {TokenPrices: map[common.Address]*big.Int{{}: big.NewInt(1)}}},
+			wantErr: "empty token address",
+		},
+		{
+			name:    "negative gas price",
+			prices:  map[uint64]FeeQuoterPrices{chainSel: {GasPrices: map[uint64]*big.Int{destChainSel: big.NewInt(-1)}}},
+			wantErr: "must be non-negative",
+		},
+		{
+			name:    "invalid gas price dest chain",
+			prices:  map[uint64]FeeQuoterPrices{chainSel: {GasPrices: map[uint64]*big.Int{1: big.NewInt(1)}}},
+			wantErr: "invalid gas price dest chain selector 1",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := UpdateFeeQuoterPricesConfig{PricesByChain: tc.prices}.Validate()
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestUpdateFeeQuoterPricesChangeset(t *testing.T) {
+	ctx := testcontext.Get(t)
+	e := NewMemoryEnvironmentWithJobsAndContracts(t, logger.TestLogger(t), 2, 4, nil)
+	state, err := LoadOnchainState(e.Env)
+	require.NoError(t, err)
+	allChains := e.Env.AllChainSelectors()
+	src, dst := allChains[0], allChains[1]
+
+	linkPrice := new(big.Int).Mul(big.NewInt(15), big.NewInt(1e18))
+	wethPrice := new(big.Int).Mul(big.NewInt(3000), big.NewInt(1e18))
+	gasPrice := big.NewInt(2e12)
+	prices := map[uint64]FeeQuoterPrices{
+		src: {
+			TokenPrices: map[common.Address]*big.Int{
+				state.Chains[src].LinkToken.Address(): linkPrice,
+				state.Chains[src].Weth9.Address():     wethPrice,
+			},
+			GasPrices: map[uint64]*big.Int{dst: gasPrice},
+		},
+		dst: {
+			TokenPrices: map[common.Address]*big.Int{
+				state.Chains[dst].LinkToken.Address(): linkPrice,
+			},
+		},
+	}
+
+	out, err := UpdateFeeQuoterPricesChangeset(e.Env, UpdateFeeQuoterPricesConfig{PricesByChain: prices})
+	require.NoError(t, err)
+	require.Empty(t, out.Proposals)
+
+	for chainSel, chainPrices := range prices {
+		for token, price := range chainPrices.TokenPrices {
+			got, err := state.Chains[chainSel].FeeQuoter.GetTokenPrice(&bind.CallOpts{Context: ctx}, token)
+			require.NoError(t, err)
+			require.Equal(t, price, got.Value, "token %s on chain %d", token.Hex(), chainSel)
+		}
+	}
+	gotGasPrice, err := state.Chains[src].FeeQuoter.GetDestinationChainGasPrice(&bind.CallOpts{Context: ctx}, dst)
+	require.NoError(t, err)
+	require.Equal(t, gasPrice, gotGasPrice.Value)
+
+	// with MCMS a single proposal covering all chains is generated instead
+	out, err = UpdateFeeQuoterPricesChangeset(e.Env, UpdateFeeQuoterPricesConfig{PricesByChain: prices, MCMS: &MCMSConfig{}})
+	require.NoError(t, err)
+	require.Len(t, out.Proposals, 1)
+	require.Len(t, out.Proposals[0].Transactions, 2)
+}
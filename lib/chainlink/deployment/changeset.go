@@ -28,6 +28,16 @@ type ChangesetOutput struct {
 	JobSpecs    map[string][]string
 	Proposals   []timelock.MCMSWithTimelockProposal
 	AddressBook AddressBook
+	// Report is an optional changeset specific summary of the changes made (or planned, for dry runs)
+	// that operators can review.
+	Report ChangesetReport
+}
+
+// ChangesetReport is a changeset specific summary of the changes made by a changeset, see ChangesetOutput.Report.
+// Changesets offer a dedicated function returning their concrete report type to callers needing its details.
+type ChangesetReport interface {
+	// String describes the changes for operators to review.
+	String() string
 }
 
 // ViewState produces a product specific JSON representation of
@@ -1,16 +1,55 @@
 package changeset
 
 import (
+	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"go.uber.org/multierr"
 
 	"github.com/smartcontractkit/chainlink/deployment"
 	"github.com/smartcontractkit/chainlink/deployment/common/types"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/shared/generated/burn_mint_erc677"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/shared/generated/link_token"
 )
 
 var _ deployment.ChangeSet[uint64] = DeployLinkToken
+var _ deployment.ChangeSet[[]uint64] = DeployLinkTokenMultiChain
+var _ deployment.ChangeSet[DeployLinkTokenConfig] = DeployConfigurableLinkToken
+
+// DeployLinkTokenConfig configures the deployment of a LINK-like BurnMintERC677 token.
+type DeployLinkTokenConfig struct {
+	ChainSelector uint64
+	Name          string
+	Symbol        string
+	Decimals      uint8
+	// MaxSupply caps the total supply of the token, zero means unlimited.
+	MaxSupply *big.Int
+	// InitialSupply is minted to the deployer key once the token is deployed, nil or zero skips the mint.
+	InitialSupply *big.Int
+}
+
+func (c DeployLinkTokenConfig) Validate() error {
+	if c.Name == "" {
+		return errors.New("name must be set")
+	}
+	if c.Symbol == "" {
+		return errors.New("symbol must be set")
+	}
+	if c.MaxSupply == nil || c.MaxSupply.Sign() < 0 {
+		return errors.New("maxSupply must be set and non-negative")
+	}
+	if c.InitialSupply != nil {
+		if c.InitialSupply.Sign() < 0 {
+			return errors.New("initialSupply must be non-negative")
+		}
+		if c.MaxSupply.Sign() > 0 && c.InitialSupply.Cmp(c.MaxSupply) > 0 {
+			return fmt.Errorf("initialSupply %s exceeds maxSupply %s", c.InitialSupply, c.MaxSupply)
+		}
+	}
+	return nil
+}
 
 // DeployLinkToken deploys a link token contract to the chain identified by the chainSelector.
 func DeployLinkToken(e deployment.Environment, chainSelector uint64) (deployment.ChangesetOutput, error) {
@@ -28,6 +67,46 @@ func DeployLinkToken(e deployment.Environment, chainSelector uint64) (deployment
 	return deployment.ChangesetOutput{AddressBook: newAddresses}, nil
 }
 
+// DeployLinkTokenMultiChain deploys a link token contract to each of the chains identified by chainSelectors.
+// A failure on one chain does not stop deployment to the others: the returned address book holds every
+// successful deployment and the error aggregates the per-chain failures.
+func DeployLinkTokenMultiChain(e deployment.Environment, chainSelectors []uint64) (deployment.ChangesetOutput, error) {
+	newAddresses := deployment.NewMemoryAddressBook()
+	var errs error
+	for _, chainSelector := range chainSelectors {
+		c, ok := e.Chains[chainSelector]
+		if !ok {
+			errs = multierr.Append(errs, fmt.Errorf("chain %d not found in environment", chainSelector))
+			continue
+		}
+		if _, err := deployLinkTokenContract(e.Logger, c, newAddresses); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("failed to deploy link token to chain %d: %w", chainSelector, err))
+		}
+	}
+	return deployment.ChangesetOutput{AddressBook: newAddresses}, errs
+}
+
+// DeployConfigurableLinkToken deploys a LINK-like token with the configured name, symbol, decimals and max supply
+// to the chain identified by cfg.ChainSelector, minting cfg.InitialSupply to the deployer key.
+// The token is recorded in the address book as a LinkToken.
+func DeployConfigurableLinkToken(e deployment.Environment, cfg DeployLinkTokenConfig) (deployment.ChangesetOutput, error) {
+	if err := cfg.Validate(); err != nil {
+		return deployment.ChangesetOutput{}, fmt.Errorf("invalid DeployLinkTokenConfig: %w", err)
+	}
+	c, ok := e.Chains[cfg.ChainSelector]
+	if !ok {
+		return deployment.ChangesetOutput{}, fmt.Errorf("chain not found in environment")
+	}
+	newAddresses := deployment.NewMemoryAddressBook()
+	_, err := deployConfigurableLinkTokenContract(
+		e.Logger, c, newAddresses, cfg,
+	)
+	if err != nil {
+		return deployment.ChangesetOutput{AddressBook: newAddresses}, err
+	}
+	return deployment.ChangesetOutput{AddressBook: newAddresses}, nil
+}
+
 func deployLinkTokenContract(
 	lggr logger.Logger,
 	chain deployment.Chain,
@@ -53,3 +132,48 @@ func deployLinkTokenContract(
 	}
 	return linkToken, nil
 }
+
+func deployConfigurableLinkTokenContract(
+	lggr logger.Logger,
+	chain deployment.Chain,
+	ab deployment.AddressBook,
+	cfg DeployLinkTokenConfig,
+) (*deployment.ContractDeploy[*burn_mint_erc677.BurnMintERC677], error) {
+	linkToken, err := deployment.DeployContract[*burn_mint_erc677.BurnMintERC677](lggr, chain, ab,
+		func(chain deployment.Chain) deployment.ContractDeploy[*burn_mint_erc677.BurnMintERC677] {
+			linkTokenAddr, tx, linkToken, err2 := burn_mint_erc677.DeployBurnMintERC677(
+				chain.DeployerKey,
+				chain.Client,
+				cfg.Name,
+				cfg.Symbol,
+				cfg.Decimals,
+				cfg.MaxSupply,
+			)
+			return deployment.ContractDeploy[*burn_mint_erc677.BurnMintERC677]{
+				Address:  linkTokenAddr,
+				Contract: linkToken,
+				Tx:       tx,
+				Tv:       deployment.NewTypeAndVersion(types.LinkToken, deployment.Version1_0_0),
+				Err:      err2,
+			}
+		})
+	if err != nil {
+		lggr.Errorw("Failed to deploy link token", "err", err)
+		return linkToken, err
+	}
+	if cfg.InitialSupply == nil || cfg.InitialSupply.Sign() == 0 {
+		return linkToken, nil
+	}
+
+	tx, err := linkToken.Contract.GrantMintRole(chain.DeployerKey, chain.DeployerKey.From)
+	if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+		lggr.Errorw("Failed to grant mint role on link token", "err", err)
+		return linkToken, err
+	}
+	tx, err = linkToken.Contract.Mint(chain.DeployerKey, chain.DeployerKey.From, cfg.InitialSupply)
+	if _, err := deployment.ConfirmIfNoError(chain, tx, err); err != nil {
+		lggr.Errorw("Failed to mint initial link token supply", "err", err)
+		return linkToken, err
+	}
+	return linkToken, nil
+}
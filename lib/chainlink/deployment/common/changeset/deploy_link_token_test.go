@@ -1,8 +1,11 @@
 package changeset_test
 
 import (
+	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -10,6 +13,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/deployment/common/changeset"
 	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/shared/generated/burn_mint_erc677"
 )
 
 func TestDeployLinkToken(t *testing.T) {
@@ -37,3 +41,97 @@ func TestDeployLinkToken(t *testing.T) {
 	oaddrs, _ := resp.AddressBook.AddressesForChain(env.AllChainSelectors()[1])
 	assert.Len(t, oaddrs, 0)
 }
+
+func TestDeployLinkTokenMultiChain(t *testing.T) {
+	t.Parallel()
+
+	lggr := logger.Test(t)
+	cfg := memory.MemoryEnvironmentConfig{
+		Nodes:  1,
+		Chains: 3,
+	}
+	env := memory.NewMemoryEnvironment(t, lggr, zapcore.DebugLevel, cfg)
+	chainSelectors := env.AllChainSelectors()
+
+	resp, err := changeset.DeployLinkTokenMultiChain(env, chainSelectors)
+	require.NoError(t, err)
+	for _, chainSelector := range chainSelectors {
+		addrs, err := resp.AddressBook.AddressesForChain(chainSelector)
+		require.NoError(t, err)
+		require.Len(t, addrs, 1)
+	}
+
+	// unknown chains are reported but don't prevent deployment to the known ones
+	unknownChain := uint64(1)
+	resp, err = changeset.DeployLinkTokenMultiChain(env, []uint64{chainSelectors[0], unknownChain})
+	require.ErrorContains(t, err, "chain 1 not found in environment")
+	addrs, err := resp.AddressBook.AddressesForChain(chainSelectors[0])
+	require.NoError(t, err)
+	require.Len(t, addrs, 1)
+}
+
+func TestDeployConfigurableLinkToken(t *testing.T) {
+	t.Parallel()
+
+	lggr := logger.Test(t)
+	cfg := memory.MemoryEnvironmentConfig{
+		Nodes:  1,
+		Chains: 1,
+	}
+	env := memory.NewMemoryEnvironment(t, lggr, zapcore.DebugLevel, cfg)
+	chainSelector := env.AllChainSelectors()[0]
+	chain := env.Chains[chainSelector]
+	initialSupply := big.NewInt(0).Mul(big.NewInt(1000), big.NewInt(1e6))
+
+	resp, err := changeset.DeployConfigurableLinkToken(env, changeset.DeployLinkTokenConfig{
+		ChainSelector: chainSelector,
+		Name:          "Test Link Token",
+		Symbol:        "tLINK",
+		Decimals:      6,
+		MaxSupply:     big.NewInt(0),
+		InitialSupply: initialSupply,
+	})
+	require.NoError(t, err)
+
+	addrs, err := resp.AddressBook.AddressesForChain(chainSelector)
+	require.NoError(t, err)
+	require.Len(t, addrs, 1)
+	for addr := range addrs {
+		token, err := burn_mint_erc677.NewBurnMintERC677(common.HexToAddress(addr), chain.Client)
+		require.NoError(t, err)
+		decimals, err := token.Decimals(&bind.CallOpts{})
+		require.NoError(t, err)
+		assert.Equal(t, uint8(6), decimals)
+		symbol, err := token.Symbol(&bind.CallOpts{})
+		require.NoError(t, err)
+		assert.Equal(t, "tLINK", symbol)
+		balance, err := token.BalanceOf(&bind.CallOpts{}, chain.DeployerKey.From)
+		require.NoError(t, err)
+		assert.Equal(t, initialSupply, balance)
+	}
+}
+
+func TestDeployLinkTokenConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	valid := changeset.DeployLinkTokenConfig{
+		Name:          "Test Link Token",
+		Symbol:        "tLINK",
+		Decimals:      18,
+		MaxSupply:     big.NewInt(100),
+		InitialSupply: big.NewInt(10),
+	}
+	require.NoError(t, valid.Validate())
+
+	noSymbol := valid
+	noSymbol.Symbol = ""
+	require.Error(t, noSymbol.Validate())
+
+	noMaxSupply := valid
+	noMaxSupply.MaxSupply = nil
+	require.Error(t, noMaxSupply.Validate())
+
+	overMint := valid
+	overMint.InitialSupply = big.NewInt(101)
+	require.Error(t, overMint.Validate())
+}
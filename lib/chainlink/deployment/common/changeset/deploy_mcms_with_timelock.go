@@ -1,6 +1,8 @@
 package changeset
 
 import (
+	"fmt"
+
 	"github.com/smartcontractkit/chainlink/deployment"
 	"github.com/smartcontractkit/chainlink/deployment/common/changeset/internal"
 	"github.com/smartcontractkit/chainlink/deployment/common/types"
@@ -9,6 +11,11 @@ import (
 var _ deployment.ChangeSet[map[uint64]types.MCMSWithTimelockConfig] = DeployMCMSWithTimelock
 
 func DeployMCMSWithTimelock(e deployment.Environment, cfgByChain map[uint64]types.MCMSWithTimelockConfig) (deployment.ChangesetOutput, error) {
+	for chainSel, cfg := range cfgByChain {
+		if err := cfg.Validate(); err != nil {
+			return deployment.ChangesetOutput{}, fmt.Errorf("invalid MCMS with timelock config for chain %d: %w", chainSel, err)
+		}
+	}
 	newAddresses := deployment.NewMemoryAddressBook()
 	err := internal.DeployMCMSWithTimelockContractsBatch(
 		e.Logger, e.Chains, newAddresses, cfgByChain,
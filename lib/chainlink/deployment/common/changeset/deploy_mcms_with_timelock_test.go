@@ -0,0 +1,75 @@
+package changeset_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/smartcontractkit/chainlink/deployment/common/changeset"
+	"github.com/smartcontractkit/chainlink/deployment/common/types"
+	"github.com/smartcontractkit/chainlink/deployment/environment/memory"
+)
+
+func TestDeployMCMSWithTimelock_PerChainMinDelay(t *testing.T) {
+	t.Parallel()
+
+	lggr := logger.Test(t)
+	env := memory.NewMemoryEnvironment(t, lggr, zapcore.DebugLevel, memory.MemoryEnvironmentConfig{
+		Chains: 2,
+	})
+	chains := env.AllChainSelectors()
+	defaultCfg := types.MCMSWithTimelockConfig{
+		Canceller:         changeset.SingleGroupMCMS(t),
+		Bypasser:          changeset.SingleGroupMCMS(t),
+		Proposer:          changeset.SingleGroupMCMS(t),
+		TimelockExecutors: env.AllDeployerKeys(),
+		TimelockMinDelay:  big.NewInt(0),
+	}
+	cfgByChain, err := types.MCMSWithTimelockConfigPerChain(chains, defaultCfg, map[uint64]*big.Int{
+		chains[1]: big.NewInt(3600),
+	})
+	require.NoError(t, err)
+
+	out, err := changeset.DeployMCMSWithTimelock(env, cfgByChain)
+	require.NoError(t, err)
+
+	for _, chain := range chains {
+		addrs, err := out.AddressBook.AddressesForChain(chain)
+		require.NoError(t, err)
+		state, err := changeset.LoadMCMSWithTimelockState(env.Chains[chain], addrs)
+		require.NoError(t, err)
+		minDelay, err := state.Timelock.GetMinDelay(&bind.CallOpts{})
+		require.NoError(t, err)
+		require.Equal(t, cfgByChain[chain].TimelockMinDelay.Int64(), minDelay.Int64())
+	}
+
+	// a negative delay is rejected before anything is deployed
+	cfgByChain[chains[0]] = types.MCMSWithTimelockConfig{
+		Canceller:         changeset.SingleGroupMCMS(t),
+		Bypasser:          changeset.SingleGroupMCMS(t),
+		Proposer:          changeset.SingleGroupMCMS(t),
+		TimelockExecutors: []common.Address{env.Chains[chains[0]].DeployerKey.From},
+		TimelockMinDelay:  big.NewInt(-1),
+	}
+	out, err = changeset.DeployMCMSWithTimelock(env, cfgByChain)
+	require.ErrorContains(t, err, "timelockMinDelay must be non-negative")
+	require.Nil(t, out.AddressBook)
+
+	// as are duplicate executors
+	deployer := env.Chains[chains[0]].DeployerKey.From
+	cfgByChain[chains[0]] = types.MCMSWithTimelockConfig{
+		Canceller:         changeset.SingleGroupMCMS(t),
+		Bypasser:          changeset.SingleGroupMCMS(t),
+		Proposer:          changeset.SingleGroupMCMS(t),
+		TimelockExecutors: []common.Address{deployer, deployer},
+		TimelockMinDelay:  big.NewInt(0),
+	}
+	out, err = changeset.DeployMCMSWithTimelock(env, cfgByChain)
+	require.ErrorContains(t, err, "duplicate timelock executor "+deployer.Hex())
+	require.Nil(t, out.AddressBook)
+}
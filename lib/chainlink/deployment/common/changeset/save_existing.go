@@ -25,6 +25,7 @@ type ExistingContractsConfig struct {
 }
 
 func (cfg ExistingContractsConfig) Validate() error {
+	seen := make(map[uint64]map[common.Address]struct{})
 	for _, ec := range cfg.ExistingContracts {
 		if err := deployment.IsValidChainSelector(ec.ChainSelector); err != nil {
 			return fmt.Errorf("invalid chain selector: %d - %w", ec.ChainSelector, err)
@@ -38,6 +39,14 @@ func (cfg ExistingContractsConfig) Validate() error {
 		if val, err := ec.TypeAndVersion.Version.Value(); err != nil || val == "" {
 			return fmt.Errorf("version must be set")
 		}
+		// an address maps to a single type and version in the address book, so a duplicate would corrupt the state
+		if _, ok := seen[ec.ChainSelector][ec.Address]; ok {
+			return fmt.Errorf("duplicate address %s on chain %d", ec.Address.Hex(), ec.ChainSelector)
+		}
+		if seen[ec.ChainSelector] == nil {
+			seen[ec.ChainSelector] = make(map[common.Address]struct{})
+		}
+		seen[ec.ChainSelector][ec.Address] = struct{}{}
 	}
 	return nil
 }
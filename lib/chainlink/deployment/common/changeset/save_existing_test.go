@@ -52,3 +52,54 @@ func TestSaveExisting(t *testing.T) {
 	require.True(t, exists)
 	require.Len(t, addressForChain1, 1)
 }
+
+func TestExistingContractsConfig_Validate(t *testing.T) {
+	contract := func(addr int64, typ deployment.ContractType, chainSel uint64) Contract {
+		return Contract{
+			Address: common.BigToAddress(big.NewInt(addr)),
+			TypeAndVersion: deployment.TypeAndVersion{
+				Type:    typ,
+				Version: deployment.Version1_0_0,
+			},
+			ChainSelector: chainSel,
+		}
+	}
+	tests := []struct {
+		name      string
+		contracts []Contract
+		wantErr   string
+	}{
+		{
+			name: "valid",
+			contracts: []Contract{
+				contract(1, "dummy1", chainsel.TEST_90000001.Selector),
+				contract(2, "dummy2", chainsel.TEST_90000001.Selector),
+				// the same address on another chain is a different contract
+				contract(1, "dummy1", chainsel.TEST_90000002.Selector),
+			},
+		},
+		{
+			name: "duplicate address on a chain",
+			contracts: []Contract{
+				contract(1, "dummy1", chainsel.TEST_90000001.Selector),
+				contract(1, "dummy2", chainsel.TEST_90000001.Selector),
+			},
+			wantErr: "duplicate address",
+		},
+		{
+			name:      "invalid chain selector",
+			contracts: []Contract{contract(1, "dummy1", 1)},
+			wantErr:   "invalid chain selector",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ExistingContractsConfig{ExistingContracts: tc.contracts}.Validate()
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
@@ -2,16 +2,24 @@ package changeset
 
 import (
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+
 	"github.com/smartcontractkit/chainlink/deployment"
 )
 
+// OwnershipTransferrer is an EVM contract whose ownership can be transferred.
+// Only EVM chains are supported, configs referencing other chain families are rejected by Validate.
 type OwnershipTransferrer interface {
 	TransferOwnership(opts *bind.TransactOpts, newOwner common.Address) (*gethtypes.Transaction, error)
 	Owner(opts *bind.CallOpts) (common.Address, error)
+	Address() common.Address
 }
 
 type TransferOwnershipConfig struct {
@@ -20,11 +28,59 @@ type TransferOwnershipConfig struct {
 
 	// Contracts is a mapping from chain selector to the ownership transferrers on that chain.
 	Contracts map[uint64][]OwnershipTransferrer
+
+	// DryRun reports the ownership transfers that would be made without sending any transactions.
+	DryRun bool
+}
+
+// OwnershipTransfer describes the transfer of ownership of a single contract to a timelock.
+type OwnershipTransfer struct {
+	Contract     common.Address
+	CurrentOwner common.Address
+	NewOwner     common.Address
+}
+
+// TransferOwnershipReport is the report returned by TransferOwnership, and in the ChangesetOutput of
+// NewTransferOwnershipChangeset.
+// Per chain selector, it lists the ownership transfers made (or planned, for a dry run)
+// and the contracts skipped because the timelock already owns them.
+type TransferOwnershipReport struct {
+	DryRun       bool
+	Transfers    map[uint64][]OwnershipTransfer
+	AlreadyOwned map[uint64][]common.Address
+}
+
+var _ deployment.ChangesetReport = TransferOwnershipReport{}
+
+func (r TransferOwnershipReport) String() string {
+	var b strings.Builder
+	verb := "transferred"
+	if r.DryRun {
+		verb = "to transfer"
+	}
+	for _, chainSelector := range slices.Sorted(maps.Keys(r.Transfers)) {
+		for _, t := range r.Transfers[chainSelector] {
+			fmt.Fprintf(&b, "chain %d: %s %s from %s to %s\n", chainSelector, verb, t.Contract.Hex(), t.CurrentOwner.Hex(), t.NewOwner.Hex())
+		}
+	}
+	for _, chainSelector := range slices.Sorted(maps.Keys(r.AlreadyOwned)) {
+		for _, contract := range r.AlreadyOwned[chainSelector] {
+			fmt.Fprintf(&b, "chain %d: %s already owned by the timelock\n", chainSelector, contract.Hex())
+		}
+	}
+	return b.String()
 }
 
 func (t TransferOwnershipConfig) Validate() error {
 	// check that we have timelocks for the chains in the Contracts field.
 	for chainSelector := range t.Contracts {
+		family, err := chainsel.GetSelectorFamily(chainSelector)
+		if err != nil {
+			return fmt.Errorf("unknown chain selector %d: %w", chainSelector, err)
+		}
+		if family != chainsel.FamilyEVM {
+			return fmt.Errorf("ownership transfer is not supported for %s chain %d, only EVM chains are supported", family, chainSelector)
+		}
 		if _, ok := t.TimelocksPerChain[chainSelector]; !ok {
 			return fmt.Errorf("missing timelock for chain %d", chainSelector)
 		}
@@ -38,33 +94,62 @@ var _ deployment.ChangeSet[TransferOwnershipConfig] = NewTransferOwnershipChange
 // NewTransferOwnershipChangeset creates a changeset that transfers ownership of all the
 // contracts in the provided configuration to the the appropriate timelock on that chain.
 // If the owner is already the timelock contract, no transaction is sent.
+// The output Report is the TransferOwnershipReport of the transferred and skipped contracts, see TransferOwnership.
+// If cfg.DryRun is set, no transactions are sent at all and the report lists the planned transfers.
 func NewTransferOwnershipChangeset(
 	e deployment.Environment,
 	cfg TransferOwnershipConfig,
 ) (deployment.ChangesetOutput, error) {
-	if err := cfg.Validate(); err != nil {
+	report, err := TransferOwnership(e, cfg)
+	if err != nil {
 		return deployment.ChangesetOutput{}, err
 	}
+	// no new addresses or proposals or jobspecs, the onchain state change is captured in the report.
+	return deployment.ChangesetOutput{Report: report}, nil
+}
+
+// TransferOwnership transfers the ownership of the contracts as NewTransferOwnershipChangeset does, and returns the
+// report of the transferred and skipped contracts.
+func TransferOwnership(e deployment.Environment, cfg TransferOwnershipConfig) (TransferOwnershipReport, error) {
+	if err := cfg.Validate(); err != nil {
+		return TransferOwnershipReport{}, err
+	}
 
+	report := TransferOwnershipReport{
+		DryRun:       cfg.DryRun,
+		Transfers:    make(map[uint64][]OwnershipTransfer),
+		AlreadyOwned: make(map[uint64][]common.Address),
+	}
+	for chainSelector := range cfg.Contracts {
+		if _, ok := e.Chains[chainSelector]; !ok {
+			return TransferOwnershipReport{}, fmt.Errorf("chain %d not found in environment", chainSelector)
+		}
+	}
 	for chainSelector, contracts := range cfg.Contracts {
 		timelock := cfg.TimelocksPerChain[chainSelector]
 		for _, contract := range contracts {
 			owner, err := contract.Owner(nil)
 			if err != nil {
-				return deployment.ChangesetOutput{}, fmt.Errorf("failed to get owner of contract %T: %v", contract, err)
+				return TransferOwnershipReport{}, fmt.Errorf("failed to get owner of contract %T: %v", contract, err)
+			}
+			if owner == timelock {
+				report.AlreadyOwned[chainSelector] = append(report.AlreadyOwned[chainSelector], contract.Address())
+				continue
 			}
-			if owner != timelock {
+			if !cfg.DryRun {
 				tx, err := contract.TransferOwnership(e.Chains[chainSelector].DeployerKey, timelock)
 				_, err = deployment.ConfirmIfNoError(e.Chains[chainSelector], tx, err)
 				if err != nil {
-					return deployment.ChangesetOutput{}, fmt.Errorf("failed to transfer ownership of contract %T: %v", contract, err)
+					return TransferOwnershipReport{}, fmt.Errorf("failed to transfer ownership of contract %T: %v", contract, err)
 				}
 			}
+			report.Transfers[chainSelector] = append(report.Transfers[chainSelector], OwnershipTransfer{
+				Contract:     contract.Address(),
+				CurrentOwner: owner,
+				NewOwner:     timelock,
+			})
 		}
 	}
 
-	// no new addresses or proposals or jobspecs, so changeset output is empty.
-	// NOTE: onchain state has technically changed for above contracts, maybe that should
-	// be captured?
-	return deployment.ChangesetOutput{}, nil
+	return report, nil
 }
@@ -0,0 +1,161 @@
+package changeset_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	chainsel "github.com/smartcontractkit/chain-selectors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/deployment"
+	"github.com/smartcontractkit/chainlink/deployment/common/changeset"
+)
+
+// fakeOwnable is an OwnershipTransferrer that records transfers instead of sending transactions.
+type fakeOwnable struct {
+	address   common.Address
+	owner     common.Address
+	transfers int
+}
+
+func (f *fakeOwnable) TransferOwnership(_ *bind.TransactOpts, newOwner common.Address) (*gethtypes.Transaction, error) {
+	f.transfers++
+	f.owner = newOwner
+	return gethtypes.NewTx(&gethtypes.LegacyTx{}), nil
+}
+
+func (f *fakeOwnable) Owner(_ *bind.CallOpts) (common.Address, error) {
+	return f.owner, nil
+}
+
+func (f *fakeOwnable) Address() common.Address {
+	return f.address
+}
+
+func TestNewTransferOwnershipChangeset_DryRun(t *testing.T) {
+	chainSelector := chainsel.TEST_90000001.Selector
+	deployer := common.HexToAddress("0xde")
+	timelock := common.HexToAddress("0x71")
+	needsTransfer := &fakeOwnable{address: common.HexToAddress("0x1"), owner: deployer}
+	alreadyOwned := &fakeOwnable{address: common.HexToAddress("0x2"), owner: timelock}
+	env := deployment.Environment{
+		Chains: map[uint64]deployment.Chain{
+			chainSelector: {Selector: chainSelector},
+		},
+	}
+
+	report, err := changeset.TransferOwnership(env, changeset.TransferOwnershipConfig{
+		TimelocksPerChain: map[uint64]common.Address{chainSelector: timelock},
+		Contracts: map[uint64][]changeset.OwnershipTransferrer{
+			chainSelector: {needsTransfer, alreadyOwned},
+		},
+		DryRun: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 0, needsTransfer.transfers)
+	require.Equal(t, 0, alreadyOwned.transfers)
+	require.Equal(t, deployer, needsTransfer.owner)
+
+	require.True(t, report.DryRun)
+	require.Equal(t, map[uint64][]changeset.OwnershipTransfer{
+		chainSelector: {
+			{Contract: needsTransfer.address, CurrentOwner: deployer, NewOwner: timelock},
+		},
+	}, report.Transfers)
+	require.Equal(t, map[uint64][]common.Address{
+		chainSelector: {alreadyOwned.address},
+	}, report.AlreadyOwned)
+}
+
+func TestNewTransferOwnershipChangeset_Report(t *testing.T) {
+	chainSelector := chainsel.TEST_90000001.Selector
+	deployer := common.HexToAddress("0xde")
+	timelock := common.HexToAddress("0x71")
+	needsTransfer := &fakeOwnable{address: common.HexToAddress("0x1"), owner: deployer}
+	alreadyOwned := &fakeOwnable{address: common.HexToAddress("0x2"), owner: timelock}
+	env := deployment.Environment{
+		Chains: map[uint64]deployment.Chain{
+			chainSelector: {
+				Selector:    chainSelector,
+				DeployerKey: &bind.TransactOpts{From: deployer},
+				Confirm: func(tx *gethtypes.Transaction) (uint64, error) {
+					return 0, nil
+				},
+			},
+		},
+	}
+
+	out, err := changeset.NewTransferOwnershipChangeset(env, changeset.TransferOwnershipConfig{
+		TimelocksPerChain: map[uint64]common.Address{chainSelector: timelock},
+		Contracts: map[uint64][]changeset.OwnershipTransferrer{
+			chainSelector: {needsTransfer, alreadyOwned},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, needsTransfer.transfers)
+	require.Equal(t, timelock, needsTransfer.owner)
+	require.Equal(t, 0, alreadyOwned.transfers)
+
+	report, ok := out.Report.(changeset.TransferOwnershipReport)
+	require.True(t, ok)
+	require.False(t, report.DryRun)
+	require.Equal(t, map[uint64][]changeset.OwnershipTransfer{
+		chainSelector: {
+			{Contract: needsTransfer.address, CurrentOwner: deployer, NewOwner: timelock},
+		},
+	}, report.Transfers)
+	require.Equal(t, map[uint64][]common.Address{
+		chainSelector: {alreadyOwned.address},
+	}, report.AlreadyOwned)
+	require.Equal(t, fmt.Sprintf("chain %d: transferred %s from %s to %s\nchain %d: %s already owned by the timelock\n",
+		chainSelector, needsTransfer.address.Hex(), deployer.Hex(), timelock.Hex(), chainSelector, alreadyOwned.address.Hex()),
+		out.Report.String())
+}
+
+func TestNewTransferOwnershipChangeset_NonEVM(t *testing.T) {
+	evmSelector := chainsel.TEST_90000001.Selector
+	aptosSelector := chainsel.APTOS_TESTNET.Selector
+	deployer := common.HexToAddress("0xde")
+	timelock := common.HexToAddress("0x71")
+	evmContract := &fakeOwnable{address: common.HexToAddress("0x1"), owner: deployer}
+	aptosContract := &fakeOwnable{address: common.HexToAddress("0x2"), owner: deployer}
+	env := deployment.Environment{
+		Chains: map[uint64]deployment.Chain{
+			evmSelector: {
+				Selector:    evmSelector,
+				DeployerKey: &bind.TransactOpts{From: deployer},
+				Confirm: func(tx *gethtypes.Transaction) (uint64, error) {
+					return 0, nil
+				},
+			},
+		},
+	}
+
+	_, err := changeset.NewTransferOwnershipChangeset(env, changeset.TransferOwnershipConfig{
+		TimelocksPerChain: map[uint64]common.Address{
+			evmSelector:   timelock,
+			aptosSelector: timelock,
+		},
+		Contracts: map[uint64][]changeset.OwnershipTransferrer{
+			evmSelector:   {evmContract},
+			aptosSelector: {aptosContract},
+		},
+	})
+	require.ErrorContains(t, err, "ownership transfer is not supported for aptos chain")
+	// the config is rejected up front, so nothing is transferred on the EVM chain either
+	require.Equal(t, 0, evmContract.transfers)
+	require.Equal(t, 0, aptosContract.transfers)
+
+	// an EVM chain missing from the environment is an error rather than a nil deployer key
+	_, err = changeset.NewTransferOwnershipChangeset(deployment.Environment{}, changeset.TransferOwnershipConfig{
+		TimelocksPerChain: map[uint64]common.Address{evmSelector: timelock},
+		Contracts: map[uint64][]changeset.OwnershipTransferrer{
+			evmSelector: {evmContract},
+		},
+	})
+	require.ErrorContains(t, err, "not found in environment")
+	require.Equal(t, 0, evmContract.transfers)
+}
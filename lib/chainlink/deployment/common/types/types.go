@@ -1,8 +1,11 @@
 package types
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
+	"slices"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -27,7 +30,95 @@ type MCMSWithTimelockConfig struct {
 	TimelockMinDelay  *big.Int
 }
 
+// Validate checks that the config can be used to deploy an MCMS with timelock.
+func (c MCMSWithTimelockConfig) Validate() error {
+	if c.TimelockMinDelay == nil {
+		return errors.New("timelockMinDelay must be set")
+	}
+	if c.TimelockMinDelay.Sign() < 0 {
+		return fmt.Errorf("timelockMinDelay must be non-negative, got %s", c.TimelockMinDelay)
+	}
+	if len(c.TimelockExecutors) == 0 {
+		return errors.New("timelockExecutors must not be empty")
+	}
+	seen := make(map[common.Address]struct{}, len(c.TimelockExecutors))
+	for i, executor := range c.TimelockExecutors {
+		if executor == (common.Address{}) {
+			return fmt.Errorf("timelockExecutors[%d] must not be the zero address", i)
+		}
+		if _, ok := seen[executor]; ok {
+			return fmt.Errorf("duplicate timelock executor %s", executor)
+		}
+		seen[executor] = struct{}{}
+	}
+	return nil
+}
+
+// Clone returns a deep copy of the config, so that it can be customized per chain without aliasing the
+// executors, the signer sets or the min delay of the original.
+func (c MCMSWithTimelockConfig) Clone() MCMSWithTimelockConfig {
+	clone := MCMSWithTimelockConfig{
+		Canceller:         cloneMCMSConfig(c.Canceller),
+		Bypasser:          cloneMCMSConfig(c.Bypasser),
+		Proposer:          cloneMCMSConfig(c.Proposer),
+		TimelockExecutors: slices.Clone(c.TimelockExecutors),
+	}
+	if c.TimelockMinDelay != nil {
+		clone.TimelockMinDelay = new(big.Int).Set(c.TimelockMinDelay)
+	}
+	return clone
+}
+
+func cloneMCMSConfig(c config.Config) config.Config {
+	clone := config.Config{
+		Quorum:  c.Quorum,
+		Signers: slices.Clone(c.Signers),
+	}
+	if c.GroupSigners != nil {
+		clone.GroupSigners = make([]config.Config, len(c.GroupSigners))
+		for i, group := range c.GroupSigners {
+			clone.GroupSigners[i] = cloneMCMSConfig(group)
+		}
+	}
+	return clone
+}
+
+// MCMSWithTimelockConfigPerChain builds the per-chain config map expected by DeployMCMSWithTimelock from a
+// shared default, overriding TimelockMinDelay for every chain present in minDelayByChain.
+func MCMSWithTimelockConfigPerChain(
+	chains []uint64,
+	defaultCfg MCMSWithTimelockConfig,
+	minDelayByChain map[uint64]*big.Int,
+) (map[uint64]MCMSWithTimelockConfig, error) {
+	cfgByChain := make(map[uint64]MCMSWithTimelockConfig, len(chains))
+	for _, chain := range chains {
+		// each chain gets its own copy of the default, so customizing one does not change the others
+		cfg := defaultCfg.Clone()
+		if override := minDelayByChain[chain]; override != nil {
+			cfg.TimelockMinDelay = new(big.Int).Set(override)
+		}
+		cfgByChain[chain] = cfg
+	}
+	for chain := range minDelayByChain {
+		if _, ok := cfgByChain[chain]; !ok {
+			return nil, fmt.Errorf("timelockMinDelay override given for chain %d which is not in the chain list", chain)
+		}
+	}
+	return cfgByChain, nil
+}
+
+const (
+	// MaxOCRDuration is the upper bound accepted for any single OCR duration parameter.
+	MaxOCRDuration = 24 * time.Hour
+	// MaxOCRRmax is the upper bound accepted for the maximum number of rounds per epoch.
+	MaxOCRRmax = 255
+	// OCRParametersSchemaVersion is the current version of the JSON serialization of OCRParameters.
+	OCRParametersSchemaVersion = 1
+)
+
 type OCRParameters struct {
+	// SchemaVersion is the version of the JSON serialization, zero is treated as the current version.
+	SchemaVersion                           uint32
 	DeltaProgress                           time.Duration
 	DeltaResend                             time.Duration
 	DeltaInitial                            time.Duration
@@ -42,6 +133,93 @@ type OCRParameters struct {
 	MaxDurationShouldTransmitAcceptedReport time.Duration
 }
 
+// OCRParametersOption overrides a single field of OCRParameters.
+type OCRParametersOption func(*OCRParameters)
+
+// DefaultOCRParameters returns a baseline set of OCR parameters that passes Validate, with the given options applied.
+// The values match those used for the CCIP DONs: a 30s progress timeout, 2s rounds and up to 3 rounds per epoch.
+func DefaultOCRParameters(opts ...OCRParametersOption) OCRParameters {
+	params := OCRParameters{
+		SchemaVersion:                           OCRParametersSchemaVersion,
+		DeltaProgress:                           30 * time.Second,
+		DeltaResend:                             10 * time.Second,
+		DeltaInitial:                            20 * time.Second,
+		DeltaRound:                              2 * time.Second,
+		DeltaGrace:                              2 * time.Second,
+		DeltaCertifiedCommitRequest:             10 * time.Second,
+		DeltaStage:                              10 * time.Second,
+		Rmax:                                    3,
+		MaxDurationQuery:                        500 * time.Millisecond,
+		MaxDurationObservation:                  5 * time.Second,
+		MaxDurationShouldAcceptAttestedReport:   10 * time.Second,
+		MaxDurationShouldTransmitAcceptedReport: 10 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(&params)
+	}
+	return params
+}
+
+// WithDeltaProgress sets the duration after which a new leader is elected if no progress is made.
+func WithDeltaProgress(d time.Duration) OCRParametersOption {
+	return func(p *OCRParameters) { p.DeltaProgress = d }
+}
+
+// WithDeltaResend sets the interval at which nodes resend their NEWEPOCH messages.
+func WithDeltaResend(d time.Duration) OCRParametersOption {
+	return func(p *OCRParameters) { p.DeltaResend = d }
+}
+
+// WithDeltaInitial sets how long a new leader waits for the initial observations of an epoch.
+func WithDeltaInitial(d time.Duration) OCRParametersOption {
+	return func(p *OCRParameters) { p.DeltaInitial = d }
+}
+
+// WithDeltaRound sets the minimum duration of a round.
+func WithDeltaRound(d time.Duration) OCRParametersOption {
+	return func(p *OCRParameters) { p.DeltaRound = d }
+}
+
+// WithDeltaGrace sets how long the leader waits for late observations after the quorum is reached.
+func WithDeltaGrace(d time.Duration) OCRParametersOption {
+	return func(p *OCRParameters) { p.DeltaGrace = d }
+}
+
+// WithDeltaCertifiedCommitRequest sets the interval at which the certified commit is requested from the leader.
+func WithDeltaCertifiedCommitRequest(d time.Duration) OCRParametersOption {
+	return func(p *OCRParameters) { p.DeltaCertifiedCommitRequest = d }
+}
+
+// WithDeltaStage sets the duration between stages of the transmission protocol.
+func WithDeltaStage(d time.Duration) OCRParametersOption {
+	return func(p *OCRParameters) { p.DeltaStage = d }
+}
+
+// WithRmax sets the maximum number of rounds in an epoch.
+func WithRmax(rmax uint64) OCRParametersOption {
+	return func(p *OCRParameters) { p.Rmax = rmax }
+}
+
+// WithMaxDurationQuery sets the timeout of the Query plugin call.
+func WithMaxDurationQuery(d time.Duration) OCRParametersOption {
+	return func(p *OCRParameters) { p.MaxDurationQuery = d }
+}
+
+// WithMaxDurationObservation sets the timeout of the Observation plugin call.
+func WithMaxDurationObservation(d time.Duration) OCRParametersOption {
+	return func(p *OCRParameters) { p.MaxDurationObservation = d }
+}
+
+// WithMaxDurationShouldAcceptAttestedReport sets the timeout of the ShouldAcceptAttestedReport plugin call.
+func WithMaxDurationShouldAcceptAttestedReport(d time.Duration) OCRParametersOption {
+	return func(p *OCRParameters) { p.MaxDurationShouldAcceptAttestedReport = d }
+}
+
+// WithMaxDurationShouldTransmitAcceptedReport sets the timeout of the ShouldTransmitAcceptedReport plugin call.
+func WithMaxDurationShouldTransmitAcceptedReport(d time.Duration) OCRParametersOption {
+	return func(p *OCRParameters) { p.MaxDurationShouldTransmitAcceptedReport = d }
+}
+
 func (params OCRParameters) Validate() error {
 	if params.DeltaProgress <= 0 {
 		return fmt.Errorf("deltaProgress must be positive")
@@ -79,5 +257,143 @@ func (params OCRParameters) Validate() error {
 	if params.MaxDurationShouldTransmitAcceptedReport <= 0 {
 		return fmt.Errorf("maxDurationShouldTransmitAcceptedReport must be positive")
 	}
+
+	durations := []struct {
+		name  string
+		value time.Duration
+	}{
+		{"deltaProgress", params.DeltaProgress},
+		{"deltaResend", params.DeltaResend},
+		{"deltaInitial", params.DeltaInitial},
+		{"deltaRound", params.DeltaRound},
+		{"deltaGrace", params.DeltaGrace},
+		{"deltaCertifiedCommitRequest", params.DeltaCertifiedCommitRequest},
+		{"deltaStage", params.DeltaStage},
+		{"maxDurationQuery", params.MaxDurationQuery},
+		{"maxDurationObservation", params.MaxDurationObservation},
+		{"maxDurationShouldAcceptAttestedReport", params.MaxDurationShouldAcceptAttestedReport},
+		{"maxDurationShouldTransmitAcceptedReport", params.MaxDurationShouldTransmitAcceptedReport},
+	}
+	for _, d := range durations {
+		if d.value > MaxOCRDuration {
+			return fmt.Errorf("%s (%v) must not exceed %v", d.name, d.value, MaxOCRDuration)
+		}
+	}
+	if params.Rmax > MaxOCRRmax {
+		return fmt.Errorf("rmax (%d) must not exceed %d", params.Rmax, MaxOCRRmax)
+	}
+
+	// libocr rejects configs where a round or its grace period cannot complete before the progress timeout
+	if params.DeltaRound >= params.DeltaProgress {
+		return fmt.Errorf("deltaRound (%v) must be less than deltaProgress (%v)", params.DeltaRound, params.DeltaProgress)
+	}
+	if params.DeltaGrace >= params.DeltaProgress {
+		return fmt.Errorf("deltaGrace (%v) must be less than deltaProgress (%v)", params.DeltaGrace, params.DeltaProgress)
+	}
+	// deltaGrace may equal deltaRound, rather than having to be strictly less than it, as the CCIP DONs are deployed
+	// with 2s for both, see DefaultOCRParameters
+	if params.DeltaGrace > params.DeltaRound {
+		return fmt.Errorf("deltaGrace (%v) must not exceed deltaRound (%v)", params.DeltaGrace, params.DeltaRound)
+	}
+	if params.MaxDurationQuery+params.MaxDurationObservation >= params.DeltaProgress {
+		return fmt.Errorf("maxDurationQuery (%v) plus maxDurationObservation (%v) must be less than deltaProgress (%v)",
+			params.MaxDurationQuery, params.MaxDurationObservation, params.DeltaProgress)
+	}
+	return nil
+}
+
+// ocrDuration serializes a time.Duration as a human-readable string such as "2s".
+// Integers are accepted as nanoseconds when unmarshalling, which is how durations were serialized before.
+type ocrDuration time.Duration
+
+func (d ocrDuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *ocrDuration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		parsed, err := time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q: %w", s, err)
+		}
+		*d = ocrDuration(parsed)
+		return nil
+	}
+	var ns int64
+	if err := json.Unmarshal(b, &ns); err != nil {
+		return fmt.Errorf("duration must be a string such as \"2s\" or an integer number of nanoseconds, got %s", b)
+	}
+	*d = ocrDuration(ns)
+	return nil
+}
+
+type ocrParametersJSON struct {
+	SchemaVersion                           uint32
+	DeltaProgress                           ocrDuration
+	DeltaResend                             ocrDuration
+	DeltaInitial                            ocrDuration
+	DeltaRound                              ocrDuration
+	DeltaGrace                              ocrDuration
+	DeltaCertifiedCommitRequest             ocrDuration
+	DeltaStage                              ocrDuration
+	Rmax                                    uint64
+	MaxDurationQuery                        ocrDuration
+	MaxDurationObservation                  ocrDuration
+	MaxDurationShouldAcceptAttestedReport   ocrDuration
+	MaxDurationShouldTransmitAcceptedReport ocrDuration
+}
+
+// MarshalJSON serializes the durations as human-readable strings such as "2s", under the current schema version.
+func (params OCRParameters) MarshalJSON() ([]byte, error) {
+	version := params.SchemaVersion
+	if version == 0 {
+		version = OCRParametersSchemaVersion
+	}
+	return json.Marshal(ocrParametersJSON{
+		SchemaVersion:                           version,
+		DeltaProgress:                           ocrDuration(params.DeltaProgress),
+		DeltaResend:                             ocrDuration(params.DeltaResend),
+		DeltaInitial:                            ocrDuration(params.DeltaInitial),
+		DeltaRound:                              ocrDuration(params.DeltaRound),
+		DeltaGrace:                              ocrDuration(params.DeltaGrace),
+		DeltaCertifiedCommitRequest:             ocrDuration(params.DeltaCertifiedCommitRequest),
+		DeltaStage:                              ocrDuration(params.DeltaStage),
+		Rmax:                                    params.Rmax,
+		MaxDurationQuery:                        ocrDuration(params.MaxDurationQuery),
+		MaxDurationObservation:                  ocrDuration(params.MaxDurationObservation),
+		MaxDurationShouldAcceptAttestedReport:   ocrDuration(params.MaxDurationShouldAcceptAttestedReport),
+		MaxDurationShouldTransmitAcceptedReport: ocrDuration(params.MaxDurationShouldTransmitAcceptedReport),
+	})
+}
+
+// UnmarshalJSON accepts durations as strings such as "2s" or as nanoseconds. A missing schema version is read as
+// the current one, and versions newer than the current one are rejected.
+func (params *OCRParameters) UnmarshalJSON(b []byte) error {
+	var raw ocrParametersJSON
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return fmt.Errorf("failed to unmarshal OCRParameters: %w", err)
+	}
+	if raw.SchemaVersion > OCRParametersSchemaVersion {
+		return fmt.Errorf("unsupported OCRParameters schema version %d, latest supported is %d", raw.SchemaVersion, OCRParametersSchemaVersion)
+	}
+	if raw.SchemaVersion == 0 {
+		raw.SchemaVersion = OCRParametersSchemaVersion
+	}
+	*params = OCRParameters{
+		SchemaVersion:                           raw.SchemaVersion,
+		DeltaProgress:                           time.Duration(raw.DeltaProgress),
+		DeltaResend:                             time.Duration(raw.DeltaResend),
+		DeltaInitial:                            time.Duration(raw.DeltaInitial),
+		DeltaRound:                              time.Duration(raw.DeltaRound),
+		DeltaGrace:                              time.Duration(raw.DeltaGrace),
+		DeltaCertifiedCommitRequest:             time.Duration(raw.DeltaCertifiedCommitRequest),
+		DeltaStage:                              time.Duration(raw.DeltaStage),
+		Rmax:                                    raw.Rmax,
+		MaxDurationQuery:                        time.Duration(raw.MaxDurationQuery),
+		MaxDurationObservation:                  time.Duration(raw.MaxDurationObservation),
+		MaxDurationShouldAcceptAttestedReport:   time.Duration(raw.MaxDurationShouldAcceptAttestedReport),
+		MaxDurationShouldTransmitAcceptedReport: time.Duration(raw.MaxDurationShouldTransmitAcceptedReport),
+	}
 	return nil
 }
@@ -0,0 +1,301 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/smartcontractkit/ccip-owner-contracts/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOCRParameters_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(p *OCRParameters)
+		errStr string
+	}{
+		{
+			name:   "valid",
+			modify: func(p *OCRParameters) {},
+		},
+		{
+			name:   "non-positive duration",
+			modify: func(p *OCRParameters) { p.DeltaResend = 0 },
+			errStr: "deltaResend must be positive",
+		},
+		{
+			name:   "non-positive rmax",
+			modify: func(p *OCRParameters) { p.Rmax = 0 },
+			errStr: "rmax must be positive",
+		},
+		{
+			name:   "duration exceeds upper bound",
+			modify: func(p *OCRParameters) { p.MaxDurationShouldAcceptAttestedReport = MaxOCRDuration + time.Second },
+			errStr: "maxDurationShouldAcceptAttestedReport (24h0m1s) must not exceed 24h0m0s",
+		},
+		{
+			name:   "rmax exceeds upper bound",
+			modify: func(p *OCRParameters) { p.Rmax = MaxOCRRmax + 1 },
+			errStr: "rmax (256) must not exceed 255",
+		},
+		{
+			name:   "deltaRound not less than deltaProgress",
+			modify: func(p *OCRParameters) { p.DeltaRound = p.DeltaProgress },
+			errStr: "deltaRound (30s) must be less than deltaProgress (30s)",
+		},
+		{
+			name: "deltaGrace not less than deltaProgress",
+			modify: func(p *OCRParameters) {
+				p.DeltaProgress = 40 * time.Second
+				p.DeltaGrace = 40 * time.Second
+				p.DeltaRound = 39 * time.Second
+			},
+			errStr: "deltaGrace (40s) must be less than deltaProgress (40s)",
+		},
+		{
+			name:   "deltaGrace equal to deltaRound",
+			modify: func(p *OCRParameters) { p.DeltaGrace = p.DeltaRound },
+		},
+		{
+			name:   "deltaGrace exceeds deltaRound",
+			modify: func(p *OCRParameters) { p.DeltaGrace = p.DeltaRound + time.Second },
+			errStr: "deltaGrace (3s) must not exceed deltaRound (2s)",
+		},
+		{
+			name:   "query and observation exceed deltaProgress",
+			modify: func(p *OCRParameters) { p.MaxDurationObservation = 30 * time.Second },
+			errStr: "maxDurationQuery (500ms) plus maxDurationObservation (30s) must be less than deltaProgress (30s)",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			params := DefaultOCRParameters()
+			tc.modify(&params)
+			err := params.Validate()
+			if tc.errStr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.errStr)
+		})
+	}
+}
+
+func TestDefaultOCRParameters(t *testing.T) {
+	t.Run("defaults validate", func(t *testing.T) {
+		require.NoError(t, DefaultOCRParameters().Validate())
+	})
+
+	t.Run("options override", func(t *testing.T) {
+		defaults := DefaultOCRParameters()
+		params := DefaultOCRParameters(
+			WithDeltaRound(5*time.Second),
+			WithDeltaGrace(4*time.Second),
+			WithRmax(10),
+		)
+		require.NoError(t, params.Validate())
+		require.Equal(t, 5*time.Second, params.DeltaRound)
+		require.Equal(t, 4*time.Second, params.DeltaGrace)
+		require.Equal(t, uint64(10), params.Rmax)
+
+		// untouched fields keep their defaults
+		params.DeltaRound = defaults.DeltaRound
+		params.DeltaGrace = defaults.DeltaGrace
+		params.Rmax = defaults.Rmax
+		require.Equal(t, defaults, params)
+	})
+
+	t.Run("options applied in order", func(t *testing.T) {
+		params := DefaultOCRParameters(WithDeltaStage(time.Second), WithDeltaStage(3*time.Second))
+		require.Equal(t, 3*time.Second, params.DeltaStage)
+	})
+}
+
+func TestOCRParameters_JSON(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		params := DefaultOCRParameters(WithDeltaRound(2500*time.Millisecond), WithRmax(5))
+		b, err := json.Marshal(params)
+		require.NoError(t, err)
+
+		var fields map[string]any
+		require.NoError(t, json.Unmarshal(b, &fields))
+		require.Equal(t, "30s", fields["DeltaProgress"])
+		require.Equal(t, "2.5s", fields["DeltaRound"])
+		require.Equal(t, "500ms", fields["MaxDurationQuery"])
+		require.InDelta(t, 5, fields["Rmax"], 0)
+		require.InDelta(t, OCRParametersSchemaVersion, fields["SchemaVersion"], 0)
+
+		var decoded OCRParameters
+		require.NoError(t, json.Unmarshal(b, &decoded))
+		require.Equal(t, params, decoded)
+		require.NoError(t, decoded.Validate())
+	})
+
+	t.Run("zero schema version is written as the current one", func(t *testing.T) {
+		params := DefaultOCRParameters()
+		params.SchemaVersion = 0
+		b, err := json.Marshal(params)
+		require.NoError(t, err)
+		var decoded OCRParameters
+		require.NoError(t, json.Unmarshal(b, &decoded))
+		require.Equal(t, DefaultOCRParameters(), decoded)
+	})
+
+	t.Run("nanosecond durations without schema version", func(t *testing.T) {
+		var decoded OCRParameters
+		require.NoError(t, json.Unmarshal([]byte(`{"DeltaProgress":30000000000,"DeltaRound":"2s","Rmax":3}`), &decoded))
+		require.Equal(t, uint32(OCRParametersSchemaVersion), decoded.SchemaVersion)
+		require.Equal(t, 30*time.Second, decoded.DeltaProgress)
+		require.Equal(t, 2*time.Second, decoded.DeltaRound)
+		require.Equal(t, uint64(3), decoded.Rmax)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var decoded OCRParameters
+		require.ErrorContains(t, json.Unmarshal([]byte(`{"DeltaRound":"2 seconds"}`), &decoded), `invalid duration "2 seconds"`)
+		require.ErrorContains(t, json.Unmarshal([]byte(`{"DeltaRound":true}`), &decoded), "duration must be a string")
+		require.ErrorContains(t, json.Unmarshal([]byte(`{"SchemaVersion":2}`), &decoded), "unsupported OCRParameters schema version 2")
+	})
+}
+
+func TestMCMSWithTimelockConfig_Validate(t *testing.T) {
+	executorA := common.HexToAddress("0x1")
+	executorB := common.HexToAddress("0x2")
+	tests := []struct {
+		name      string
+		minDelay  *big.Int
+		executors []common.Address
+		errStr    string
+	}{
+		{
+			name:      "zero delay",
+			minDelay:  big.NewInt(0),
+			executors: []common.Address{executorA},
+		},
+		{
+			name:      "positive delay with several executors",
+			minDelay:  big.NewInt(3600),
+			executors: []common.Address{executorA, executorB},
+		},
+		{
+			name:      "nil delay",
+			executors: []common.Address{executorA},
+			errStr:    "timelockMinDelay must be set",
+		},
+		{
+			name:      "negative delay",
+			minDelay:  big.NewInt(-1),
+			executors: []common.Address{executorA},
+			errStr:    "timelockMinDelay must be non-negative, got -1",
+		},
+		{
+			name:     "no executors",
+			minDelay: big.NewInt(0),
+			errStr:   "timelockExecutors must not be empty",
+		},
+		{
+			name:      "zero address executor",
+			minDelay:  big.NewInt(0),
+			executors: []common.Address{executorA, {}},
+			errStr:    "timelockExecutors[1] must not be the zero address",
+		},
+		{
+			name:      "duplicate executors",
+			minDelay:  big.NewInt(0),
+			executors: []common.Address{executorA, executorB, executorA},
+			errStr:    "duplicate timelock executor " + executorA.Hex(),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := MCMSWithTimelockConfig{TimelockMinDelay: tc.minDelay, TimelockExecutors: tc.executors}.Validate()
+			if tc.errStr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.errStr)
+		})
+	}
+}
+
+func TestMCMSWithTimelockConfig_Clone(t *testing.T) {
+	signer := common.HexToAddress("0x1")
+	executor := common.HexToAddress("0x2")
+	orig := MCMSWithTimelockConfig{
+		Canceller: config.Config{
+			Quorum:       1,
+			Signers:      []common.Address{signer},
+			GroupSigners: []config.Config{{Quorum: 1, Signers: []common.Address{signer}}},
+		},
+		Proposer:          config.Config{Quorum: 1, Signers: []common.Address{signer}},
+		TimelockExecutors: []common.Address{executor},
+		TimelockMinDelay:  big.NewInt(60),
+	}
+
+	clone := orig.Clone()
+	require.Equal(t, orig, clone)
+
+	clone.TimelockExecutors[0] = common.HexToAddress("0x3")
+	clone.TimelockExecutors = append(clone.TimelockExecutors, common.HexToAddress("0x4"))
+	clone.TimelockMinDelay.SetInt64(3600)
+	clone.Canceller.Signers[0] = common.HexToAddress("0x5")
+	clone.Canceller.GroupSigners[0].Signers[0] = common.HexToAddress("0x6")
+	clone.Proposer.Quorum = 2
+
+	require.Equal(t, []common.Address{executor}, orig.TimelockExecutors)
+	require.Equal(t, int64(60), orig.TimelockMinDelay.Int64())
+	require.Equal(t, []common.Address{signer}, orig.Canceller.Signers)
+	require.Equal(t, []common.Address{signer}, orig.Canceller.GroupSigners[0].Signers)
+	require.Equal(t, uint8(1), orig.Proposer.Quorum)
+
+	// unset fields stay unset
+	require.Equal(t, MCMSWithTimelockConfig{}, MCMSWithTimelockConfig{}.Clone())
+}
+
+func TestMCMSWithTimelockConfigPerChain(t *testing.T) {
+	defaultCfg := MCMSWithTimelockConfig{TimelockMinDelay: big.NewInt(0)}
+
+	t.Run("mixed delays", func(t *testing.T) {
+		cfgByChain, err := MCMSWithTimelockConfigPerChain([]uint64{1, 2, 3}, defaultCfg, map[uint64]*big.Int{
+			2: big.NewInt(3600),
+			3: big.NewInt(86400),
+		})
+		require.NoError(t, err)
+		require.Len(t, cfgByChain, 3)
+		require.Equal(t, int64(0), cfgByChain[1].TimelockMinDelay.Int64())
+		require.Equal(t, int64(3600), cfgByChain[2].TimelockMinDelay.Int64())
+		require.Equal(t, int64(86400), cfgByChain[3].TimelockMinDelay.Int64())
+
+		// each chain gets its own copy of the delay
+		cfgByChain[1].TimelockMinDelay.SetInt64(10)
+		require.Equal(t, int64(0), defaultCfg.TimelockMinDelay.Int64())
+	})
+
+	t.Run("configs do not share slices", func(t *testing.T) {
+		signer, executor := common.HexToAddress("0x1"), common.HexToAddress("0x2")
+		shared := MCMSWithTimelockConfig{
+			Proposer:          config.Config{Quorum: 1, Signers: []common.Address{signer}},
+			TimelockExecutors: []common.Address{executor},
+			TimelockMinDelay:  big.NewInt(0),
+		}
+		cfgByChain, err := MCMSWithTimelockConfigPerChain([]uint64{1, 2}, shared, nil)
+		require.NoError(t, err)
+
+		cfgByChain[1].TimelockExecutors[0] = common.HexToAddress("0x3")
+		cfgByChain[1].Proposer.Signers[0] = common.HexToAddress("0x4")
+		require.Equal(t, []common.Address{executor}, shared.TimelockExecutors)
+		require.Equal(t, []common.Address{executor}, cfgByChain[2].TimelockExecutors)
+		require.Equal(t, []common.Address{signer}, shared.Proposer.Signers)
+		require.Equal(t, []common.Address{signer}, cfgByChain[2].Proposer.Signers)
+	})
+
+	t.Run("override for unknown chain", func(t *testing.T) {
+		_, err := MCMSWithTimelockConfigPerChain([]uint64{1}, defaultCfg, map[uint64]*big.Int{
+			2: big.NewInt(3600),
+		})
+		require.EqualError(t, err, "timelockMinDelay override given for chain 2 which is not in the chain list")
+	})
+}
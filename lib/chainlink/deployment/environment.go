@@ -9,6 +9,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -54,6 +55,9 @@ type Chain struct {
 	// Note the Sign function can be abstract supporting a variety of key storage mechanisms (e.g. KMS etc).
 	DeployerKey *bind.TransactOpts
 	Confirm     func(tx *types.Transaction) (uint64, error)
+	// ErrorABIs are the ABIs whose custom errors ConfirmIfNoError decodes into revert reasons, on top of
+	// Error(string) and Panic(uint256) which are always decoded.
+	ErrorABIs []abi.ABI
 }
 
 // Environment represents an instance of a deployed product
@@ -139,16 +143,34 @@ func (e Environment) AllDeployerKeys() []common.Address {
 }
 
 func ConfirmIfNoError(chain Chain, tx *types.Transaction, err error) (uint64, error) {
+	return ConfirmIfNoErrorCtx(context.Background(), chain, tx, err)
+}
+
+// ConfirmIfNoErrorCtx is like ConfirmIfNoError, but replays a reverted transaction to find its revert reason
+// with the given ctx.
+func ConfirmIfNoErrorCtx(ctx context.Context, chain Chain, tx *types.Transaction, err error) (uint64, error) {
 	if err != nil {
 		//revive:disable
 		var d rpc.DataError
 		ok := errors.As(err, &d)
 		if ok {
+			if errData, isString := d.ErrorData().(string); isString {
+				return 0, fmt.Errorf("transaction reverted: Error %s ErrorData %v Reason %s", d.Error(), d.ErrorData(), describeErrorData(errData, chain.ErrorABIs))
+			}
 			return 0, fmt.Errorf("transaction reverted: Error %s ErrorData %v", d.Error(), d.ErrorData())
 		}
 		return 0, err
 	}
-	return chain.Confirm(tx)
+	block, err := chain.Confirm(tx)
+	if err != nil {
+		// Confirm implementations typically only surface the raw revert data (if any),
+		// so replay the tx to give the caller a decoded reason.
+		if reason := revertReason(ctx, chain, tx); reason != "" {
+			return block, fmt.Errorf("%w: revert reason: %s", err, reason)
+		}
+		return block, err
+	}
+	return block, nil
 }
 
 func MaybeDataErr(err error) error {
@@ -48,6 +48,10 @@ func SimTransactOpts() *bind.TransactOpts {
 }
 
 func GetErrorReasonFromTx(client bind.ContractBackend, from common.Address, tx *types.Transaction, receipt *types.Receipt) (string, error) {
+	return getErrorReasonFromTx(context.Background(), client, from, tx, receipt)
+}
+
+func getErrorReasonFromTx(ctx context.Context, client bind.ContractBackend, from common.Address, tx *types.Transaction, receipt *types.Receipt) (string, error) {
 	call := ethereum.CallMsg{
 		From:     from,
 		To:       tx.To(),
@@ -56,7 +60,7 @@ func GetErrorReasonFromTx(client bind.ContractBackend, from common.Address, tx *
 		Gas:      tx.Gas(),
 		GasPrice: tx.GasPrice(),
 	}
-	_, err := client.CallContract(context.Background(), call, receipt.BlockNumber)
+	_, err := client.CallContract(ctx, call, receipt.BlockNumber)
 	if err != nil {
 		errorReason, err := parseError(err)
 		if err == nil {
@@ -112,6 +116,72 @@ func ParseErrorFromABI(errorString string, contractABI string) (string, error) {
 	return "", errors.New("error not found in ABI")
 }
 
+// DecodeErrorReason decodes hex encoded revert data into a human readable reason.
+// Error(string) and Panic(uint256) reverts are always understood, custom errors
+// are matched by selector against the given ABIs.
+func DecodeErrorReason(errorData string, errorABIs ...abi.ABI) (string, error) {
+	errorData = strings.TrimPrefix(errorData, "Reverted ")
+	data, err := hex.DecodeString(strings.TrimPrefix(errorData, "0x"))
+	if err != nil {
+		return "", errors.Wrap(err, "error decoding error string")
+	}
+	if len(data) < 4 {
+		return "", errors.Errorf("revert data %q is too short to contain an error selector", errorData)
+	}
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return reason, nil
+	}
+
+	for _, a := range errorABIs {
+		for name, abiError := range a.Errors {
+			if !bytes.Equal(data[:4], abiError.ID.Bytes()[:4]) {
+				continue
+			}
+			v, err := abiError.Unpack(data)
+			if err != nil {
+				return "", errors.Wrapf(err, "error unpacking data for %s", name)
+			}
+			return fmt.Sprintf("%s%v", name, v), nil
+		}
+	}
+	return "", errors.Errorf("error selector 0x%x not found in the given ABIs", data[:4])
+}
+
+// revertReason replays a mined but reverted transaction at the block it was
+// included in and decodes the resulting revert data.
+// It returns an empty string if the transaction did not revert or no reason could be found.
+func revertReason(ctx context.Context, chain Chain, tx *types.Transaction) string {
+	if tx == nil || chain.Client == nil {
+		return ""
+	}
+	receipt, err := chain.Client.TransactionReceipt(ctx, tx.Hash())
+	if err != nil || receipt.Status != types.ReceiptStatusFailed {
+		return ""
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		if chain.DeployerKey == nil {
+			return ""
+		}
+		from = chain.DeployerKey.From
+	}
+	errData, err := getErrorReasonFromTx(ctx, chain.Client, from, tx, receipt)
+	if err != nil || errData == "" {
+		return ""
+	}
+	return describeErrorData(errData, chain.ErrorABIs)
+}
+
+// describeErrorData returns the decoded reason for the given revert data,
+// falling back to the raw data when it can't be decoded.
+func describeErrorData(errData string, errorABIs []abi.ABI) string {
+	reason, err := DecodeErrorReason(errData, errorABIs...)
+	if err != nil {
+		return errData
+	}
+	return reason
+}
+
 // ContractDeploy represents the result of an EVM contract deployment
 // via an abigen Go binding. It contains all the return values
 // as they are useful in different ways.
@@ -0,0 +1,141 @@
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
+
+	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/maybe_revert_message_receiver"
+)
+
+func TestDecodeErrorReason(t *testing.T) {
+	receiverABI, err := maybe_revert_message_receiver.MaybeRevertMessageReceiverMetaData.GetAbi()
+	require.NoError(t, err)
+	customErr := receiverABI.Errors["CustomError"]
+	customErrArgs, err := customErr.Inputs.Pack([]byte("boom"))
+	require.NoError(t, err)
+
+	stringTy, err := abi.NewType("string", "", nil)
+	require.NoError(t, err)
+	revertArgs, err := abi.Arguments{{Type: stringTy}}.Pack("not allowed")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		data    string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "Error(string)",
+			data: hexutil.Encode(append(crypto.Keccak256([]byte("Error(string)"))[:4], revertArgs...)),
+			want: "not allowed",
+		},
+		{
+			name: "registered custom error",
+			data: hexutil.Encode(append(customErr.ID.Bytes()[:4], customErrArgs...)),
+			want: fmt.Sprintf("CustomError%v", []interface{}{[]byte("boom")}),
+		},
+		{
+			name:    "unknown selector",
+			data:    "0xdeadbeef",
+			wantErr: "error selector 0xdeadbeef not found in the given ABIs",
+		},
+		{
+			name:    "too short",
+			data:    "0xdead",
+			wantErr: "too short",
+		},
+		{
+			name:    "not hex",
+			data:    "0xzz",
+			wantErr: "error decoding error string",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeErrorReason(tt.data, *receiverABI)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConfirmIfNoError_RevertReason(t *testing.T) {
+	receiverABI, err := maybe_revert_message_receiver.MaybeRevertMessageReceiverMetaData.GetAbi()
+	require.NoError(t, err)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	deployer, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	require.NoError(t, err)
+	backend := simulated.NewBackend(types.GenesisAlloc{
+		deployer.From: {Balance: new(big.Int).Mul(big.NewInt(100), big.NewInt(params.Ether))}})
+	chain := Chain{
+		Client:      backend.Client(),
+		DeployerKey: deployer,
+		ErrorABIs:   []abi.ABI{*receiverABI},
+		Confirm: func(tx *types.Transaction) (uint64, error) {
+			backend.Commit()
+			receipt, err := backend.Client().TransactionReceipt(context.Background(), tx.Hash())
+			if err != nil {
+				return 0, err
+			}
+			if receipt.Status == types.ReceiptStatusFailed {
+				return 0, fmt.Errorf("tx %s reverted", tx.Hash().Hex())
+			}
+			return receipt.BlockNumber.Uint64(), nil
+		},
+	}
+
+	_, tx, receiver, err := maybe_revert_message_receiver.DeployMaybeRevertMessageReceiver(deployer, backend.Client(), true)
+	_, err = ConfirmIfNoError(chain, tx, err)
+	require.NoError(t, err)
+	tx, err = receiver.SetErr(deployer, []byte("boom"))
+	_, err = ConfirmIfNoError(chain, tx, err)
+	require.NoError(t, err)
+	wantReason := fmt.Sprintf("CustomError%v", []interface{}{[]byte("boom")})
+
+	t.Run("reverted on send", func(t *testing.T) {
+		// Gas estimation replays the call and fails before the tx is sent.
+		tx, err := receiver.CcipReceive(deployer, maybe_revert_message_receiver.ClientAny2EVMMessage{})
+		_, err = ConfirmIfNoError(chain, tx, err)
+		require.ErrorContains(t, err, "Reason "+wantReason)
+	})
+
+	t.Run("reverted onchain", func(t *testing.T) {
+		opts := *deployer
+		opts.GasLimit = 1_000_000
+		tx, err := receiver.CcipReceive(&opts, maybe_revert_message_receiver.ClientAny2EVMMessage{})
+		require.NoError(t, err)
+		_, err = ConfirmIfNoErrorCtx(testcontext.Get(t), chain, tx, err)
+		require.ErrorContains(t, err, fmt.Sprintf("tx %s reverted", tx.Hash().Hex()))
+		require.ErrorContains(t, err, "revert reason: "+wantReason)
+	})
+
+	t.Run("custom errors of other ABIs are not decoded", func(t *testing.T) {
+		chain := chain
+		chain.ErrorABIs = nil
+		tx, err := receiver.CcipReceive(deployer, maybe_revert_message_receiver.ClientAny2EVMMessage{})
+		_, err = ConfirmIfNoError(chain, tx, err)
+		require.Error(t, err)
+		require.NotContains(t, err.Error(), "Reason "+wantReason)
+	})
+}
@@ -907,7 +907,10 @@ func configureForwarder(lggr logger.Logger, chain deployment.Chain, fwdr *kf.Key
 			continue
 		}
 		ver := dn.Info.ConfigCount // note config count on the don info is the version on the forwarder
-		signers := dn.signers(chainsel.FamilyEVM)
+		signers, err := dn.signers(chainsel.FamilyEVM)
+		if err != nil {
+			return fmt.Errorf("failed to get signers for don %s: %w", dn.Name, err)
+		}
 		tx, err := fwdr.SetConfig(chain.DeployerKey, dn.Info.Id, ver, dn.Info.F, signers)
 		if err != nil {
 			err = DecodeErr(kf.KeystoneForwarderABI, err)
@@ -248,7 +248,10 @@ type configureOCR3Request struct {
 }
 
 func (r configureOCR3Request) generateOCR3Config() (OCR2OracleConfig, error) {
-	nks := makeNodeKeysSlice(r.nodes, r.chain.Selector)
+	nks, err := makeNodeKeysSlice(r.nodes, r.chain.Selector)
+	if err != nil {
+		return OCR2OracleConfig{}, err
+	}
 	return GenerateOCR3Config(*r.cfg, nks)
 }
 
@@ -14,6 +14,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/deployment"
 
+	capcommon "github.com/smartcontractkit/chainlink/v2/core/capabilities/ccip/common"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/keystone/generated/capabilities_registry"
 	kcr "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/keystone/generated/capabilities_registry"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/p2pkey"
@@ -51,7 +52,7 @@ type Nop struct {
 	NodeIDs []string // nodes run by this operator
 }
 
-func toNodeKeys(o *deployment.Node, registryChainSel uint64) NodeKeys {
+func toNodeKeys(o *deployment.Node, registryChainSel uint64) (NodeKeys, error) {
 	var aptosOcr2KeyBundleId string
 	var aptosOnchainPublicKey string
 	var aptosCC *deployment.OCRConfig
@@ -67,13 +68,16 @@ func toNodeKeys(o *deployment.Node, registryChainSel uint64) NodeKeys {
 	}
 	registryChainID, err := chainsel.ChainIdFromSelector(registryChainSel)
 	if err != nil {
-		panic(err)
+		return NodeKeys{}, fmt.Errorf("failed to get chain id for registry chain selector %d: %w", registryChainSel, err)
 	}
 	registryChainDetails, err := chainsel.GetChainDetailsByChainIDAndFamily(strconv.Itoa(int(registryChainID)), chainsel.FamilyEVM)
 	if err != nil {
-		panic(err)
+		return NodeKeys{}, fmt.Errorf("failed to get chain details for registry chain id %d: %w", registryChainID, err)
+	}
+	evmCC, exists := o.SelToOCRConfig[registryChainDetails]
+	if !exists {
+		return NodeKeys{}, fmt.Errorf("no ocr config found for registry chain %d on node %s", registryChainSel, o.NodeID)
 	}
-	evmCC := o.SelToOCRConfig[registryChainDetails]
 	return NodeKeys{
 		EthAddress:            string(evmCC.TransmitAccount),
 		P2PPeerID:             strings.TrimPrefix(o.PeerID.String(), "p2p_"),
@@ -89,14 +93,19 @@ func toNodeKeys(o *deployment.Node, registryChainSel uint64) NodeKeys {
 		// TODO: AptosAccount is unset but probably unused
 		AptosBundleID:         aptosOcr2KeyBundleId,
 		AptosOnchainPublicKey: aptosOnchainPublicKey,
-	}
+	}, nil
 }
-func makeNodeKeysSlice(nodes []deployment.Node, registryChainSel uint64) []NodeKeys {
+
+func makeNodeKeysSlice(nodes []deployment.Node, registryChainSel uint64) ([]NodeKeys, error) {
 	var out []NodeKeys
 	for _, n := range nodes {
-		out = append(out, toNodeKeys(&n, registryChainSel))
+		keys, err := toNodeKeys(&n, registryChainSel)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get node keys for node %s: %w", n.NodeID, err)
+		}
+		out = append(out, keys)
 	}
-	return out
+	return out, nil
 }
 
 type NOP struct {
@@ -141,12 +150,35 @@ func (v DonCapabilities) Validate() error {
 			return fmt.Errorf("failed to validate nop %d '%s': %w", i, n.Name, err)
 		}
 	}
+	if err := v.validateUniqueNodes(); err != nil {
+		return err
+	}
 	if len(v.Capabilities) == 0 {
 		return errors.New("no capabilities")
 	}
 	return nil
 }
 
+// validateUniqueNodes ensures that no peer ID is listed more than once across the nops of the don
+func (v DonCapabilities) validateUniqueNodes() error {
+	var peerIDs []string
+	nopsByPeerID := make(map[string][]string)
+	for _, nop := range v.Nops {
+		for _, peerID := range nop.Nodes {
+			if _, exists := nopsByPeerID[peerID]; !exists {
+				peerIDs = append(peerIDs, peerID)
+			}
+			nopsByPeerID[peerID] = append(nopsByPeerID[peerID], nop.Name)
+		}
+	}
+	for _, peerID := range peerIDs {
+		if nops := nopsByPeerID[peerID]; len(nops) > 1 {
+			return fmt.Errorf("duplicate node with p2p_id '%s' in don '%s' across nops %v", peerID, v.Name, nops)
+		}
+	}
+	return nil
+}
+
 func NodeOperator(name string, adminAddress string) capabilities_registry.CapabilitiesRegistryNodeOperator {
 	return capabilities_registry.CapabilitiesRegistryNodeOperator{
 		Name:  name,
@@ -200,12 +232,16 @@ func mapDonsToCaps(dons []DonInfo) map[string][]kcr.CapabilitiesRegistryCapabili
 
 // mapDonsToNodes returns a map of don name to simplified representation of their nodes
 // all nodes must have evm config and ocr3 capability nodes are must also have an aptos chain config
-func mapDonsToNodes(dons []DonInfo, excludeBootstraps bool, registryChainSel uint64) (map[string][]deployment.Node, error) {
+// if onlyCapabilities is non-empty, only the nodes of dons hosting at least one of those capabilities are included
+func mapDonsToNodes(dons []DonInfo, excludeBootstraps bool, registryChainSel uint64, onlyCapabilities ...kcr.CapabilitiesRegistryCapability) (map[string][]deployment.Node, error) {
 	donToNodes := make(map[string][]deployment.Node)
 	// get the nodes for each don from the offchain client, get ocr2 config from one of the chain configs for the node b/c
 	// they are equivalent
 
 	for _, don := range dons {
+		if len(onlyCapabilities) > 0 && !hostsAnyCapability(don, onlyCapabilities) {
+			continue
+		}
 		for _, node := range don.Nodes {
 			if excludeBootstraps && node.IsBootstrap {
 				continue
@@ -220,6 +256,18 @@ func mapDonsToNodes(dons []DonInfo, excludeBootstraps bool, registryChainSel uin
 	return donToNodes, nil
 }
 
+// hostsAnyCapability returns true if the don hosts at least one of the given capabilities, matched by labelled name and version
+func hostsAnyCapability(don DonInfo, caps []kcr.CapabilitiesRegistryCapability) bool {
+	for _, want := range caps {
+		if slices.ContainsFunc(don.Capabilities, func(c kcr.CapabilitiesRegistryCapability) bool {
+			return c.LabelledName == want.LabelledName && c.Version == want.Version
+		}) {
+			return true
+		}
+	}
+	return false
+}
+
 // RegisteredDon is a representation of a don that exists in the in the capabilities registry all with the enriched node data
 type RegisteredDon struct {
 	Name  string
@@ -227,37 +275,58 @@ type RegisteredDon struct {
 	Nodes []deployment.Node
 }
 
-func (d RegisteredDon) signers(chainFamily string) []common.Address {
+// signerKeys returns the onchain signer of each non-bootstrap node in the DON for the given chain family, ordered by peer ID.
+// The representation depends on the family: EVM signers are 20 byte addresses while Aptos signers are the full
+// onchain public key, which is not an address.
+func (d RegisteredDon) signerKeys(chainFamily string) ([][]byte, error) {
 	sort.Slice(d.Nodes, func(i, j int) bool {
 		return d.Nodes[i].PeerID.String() < d.Nodes[j].PeerID.String()
 	})
-	var out []common.Address
+	var out [][]byte
 	for _, n := range d.Nodes {
 		if n.IsBootstrap {
 			continue
 		}
 		var found bool
 		var registryChainDetails chainsel.ChainDetails
-		for details, _ := range n.SelToOCRConfig {
+		for details := range n.SelToOCRConfig {
 			if family, err := chainsel.GetSelectorFamily(details.ChainSelector); err == nil && family == chainFamily {
 				found = true
 				registryChainDetails = details
-
 			}
 		}
 		if !found {
-			panic(fmt.Sprintf("chainType not found: %v", chainFamily))
+			return nil, fmt.Errorf("no %s ocr config found for node %s in don %s", chainFamily, n.PeerID.String(), d.Name)
 		}
-		// eth address is the first 20 bytes of the Signer
-		config, exists := n.SelToOCRConfig[registryChainDetails]
-		if !exists {
-			panic(fmt.Sprintf("chainID not found: %v", registryChainDetails))
+		signer := n.SelToOCRConfig[registryChainDetails].OnchainPublicKey
+		switch chainFamily {
+		case chainsel.FamilyEVM:
+			// eth address is the first 20 bytes of the Signer
+			out = append(out, common.BytesToAddress(signer).Bytes())
+		case chainsel.FamilyAptos:
+			out = append(out, slices.Clone(signer))
+		default:
+			return nil, fmt.Errorf("unsupported chain family %s for signers of don %s", chainFamily, d.Name)
 		}
-		signer := config.OnchainPublicKey
-		signerAddress := common.BytesToAddress(signer)
-		out = append(out, signerAddress)
 	}
-	return out
+	return out, nil
+}
+
+// signers returns the EVM signer addresses of the non-bootstrap nodes in the DON, ordered by peer ID.
+// Only the EVM family has address signers, use signerKeys for other families.
+func (d RegisteredDon) signers(chainFamily string) ([]common.Address, error) {
+	if chainFamily != chainsel.FamilyEVM {
+		return nil, fmt.Errorf("signers of %s chains are not addresses", chainFamily)
+	}
+	keys, err := d.signerKeys(chainFamily)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]common.Address, len(keys))
+	for i, k := range keys {
+		out[i] = common.BytesToAddress(k)
+	}
+	return out, nil
 }
 
 func joinInfoAndNodes(donInfos map[string]kcr.CapabilitiesRegistryDONInfo, dons []DonInfo, registryChainSel uint64) ([]RegisteredDon, error) {
@@ -286,6 +355,84 @@ func joinInfoAndNodes(donInfos map[string]kcr.CapabilitiesRegistryDONInfo, dons
 	return out, nil
 }
 
+// DonDiff describes the changes needed to move a registered DON to its desired configuration
+type DonDiff struct {
+	Name string
+	// CapabilitiesToAdd are the desired capabilities that are not yet configured on the DON
+	CapabilitiesToAdd []kcr.CapabilitiesRegistryCapability
+	// CapabilitiesToRemove are the hashed ids of the capabilities configured on the DON that are no longer desired
+	CapabilitiesToRemove [][32]byte
+	// NodesToAdd are the peer ids of the desired nodes that are not yet members of the DON
+	NodesToAdd []string
+	// NodesToRemove are the peer ids of the DON members that are no longer desired
+	NodesToRemove []string
+}
+
+// IsEmpty returns true if the DON is already in the desired state
+func (d DonDiff) IsEmpty() bool {
+	return len(d.CapabilitiesToAdd) == 0 && len(d.CapabilitiesToRemove) == 0 &&
+		len(d.NodesToAdd) == 0 && len(d.NodesToRemove) == 0
+}
+
+// DiffDons compares the desired DON configuration with the currently registered DONs, as returned by joinInfoAndNodes,
+// and returns one diff per desired DON in the same order. A desired DON that is not registered yet
+// results in a diff that adds all of its capabilities and nodes. Registered DONs that are not in desired are ignored.
+func DiffDons(desired []DonCapabilities, current []RegisteredDon) ([]DonDiff, error) {
+	registered := make(map[string]RegisteredDon)
+	for _, don := range current {
+		registered[don.Name] = don
+	}
+
+	var out []DonDiff
+	for _, want := range desired {
+		have := registered[want.Name]
+		diff := DonDiff{Name: want.Name}
+
+		wantCapIDs := make(map[[32]byte]struct{})
+		haveCapIDs := make(map[[32]byte]struct{})
+		for _, cfg := range have.Info.CapabilityConfigurations {
+			haveCapIDs[cfg.CapabilityId] = struct{}{}
+		}
+		for _, cap := range want.Capabilities {
+			id, err := capcommon.HashedCapabilityID(cap.LabelledName, cap.Version)
+			if err != nil {
+				return nil, fmt.Errorf("failed to hash capability %s@%s for don %s: %w", cap.LabelledName, cap.Version, want.Name, err)
+			}
+			wantCapIDs[id] = struct{}{}
+			if _, ok := haveCapIDs[id]; !ok {
+				diff.CapabilitiesToAdd = append(diff.CapabilitiesToAdd, cap)
+			}
+		}
+		for _, cfg := range have.Info.CapabilityConfigurations {
+			if _, ok := wantCapIDs[cfg.CapabilityId]; !ok {
+				diff.CapabilitiesToRemove = append(diff.CapabilitiesToRemove, cfg.CapabilityId)
+			}
+		}
+
+		wantNodes := make(map[string]struct{})
+		haveNodes := make(map[string]struct{})
+		for _, n := range have.Nodes {
+			haveNodes[n.PeerID.String()] = struct{}{}
+		}
+		for _, nop := range want.Nops {
+			for _, peerID := range nop.Nodes {
+				wantNodes[peerID] = struct{}{}
+				if _, ok := haveNodes[peerID]; !ok {
+					diff.NodesToAdd = append(diff.NodesToAdd, peerID)
+				}
+			}
+		}
+		for _, n := range have.Nodes {
+			if _, ok := wantNodes[n.PeerID.String()]; !ok {
+				diff.NodesToRemove = append(diff.NodesToRemove, n.PeerID.String())
+			}
+		}
+
+		out = append(out, diff)
+	}
+	return out, nil
+}
+
 var emptyAddr = "0x0000000000000000000000000000000000000000"
 
 // compute the admin address from the string. If the address is empty, replaces the 0s with fs
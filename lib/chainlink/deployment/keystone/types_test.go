@@ -11,6 +11,8 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	chainsel "github.com/smartcontractkit/chain-selectors"
 	"github.com/smartcontractkit/chainlink/deployment"
+	capcommon "github.com/smartcontractkit/chainlink/v2/core/capabilities/ccip/common"
+	kcr "github.com/smartcontractkit/chainlink/v2/core/gethwrappers/keystone/generated/capabilities_registry"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/p2pkey"
 	"github.com/smartcontractkit/libocr/offchainreporting2plus/types"
 	"github.com/stretchr/testify/require"
@@ -40,7 +42,7 @@ func Test_toNodeKeys(t *testing.T) {
 	if _, err := hex.Decode(encryptionpubkey[:], []byte(pubKey_1)); err != nil {
 		panic(fmt.Sprintf("failed to decode pubkey %s: %v", encryptionpubkey, err))
 	}
-	keys := toNodeKeys(&deployment.Node{
+	keys, err := toNodeKeys(&deployment.Node{
 		NodeID:    "p2p_123",
 		Name:      "node 1",
 		PeerID:    p2pID.PeerID(),
@@ -62,6 +64,7 @@ func Test_toNodeKeys(t *testing.T) {
 			},
 		},
 	}, registryChainSel.Selector)
+	require.NoError(t, err)
 
 	require.Equal(t, NodeKeys{
 		EthAddress:            admin_1.String(),
@@ -76,3 +79,271 @@ func Test_toNodeKeys(t *testing.T) {
 		EncryptionPublicKey:   pubKey_1,
 	}, keys)
 }
+
+func Test_toNodeKeys_Errors(t *testing.T) {
+	p2pID := p2pkey.MustNewV2XXXTestingOnly(big.NewInt(100))
+	aptosChainDetails, err := chainsel.GetChainDetailsByChainIDAndFamily(strconv.Itoa(int(1)), chainsel.FamilyAptos)
+	require.NoError(t, err)
+	node := deployment.Node{
+		NodeID: "node_1",
+		PeerID: p2pID.PeerID(),
+		SelToOCRConfig: map[chainsel.ChainDetails]deployment.OCRConfig{
+			aptosChainDetails: {KeyBundleID: "aptos"},
+		},
+	}
+
+	t.Run("unknown registry chain selector", func(t *testing.T) {
+		_, err := toNodeKeys(&node, 1)
+		require.ErrorContains(t, err, "failed to get chain id for registry chain selector 1")
+	})
+
+	t.Run("missing registry chain ocr config", func(t *testing.T) {
+		_, err := toNodeKeys(&node, chainsel.TEST_90000001.Selector)
+		require.ErrorContains(t, err, "no ocr config found for registry chain")
+	})
+
+	t.Run("propagated by makeNodeKeysSlice", func(t *testing.T) {
+		_, err := makeNodeKeysSlice([]deployment.Node{node}, chainsel.TEST_90000001.Selector)
+		require.ErrorContains(t, err, "failed to get node keys for node node_1")
+	})
+}
+
+func TestDonCapabilities_Validate(t *testing.T) {
+	peerID := func(seed int64) string {
+		return p2pkey.MustNewV2XXXTestingOnly(big.NewInt(seed)).PeerID().String()
+	}
+	caps := []kcr.CapabilitiesRegistryCapability{
+		{LabelledName: "ocr3", Version: "1.0.0", CapabilityType: 2},
+	}
+
+	t.Run("unique nodes", func(t *testing.T) {
+		don := DonCapabilities{
+			Name: "don",
+			Nops: []NOP{
+				{Name: "nop_1", Nodes: []string{peerID(1), peerID(2)}},
+				{Name: "nop_2", Nodes: []string{peerID(3)}},
+			},
+			Capabilities: caps,
+		}
+		require.NoError(t, don.Validate())
+	})
+
+	t.Run("node duplicated across nops", func(t *testing.T) {
+		dup := peerID(2)
+		don := DonCapabilities{
+			Name: "don",
+			Nops: []NOP{
+				{Name: "nop_1", Nodes: []string{peerID(1), dup}},
+				{Name: "nop_2", Nodes: []string{dup}},
+			},
+			Capabilities: caps,
+		}
+		err := don.Validate()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), dup)
+		require.Contains(t, err.Error(), "[nop_1 nop_2]")
+	})
+}
+
+func TestDiffDons(t *testing.T) {
+	p2pIDs := make([]p2pkey.PeerID, 3)
+	for i := range p2pIDs {
+		p2pIDs[i] = p2pkey.MustNewV2XXXTestingOnly(big.NewInt(int64(i + 1))).PeerID()
+	}
+	ocr3Cap := kcr.CapabilitiesRegistryCapability{LabelledName: "ocr3", Version: "1.0.0", CapabilityType: 2}
+	writeCap := kcr.CapabilitiesRegistryCapability{LabelledName: "write_chain", Version: "1.0.0", CapabilityType: 3}
+	ocr3CapID, err := capcommon.HashedCapabilityID(ocr3Cap.LabelledName, ocr3Cap.Version)
+	require.NoError(t, err)
+
+	registered := []RegisteredDon{
+		{
+			Name: "don",
+			Info: kcr.CapabilitiesRegistryDONInfo{
+				CapabilityConfigurations: []kcr.CapabilitiesRegistryCapabilityConfiguration{
+					{CapabilityId: ocr3CapID},
+				},
+			},
+			Nodes: []deployment.Node{{PeerID: p2pIDs[0]}, {PeerID: p2pIDs[1]}, {PeerID: p2pIDs[2]}},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		desired DonCapabilities
+		want    DonDiff
+	}{
+		{
+			name: "no change",
+			desired: DonCapabilities{
+				Name: "don",
+				Nops: []NOP{
+					{Name: "nop_1", Nodes: []string{p2pIDs[0].String(), p2pIDs[1].String()}},
+					{Name: "nop_2", Nodes: []string{p2pIDs[2].String()}},
+				},
+				Capabilities: []kcr.CapabilitiesRegistryCapability{ocr3Cap},
+			},
+			want: DonDiff{Name: "don"},
+		},
+		{
+			name: "added capability",
+			desired: DonCapabilities{
+				Name: "don",
+				Nops: []NOP{
+					{Name: "nop_1", Nodes: []string{p2pIDs[0].String(), p2pIDs[1].String(), p2pIDs[2].String()}},
+				},
+				Capabilities: []kcr.CapabilitiesRegistryCapability{ocr3Cap, writeCap},
+			},
+			want: DonDiff{
+				Name:              "don",
+				CapabilitiesToAdd: []kcr.CapabilitiesRegistryCapability{writeCap},
+			},
+		},
+		{
+			name: "removed node",
+			desired: DonCapabilities{
+				Name: "don",
+				Nops: []NOP{
+					{Name: "nop_1", Nodes: []string{p2pIDs[0].String(), p2pIDs[1].String()}},
+				},
+				Capabilities: []kcr.CapabilitiesRegistryCapability{ocr3Cap},
+			},
+			want: DonDiff{
+				Name:          "don",
+				NodesToRemove: []string{p2pIDs[2].String()},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := DiffDons([]DonCapabilities{tc.desired}, registered)
+			require.NoError(t, err)
+			require.Len(t, got, 1)
+			require.Equal(t, tc.want, got[0])
+			require.Equal(t, tc.name == "no change", got[0].IsEmpty())
+		})
+	}
+}
+
+func Test_mapDonsToNodes(t *testing.T) {
+	p2pIDs := make([]p2pkey.PeerID, 4)
+	for i := range p2pIDs {
+		p2pIDs[i] = p2pkey.MustNewV2XXXTestingOnly(big.NewInt(int64(i + 1))).PeerID()
+	}
+	ocr3Cap := kcr.CapabilitiesRegistryCapability{LabelledName: "ocr3", Version: "1.0.0", CapabilityType: 2}
+	writeCap := kcr.CapabilitiesRegistryCapability{LabelledName: "write_chain", Version: "1.0.0", CapabilityType: 3}
+	dons := []DonInfo{
+		{
+			Name:         "wf",
+			Nodes:        []deployment.Node{{PeerID: p2pIDs[0], IsBootstrap: true}, {PeerID: p2pIDs[1]}},
+			Capabilities: []kcr.CapabilitiesRegistryCapability{ocr3Cap},
+		},
+		{
+			Name:         "writer",
+			Nodes:        []deployment.Node{{PeerID: p2pIDs[2]}, {PeerID: p2pIDs[3]}},
+			Capabilities: []kcr.CapabilitiesRegistryCapability{writeCap},
+		},
+	}
+
+	t.Run("no filter", func(t *testing.T) {
+		got, err := mapDonsToNodes(dons, false, 0)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		require.Len(t, got["wf"], 2)
+		require.Len(t, got["writer"], 2)
+	})
+
+	t.Run("capability filter", func(t *testing.T) {
+		got, err := mapDonsToNodes(dons, false, 0, ocr3Cap)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.Equal(t, []deployment.Node{{PeerID: p2pIDs[0], IsBootstrap: true}, {PeerID: p2pIDs[1]}}, got["wf"])
+	})
+
+	t.Run("capability filter excludes bootstraps", func(t *testing.T) {
+		got, err := mapDonsToNodes(dons, true, 0, ocr3Cap)
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.Equal(t, []deployment.Node{{PeerID: p2pIDs[1]}}, got["wf"])
+	})
+
+	t.Run("no matching capability", func(t *testing.T) {
+		got, err := mapDonsToNodes(dons, true, 0, kcr.CapabilitiesRegistryCapability{LabelledName: "ocr3", Version: "2.0.0"})
+		require.NoError(t, err)
+		require.Empty(t, got)
+	})
+}
+
+func Test_RegisteredDon_signers(t *testing.T) {
+	evmChainDetails, err := chainsel.GetChainDetailsByChainIDAndFamily(strconv.Itoa(int(chainsel.TEST_90000001.EvmChainID)), chainsel.FamilyEVM)
+	require.NoError(t, err)
+	aptosChainDetails, err := chainsel.GetChainDetailsByChainIDAndFamily(strconv.Itoa(int(1)), chainsel.FamilyAptos)
+	require.NoError(t, err)
+
+	evmSigner1 := common.Hex2Bytes("11117293a4Cc2621b61193135a95928735e4795f")
+	evmSigner2 := common.Hex2Bytes("22227293a4Cc2621b61193135a95928735e4795f")
+	aptosSigner1 := common.Hex2Bytes("1111111111111111111111111111111111111111111111111111111111111111")
+	aptosSigner2 := common.Hex2Bytes("2222222222222222222222222222222222222222222222222222222222222222")
+	p2pID1 := p2pkey.MustNewV2XXXTestingOnly(big.NewInt(1)).PeerID()
+	p2pID2 := p2pkey.MustNewV2XXXTestingOnly(big.NewInt(2)).PeerID()
+	// keep the expected order independent of how the peer IDs happen to sort
+	if p2pID2.String() < p2pID1.String() {
+		evmSigner1, evmSigner2 = evmSigner2, evmSigner1
+		aptosSigner1, aptosSigner2 = aptosSigner2, aptosSigner1
+	}
+
+	don := RegisteredDon{
+		Name: "don",
+		Nodes: []deployment.Node{
+			{
+				PeerID: p2pID2,
+				SelToOCRConfig: map[chainsel.ChainDetails]deployment.OCRConfig{
+					evmChainDetails:   {OnchainPublicKey: evmSigner2},
+					aptosChainDetails: {OnchainPublicKey: aptosSigner2},
+				},
+			},
+			{
+				PeerID:      p2pkey.MustNewV2XXXTestingOnly(big.NewInt(3)).PeerID(),
+				IsBootstrap: true,
+			},
+			{
+				PeerID: p2pID1,
+				SelToOCRConfig: map[chainsel.ChainDetails]deployment.OCRConfig{
+					evmChainDetails:   {OnchainPublicKey: evmSigner1},
+					aptosChainDetails: {OnchainPublicKey: aptosSigner1},
+				},
+			},
+		},
+	}
+
+	t.Run("evm", func(t *testing.T) {
+		signers, err := don.signers(chainsel.FamilyEVM)
+		require.NoError(t, err)
+		require.Equal(t, []common.Address{common.BytesToAddress(evmSigner1), common.BytesToAddress(evmSigner2)}, signers)
+	})
+
+	t.Run("aptos", func(t *testing.T) {
+		keys, err := don.signerKeys(chainsel.FamilyAptos)
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{aptosSigner1, aptosSigner2}, keys)
+
+		_, err = don.signers(chainsel.FamilyAptos)
+		require.Error(t, err)
+	})
+
+	t.Run("missing config", func(t *testing.T) {
+		evmOnly := RegisteredDon{
+			Name: "evm-only",
+			Nodes: []deployment.Node{
+				{
+					PeerID: p2pID1,
+					SelToOCRConfig: map[chainsel.ChainDetails]deployment.OCRConfig{
+						evmChainDetails: {OnchainPublicKey: evmSigner1},
+					},
+				},
+			},
+		}
+		_, err := evmOnly.signerKeys(chainsel.FamilyAptos)
+		require.ErrorContains(t, err, "no aptos ocr config found for node")
+	})
+}
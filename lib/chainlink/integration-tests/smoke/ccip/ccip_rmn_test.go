@@ -2,9 +2,9 @@ package smoke
 
 import (
 	"context"
-	"encoding/binary"
 	"errors"
-	"math/big"
+	"fmt"
+	"maps"
 	"os"
 	"slices"
 	"strconv"
@@ -12,7 +12,6 @@ import (
 	"testing"
 	"time"
 
-	mapset "github.com/deckarep/golang-set/v2"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rs/zerolog"
@@ -80,6 +79,70 @@ func TestRMN_MultipleMessagesOnOneLaneNoWaitForExec(t *testing.T) {
 	})
 }
 
+func TestRMN_PartialOracleParticipation(t *testing.T) {
+	runRmnTestCase(t, rmnTestCase{
+		name:        "one oracle is disabled, the remaining oracles still commit",
+		waitForExec: true,
+		homeChainConfig: homeChainConfig{
+			f: map[int]int{chain0: 1, chain1: 1},
+		},
+		remoteChainsConfig: []remoteChainConfig{
+			{chainIdx: chain0, f: 1},
+			{chainIdx: chain1, f: 1},
+		},
+		rmnNodes: []rmnNode{
+			{id: 0, isSigner: true, observedChainIdxs: []int{chain0, chain1}},
+			{id: 1, isSigner: true, observedChainIdxs: []int{chain0, chain1}},
+			{id: 2, isSigner: true, observedChainIdxs: []int{chain0, chain1}},
+		},
+		messagesToSend: []messageToSend{
+			{fromChainIdx: chain0, toChainIdx: chain1, count: 1},
+			{fromChainIdx: chain1, toChainIdx: chain0, count: 1},
+		},
+		// with F 1 the DON tolerates a single disabled oracle
+		disabledOracleIdxs: []int{0},
+	})
+}
+
+func TestRMN_CurseThenUncurseRecovers(t *testing.T) {
+	res := runRmnTestCase(t, rmnTestCase{
+		name:                "the lane from chain0 to chain1 is cursed, then uncursed",
+		passIfNoCommitAfter: 15 * time.Second,
+		homeChainConfig: homeChainConfig{
+			f: map[int]int{chain0: 1, chain1: 1},
+		},
+		remoteChainsConfig: []remoteChainConfig{
+			{chainIdx: chain0, f: 1},
+			{chainIdx: chain1, f: 1},
+		},
+		rmnNodes: []rmnNode{
+			{id: 0, isSigner: true, observedChainIdxs: []int{chain0, chain1}},
+			{id: 1, isSigner: true, observedChainIdxs: []int{chain0, chain1}},
+			{id: 2, isSigner: true, observedChainIdxs: []int{chain0, chain1}},
+		},
+		messagesToSend: []messageToSend{
+			{fromChainIdx: chain0, toChainIdx: chain1, count: 1}, // <----- not committed while cursed
+			{fromChainIdx: chain1, toChainIdx: chain0, count: 1},
+		},
+		cursedSubjectsPerChain: map[int][]int{
+			chain1: {chain0},
+		},
+	})
+	cursedLane := changeset.SourceDestPair{
+		SourceChainSelector: res.tc.pf.chainSelectors[chain0],
+		DestChainSelector:   res.tc.pf.chainSelectors[chain1],
+	}
+	require.Contains(t, res.sentSeqNums, cursedLane)
+	require.NotContains(t, res.committedSeqNums, cursedLane)
+	require.Len(t, res.committedSeqNums, 1)
+
+	res.tc.uncurseChains(testcontext.Get(t), t, res.onChainState, res.envWithRMN)
+	recovered := res.sendMessagesAndConfirmCommit(t)
+	require.Contains(t, recovered.committedSeqNums, cursedLane)
+	require.Greater(t, recovered.committedSeqNums[cursedLane], res.sentSeqNums[cursedLane])
+	require.Equal(t, res.activeDigest, recovered.activeDigest)
+}
+
 func TestRMN_NotEnoughObservers(t *testing.T) {
 	runRmnTestCase(t, rmnTestCase{
 		name:                "one message but not enough observers, should not get a commit report",
@@ -231,13 +294,218 @@ func TestRMN_GlobalCurseTwoMessagesOnTwoLanes(t *testing.T) {
 	})
 }
 
+func TestRMN_ThreeChainsOneLaneCursed(t *testing.T) {
+	runRmnTestCase(t, rmnTestCase{
+		name:      "three chains, only the lane from chain0 to chain2 is cursed",
+		numChains: 3,
+		lanes: []lane{
+			{fromChainIdx: chain0, toChainIdx: chain1},
+			{fromChainIdx: chain0, toChainIdx: chain2},
+			{fromChainIdx: chain1, toChainIdx: chain2},
+		},
+		homeChainConfig: homeChainConfig{
+			f: map[int]int{chain0: 1, chain1: 1, chain2: 1},
+		},
+		remoteChainsConfig: []remoteChainConfig{
+			{chainIdx: chain0, f: 1},
+			{chainIdx: chain1, f: 1},
+			{chainIdx: chain2, f: 1},
+		},
+		rmnNodes: []rmnNode{
+			{id: 0, isSigner: true, observedChainIdxs: []int{chain0, chain1, chain2}},
+			{id: 1, isSigner: true, observedChainIdxs: []int{chain0, chain1, chain2}},
+			{id: 2, isSigner: true, observedChainIdxs: []int{chain0, chain1, chain2}},
+		},
+		messagesToSend: []messageToSend{
+			{fromChainIdx: chain0, toChainIdx: chain1, count: 1},
+			{fromChainIdx: chain0, toChainIdx: chain2, count: 1}, // <----- this message should not be committed
+			{fromChainIdx: chain1, toChainIdx: chain2, count: 1},
+		},
+		cursedSubjectsPerChain: map[int][]int{
+			chain2: {chain0},
+		},
+		passIfNoCommitAfter: 15 * time.Second,
+	})
+}
+
+func TestRMN_AsymmetricObserversQuorum(t *testing.T) {
+	// node 3 observes chain1 only, so chain1 with F 0 and chain0 with F 1 have disjoint quorums
+	tc := rmnTestCase{
+		name: "asymmetric observers and F",
+		homeChainConfig: homeChainConfig{
+			f: map[int]int{chain0: 1, chain1: 0},
+		},
+		remoteChainsConfig: []remoteChainConfig{
+			{chainIdx: chain0, f: 1},
+			{chainIdx: chain1, f: 1},
+		},
+		rmnNodes: []rmnNode{
+			{id: 0, isSigner: true, observedChainIdxs: []int{chain0}},
+			{id: 1, isSigner: true, observedChainIdxs: []int{chain0}},
+			{id: 2, isSigner: true, observedChainIdxs: []int{chain0}, forceExit: true},
+			{id: 3, isSigner: true, observedChainIdxs: []int{chain1}},
+		},
+		messagesToSend: []messageToSend{
+			{fromChainIdx: chain0, toChainIdx: chain1, count: 1},
+			{fromChainIdx: chain1, toChainIdx: chain0, count: 1},
+		},
+	}
+	require.NoError(t, tc.validate())
+	require.Len(t, tc.observers(chain0), 3)
+	require.Len(t, tc.observers(chain1), 1)
+	require.True(t, tc.reachesObservationQuorum(chain0))
+	require.True(t, tc.reachesObservationQuorum(chain1))
+
+	// with a second observer of chain0 down it no longer reaches quorum, while chain1 still does
+	tc.rmnNodes[1].forceExit = true
+	require.False(t, tc.reachesObservationQuorum(chain0))
+	require.True(t, tc.reachesObservationQuorum(chain1))
+	require.ErrorContains(t, tc.validate(), "lane chain0->chain1 is expected to commit, but chain 0 does not have F+1 live observers")
+	tc.passIfNoCommitAfter = 15 * time.Second
+	require.NoError(t, tc.validate())
+
+	// a single observer is not enough for RMNHome to accept chain1 with F 1
+	tc.homeChainConfig.f[chain1] = 1
+	require.ErrorContains(t, tc.validate(), "chain 1 with F 1 needs at least 3 observers, got 1")
+}
+
+func TestRMN_ValidateUnderProvisionedTestCases(t *testing.T) {
+	baseCase := func() rmnTestCase {
+		return rmnTestCase{
+			name: "base",
+			homeChainConfig: homeChainConfig{
+				f: map[int]int{chain0: 1, chain1: 1},
+			},
+			remoteChainsConfig: []remoteChainConfig{
+				{chainIdx: chain0, f: 1},
+				{chainIdx: chain1, f: 1},
+			},
+			rmnNodes: []rmnNode{
+				{id: 0, isSigner: true, observedChainIdxs: []int{chain0, chain1}},
+				{id: 1, isSigner: true, observedChainIdxs: []int{chain0, chain1}},
+				{id: 2, isSigner: true, observedChainIdxs: []int{chain0, chain1}},
+			},
+			messagesToSend: []messageToSend{
+				{fromChainIdx: chain0, toChainIdx: chain1, count: 1},
+			},
+		}
+	}
+	require.NoError(t, baseCase().validate())
+
+	testCases := []struct {
+		name    string
+		modify  func(tc *rmnTestCase)
+		wantErr string
+	}{
+		{
+			name: "not enough live observers",
+			modify: func(tc *rmnTestCase) {
+				tc.rmnNodes[1].forceExit = true
+				tc.rmnNodes[1].isSigner = false
+				tc.rmnNodes[2].forceExit = true
+				tc.rmnNodes[2].isSigner = false
+				tc.rmnNodes = append(tc.rmnNodes, rmnNode{id: 3, isSigner: true}, rmnNode{id: 4, isSigner: true})
+			},
+			wantErr: "chain 0 does not have F+1 live observers",
+		},
+		{
+			name: "not enough observers for RMNHome",
+			modify: func(tc *rmnTestCase) {
+				tc.rmnNodes[2].observedChainIdxs = []int{chain1}
+			},
+			wantErr: "chain 0 with F 1 needs at least 3 observers, got 2",
+		},
+		{
+			name: "not enough live signers",
+			modify: func(tc *rmnTestCase) {
+				tc.rmnNodes[1].isSigner = false
+				tc.rmnNodes[2].forceExit = true
+			},
+			wantErr: "chain 1 does not have F+1 live signers",
+		},
+		{
+			name: "no RMNRemote config on the destination",
+			modify: func(tc *rmnTestCase) {
+				tc.remoteChainsConfig = tc.remoteChainsConfig[:1]
+			},
+			wantErr: "chain 1 has no RMNRemote config",
+		},
+		{
+			name: "under-provisioned lane is cursed",
+			modify: func(tc *rmnTestCase) {
+				tc.rmnNodes[1].isSigner = false
+				tc.rmnNodes[2].forceExit = true
+				tc.cursedSubjectsPerChain = map[int][]int{chain1: {chain0}}
+				tc.passIfNoCommitAfter = 15 * time.Second
+			},
+		},
+		{
+			name: "under-provisioned lane next to a cursed one",
+			modify: func(tc *rmnTestCase) {
+				tc.rmnNodes[1].isSigner = false
+				tc.rmnNodes[2].forceExit = true
+				tc.messagesToSend = append(tc.messagesToSend, messageToSend{fromChainIdx: chain1, toChainIdx: chain0, count: 1})
+				tc.cursedSubjectsPerChain = map[int][]int{chain1: {chain0}}
+				tc.passIfNoCommitAfter = 15 * time.Second
+			},
+			wantErr: "lane chain1->chain0 is expected to commit, but chain 0 does not have F+1 live signers",
+		},
+		{
+			name: "duplicate disabled oracle",
+			modify: func(tc *rmnTestCase) {
+				tc.disabledOracleIdxs = []int{1, 1}
+			},
+			wantErr: "duplicate disabled oracle index 1",
+		},
+		{
+			name: "no commit expected",
+			modify: func(tc *rmnTestCase) {
+				tc.rmnNodes[1].forceExit = true
+				tc.rmnNodes[2].forceExit = true
+				tc.passIfNoCommitAfter = 15 * time.Second
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tc := baseCase()
+			testCase.modify(&tc)
+			err := tc.validate()
+			if testCase.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, testCase.wantErr)
+		})
+	}
+}
+
 const (
 	chain0      = 0
 	chain1      = 1
+	chain2      = 2
 	globalCurse = 1000
 )
 
-func runRmnTestCase(t *testing.T, tc rmnTestCase) {
+// rmnTestCaseResult is the state of the environment after running an RMN test case, for callers to run further
+// phases against the same environment.
+type rmnTestCaseResult struct {
+	// tc is the test case with its populated fields.
+	tc           rmnTestCase
+	envWithRMN   changeset.DeployedEnv
+	onChainState changeset.CCIPOnChainState
+	// activeDigest is the active RMNHome config digest the RMNRemotes are configured with.
+	activeDigest [32]byte
+	startBlocks  map[uint64]*uint64
+	// sentSeqNums are the sequence numbers of the last message sent on each lane.
+	sentSeqNums map[changeset.SourceDestPair]uint64
+	// expectedSeqNums are the sent sequence numbers of the lanes which are expected to commit.
+	expectedSeqNums map[changeset.SourceDestPair]uint64
+	// committedSeqNums are the sequence numbers which were confirmed committed.
+	committedSeqNums map[changeset.SourceDestPair]uint64
+}
+
+func runRmnTestCase(t *testing.T, tc rmnTestCase) rmnTestCaseResult {
 	require.NoError(t, os.Setenv("ENABLE_RMN", "true"))
 	require.NoError(t, tc.validate())
 
@@ -300,8 +568,9 @@ func runRmnTestCase(t *testing.T, tc rmnTestCase) {
 	tc.killMarkedRmnNodes(t, rmnCluster)
 
 	changeset.ReplayLogs(t, envWithRMN.Env.Offchain, envWithRMN.ReplayBlocks)
-	require.NoError(t, changeset.AddLanesForAll(envWithRMN.Env, onChainState))
-	disabledNodes := tc.disableOraclesIfThisIsACursingTestCase(ctx, t, envWithRMN)
+	tc.addLanes(t, onChainState, envWithRMN)
+	enabledOracles := tc.disableOracles(ctx, t, envWithRMN)
+	disabledNodes := tc.disableOraclesIfThisIsACursingTestCase(ctx, t, envWithRMN, enabledOracles)
 
 	startBlocks, seqNumCommit, seqNumExec := tc.sendMessages(t, onChainState, envWithRMN)
 	t.Logf("Sent all messages, seqNumCommit: %v seqNumExec: %v", seqNumCommit, seqNumExec)
@@ -310,6 +579,15 @@ func runRmnTestCase(t *testing.T, tc rmnTestCase) {
 
 	tc.enableOracles(ctx, t, envWithRMN, disabledNodes)
 
+	result := rmnTestCaseResult{
+		envWithRMN:       envWithRMN,
+		onChainState:     onChainState,
+		activeDigest:     activeDigest,
+		startBlocks:      startBlocks,
+		sentSeqNums:      seqNumCommit,
+		committedSeqNums: make(map[changeset.SourceDestPair]uint64),
+	}
+
 	expectedSeqNum := make(map[changeset.SourceDestPair]uint64)
 	for k, v := range seqNumCommit {
 		cursedSubjectsOfDest, exists := tc.pf.cursedSubjectsPerChainSel[k.DestChainSelector]
@@ -321,6 +599,7 @@ func runRmnTestCase(t *testing.T, tc rmnTestCase) {
 		}
 	}
 
+	result.expectedSeqNums = expectedSeqNum
 	t.Logf("expectedSeqNums: %v", expectedSeqNum)
 	t.Logf("expectedSeqNums including cursed chains: %v", seqNumCommit)
 
@@ -348,6 +627,7 @@ func runRmnTestCase(t *testing.T, tc rmnTestCase) {
 			t.Logf("⌛ Waiting for commit reports of non-cursed chains...")
 			<-commitReportReceived
 			t.Logf("✅ Commit reports of non-cursed chains received")
+			maps.Copy(result.committedSeqNums, expectedSeqNum)
 		}
 
 		tim := time.NewTimer(tc.passIfNoCommitAfter)
@@ -356,39 +636,41 @@ func runRmnTestCase(t *testing.T, tc rmnTestCase) {
 		select {
 		case <-commitReportReceived:
 			t.Errorf("Commit report was received while it was not expected")
-			return
 		case <-tim.C:
-			return
+			tc.assertCursedSubjects(ctx, t, onChainState)
 		}
+		result.tc = tc
+		return result
 	}
 
 	t.Logf("⌛ Waiting for commit reports...")
 	<-commitReportReceived // wait for commit reports
 	t.Logf("✅ Commit report")
+	maps.Copy(result.committedSeqNums, expectedSeqNum)
 
 	if tc.waitForExec {
 		t.Logf("⌛ Waiting for exec reports...")
 		changeset.ConfirmExecWithSeqNrsForAll(t, envWithRMN.Env, onChainState, seqNumExec, startBlocks)
 		t.Logf("✅ Exec report")
 	}
+	result.tc = tc
+	return result
 }
 
-func createObserverNodesBitmap(chainSel uint64, rmnNodes []rmnNode, chainSelectors []uint64) *big.Int {
-	bitmap := new(big.Int)
-	for _, n := range rmnNodes {
-		observedChainSelectors := mapset.NewSet[uint64]()
-		for _, chainIdx := range n.observedChainIdxs {
-			observedChainSelectors.Add(chainSelectors[chainIdx])
-		}
-
-		if !observedChainSelectors.Contains(chainSel) {
-			continue
-		}
-
-		bitmap.SetBit(bitmap, n.id, 1)
-	}
+// sendMessagesAndConfirmCommit runs another phase of the test case against its environment: it sends the messages of
+// the test case again and waits for all of them to be committed.
+func (r rmnTestCaseResult) sendMessagesAndConfirmCommit(t *testing.T) rmnTestCaseResult {
+	startBlocks, seqNumCommit, _ := r.tc.sendMessages(t, r.onChainState, r.envWithRMN)
+	t.Logf("Sent all messages, seqNumCommit: %v", seqNumCommit)
+	changeset.ConfirmCommitForAllWithExpectedSeqNums(t, r.envWithRMN.Env, r.onChainState, seqNumCommit, startBlocks)
+	t.Logf("✅ Commit report")
 
-	return bitmap
+	next := r
+	next.startBlocks = startBlocks
+	next.sentSeqNums = seqNumCommit
+	next.expectedSeqNums = seqNumCommit
+	next.committedSeqNums = maps.Clone(seqNumCommit)
+	return next
 }
 
 type homeChainConfig struct {
@@ -413,6 +695,15 @@ type messageToSend struct {
 	count        int
 }
 
+type lane struct {
+	fromChainIdx int
+	toChainIdx   int
+}
+
+func (l lane) String() string {
+	return fmt.Sprintf("chain%d->chain%d", l.fromChainIdx, l.toChainIdx)
+}
+
 type rmnTestCase struct {
 	name string
 	// If set to 0, the test will wait for commit reports.
@@ -420,10 +711,17 @@ type rmnTestCase struct {
 	passIfNoCommitAfter    time.Duration
 	cursedSubjectsPerChain map[int][]int
 	waitForExec            bool
-	homeChainConfig        homeChainConfig
-	remoteChainsConfig     []remoteChainConfig
-	rmnNodes               []rmnNode
-	messagesToSend         []messageToSend
+	// numChains is the number of chains the test case runs on, defaults to 2.
+	numChains int
+	// lanes to enable between the chains, defaults to all the lanes between the test case chains.
+	lanes              []lane
+	homeChainConfig    homeChainConfig
+	remoteChainsConfig []remoteChainConfig
+	rmnNodes           []rmnNode
+	messagesToSend     []messageToSend
+	// disabledOracleIdxs are the indexes of the oracles, i.e. the non-bootstrap nodes sorted by name,
+	// to disable for the whole test case. All the oracles participate by default.
+	disabledOracleIdxs []int
 
 	// populated fields after environment setup
 	pf testCasePopulatedFields
@@ -438,21 +736,19 @@ type testCasePopulatedFields struct {
 }
 
 func (tc *rmnTestCase) populateFields(t *testing.T, envWithRMN changeset.DeployedEnv, rmnCluster devenv.RMNCluster) {
-	require.GreaterOrEqual(t, len(envWithRMN.Env.Chains), 2, "test assumes at least two chains")
-	for _, chain := range envWithRMN.Env.Chains {
-		tc.pf.chainSelectors = append(tc.pf.chainSelectors, chain.Selector)
-	}
+	// chain indexes are mapped to the lowest selectors of the environment for determinism
+	allSelectors := envWithRMN.Env.AllChainSelectors()
+	slices.Sort(allSelectors)
+	require.GreaterOrEqual(t, len(allSelectors), tc.chainCount(),
+		"test case requires %d chains, but the environment only has %d", tc.chainCount(), len(allSelectors))
+	tc.pf.chainSelectors = allSelectors[:tc.chainCount()]
 
 	for _, rmnNodeInfo := range tc.rmnNodes {
 		rmn := rmnCluster.Nodes["rmn_"+strconv.Itoa(rmnNodeInfo.id)]
 
-		var offchainPublicKey [32]byte
-		copy(offchainPublicKey[:], rmn.RMN.OffchainPublicKey)
-
-		tc.pf.rmnHomeNodes = append(tc.pf.rmnHomeNodes, rmn_home.RMNHomeNode{
-			PeerId:            rmn.Proxy.PeerID,
-			OffchainPublicKey: offchainPublicKey,
-		})
+		rmnHomeNode, err := changeset.NewRMNHomeNode(rmn.Proxy.PeerID.String(), rmn.RMN.OffchainPublicKey)
+		require.NoError(t, err, "invalid RMN node rmn_%d", rmnNodeInfo.id)
+		tc.pf.rmnHomeNodes = append(tc.pf.rmnHomeNodes, rmnHomeNode)
 
 		if rmnNodeInfo.isSigner {
 			if rmnNodeInfo.id < 0 {
@@ -465,15 +761,26 @@ func (tc *rmnTestCase) populateFields(t *testing.T, envWithRMN changeset.Deploye
 		}
 	}
 
+	// the bitmap refers to the position of the nodes in rmnHomeNodes, which is not necessarily their id
+	observedChainsByNodeIndex := make(map[int][]uint64)
+	for nodeIndex, n := range tc.rmnNodes {
+		for _, chainIdx := range n.observedChainIdxs {
+			observedChainsByNodeIndex[nodeIndex] = append(observedChainsByNodeIndex[nodeIndex], tc.pf.chainSelectors[chainIdx])
+		}
+	}
+
 	for remoteChainIdx, remoteF := range tc.homeChainConfig.f {
 		if remoteF < 0 {
 			t.Fatalf("negative remote F: %d", remoteF)
 		}
+		observerNodesBitmap, err := changeset.CreateObserverNodesBitmap(
+			tc.pf.chainSelectors[remoteChainIdx], tc.pf.rmnHomeNodes, observedChainsByNodeIndex)
+		require.NoError(t, err)
 		// configure remote chain details on the home contract
 		tc.pf.rmnHomeSourceChains = append(tc.pf.rmnHomeSourceChains, rmn_home.RMNHomeSourceChain{
 			ChainSelector:       tc.pf.chainSelectors[remoteChainIdx],
 			F:                   uint64(remoteF),
-			ObserverNodesBitmap: createObserverNodesBitmap(tc.pf.chainSelectors[remoteChainIdx], tc.rmnNodes, tc.pf.chainSelectors),
+			ObserverNodesBitmap: observerNodesBitmap,
 		})
 	}
 
@@ -491,14 +798,204 @@ func (tc *rmnTestCase) populateFields(t *testing.T, envWithRMN changeset.Deploye
 	}
 }
 
+func (tc rmnTestCase) chainCount() int {
+	if tc.numChains == 0 {
+		return 2
+	}
+	return tc.numChains
+}
+
+// enabledLanes returns the configured lanes, or all the lanes between the test case chains if none are configured.
+func (tc rmnTestCase) enabledLanes() []lane {
+	if len(tc.lanes) > 0 {
+		return tc.lanes
+	}
+	var lanes []lane
+	for from := 0; from < tc.chainCount(); from++ {
+		for to := 0; to < tc.chainCount(); to++ {
+			if from != to {
+				lanes = append(lanes, lane{fromChainIdx: from, toChainIdx: to})
+			}
+		}
+	}
+	return lanes
+}
+
 func (tc rmnTestCase) validate() error {
 	if len(tc.cursedSubjectsPerChain) > 0 && tc.passIfNoCommitAfter == 0 {
 		return errors.New("when you define cursed subjects you also need to define the duration that the " +
 			"test will wait for non-transmitted roots")
 	}
+	if tc.chainCount() < 2 {
+		return fmt.Errorf("test case needs at least two chains, got %d", tc.chainCount())
+	}
+	validChainIdx := func(idx int) error {
+		if idx < 0 || idx >= tc.chainCount() {
+			return fmt.Errorf("chain index %d is out of range for a test case with %d chains", idx, tc.chainCount())
+		}
+		return nil
+	}
+
+	lanes := make(map[lane]struct{})
+	for _, l := range tc.enabledLanes() {
+		if l.fromChainIdx == l.toChainIdx {
+			return fmt.Errorf("lane %s has the same source and destination", l)
+		}
+		if err := errors.Join(validChainIdx(l.fromChainIdx), validChainIdx(l.toChainIdx)); err != nil {
+			return fmt.Errorf("invalid lane %s: %w", l, err)
+		}
+		lanes[l] = struct{}{}
+	}
+	for _, msg := range tc.messagesToSend {
+		l := lane{fromChainIdx: msg.fromChainIdx, toChainIdx: msg.toChainIdx}
+		if _, ok := lanes[l]; !ok {
+			return fmt.Errorf("message is sent on lane %s which is not enabled", l)
+		}
+	}
+	for chainIdx, f := range tc.homeChainConfig.f {
+		if err := validChainIdx(chainIdx); err != nil {
+			return fmt.Errorf("invalid home chain config: %w", err)
+		}
+		if f < 0 {
+			return fmt.Errorf("negative F %d for chain %d", f, chainIdx)
+		}
+		// RMNHome rejects source chains with less observers
+		if observers := len(tc.observers(chainIdx)); observers < 2*f+1 {
+			return fmt.Errorf("chain %d with F %d needs at least %d observers, got %d", chainIdx, f, 2*f+1, observers)
+		}
+	}
+	remoteChains := make(map[int]struct{})
+	for _, remoteCfg := range tc.remoteChainsConfig {
+		remoteChains[remoteCfg.chainIdx] = struct{}{}
+	}
+	for _, l := range tc.lanesExpectedToCommit() {
+		if !tc.reachesObservationQuorum(l.fromChainIdx) {
+			return fmt.Errorf("lane %s is expected to commit, but chain %d does not have F+1 live observers", l, l.fromChainIdx)
+		}
+		if _, ok := remoteChains[l.toChainIdx]; !ok {
+			return fmt.Errorf("lane %s is expected to commit, but chain %d has no RMNRemote config", l, l.toChainIdx)
+		}
+		if !tc.reachesSignerQuorum(l.toChainIdx) {
+			return fmt.Errorf("lane %s is expected to commit, but chain %d does not have F+1 live signers", l, l.toChainIdx)
+		}
+	}
+	for _, remoteCfg := range tc.remoteChainsConfig {
+		if err := validChainIdx(remoteCfg.chainIdx); err != nil {
+			return fmt.Errorf("invalid remote chain config: %w", err)
+		}
+	}
+	disabledOracles := make(map[int]struct{})
+	for _, i := range tc.disabledOracleIdxs {
+		if i < 0 {
+			return fmt.Errorf("negative disabled oracle index %d", i)
+		}
+		if _, ok := disabledOracles[i]; ok {
+			return fmt.Errorf("duplicate disabled oracle index %d", i)
+		}
+		disabledOracles[i] = struct{}{}
+	}
+	nodeIDs := make(map[int]struct{})
+	for _, n := range tc.rmnNodes {
+		if _, ok := nodeIDs[n.id]; ok {
+			return fmt.Errorf("duplicate rmn node id %d", n.id)
+		}
+		nodeIDs[n.id] = struct{}{}
+		for _, chainIdx := range n.observedChainIdxs {
+			if err := validChainIdx(chainIdx); err != nil {
+				return fmt.Errorf("invalid observed chain of rmn node %d: %w", n.id, err)
+			}
+		}
+	}
+	for chainIdx, subjects := range tc.cursedSubjectsPerChain {
+		if err := validChainIdx(chainIdx); err != nil {
+			return fmt.Errorf("invalid cursing chain: %w", err)
+		}
+		for _, subject := range subjects {
+			if subject == globalCurse {
+				continue
+			}
+			if err := validChainIdx(subject); err != nil {
+				return fmt.Errorf("invalid cursed subject of chain %d: %w", chainIdx, err)
+			}
+		}
+	}
 	return nil
 }
 
+// observers returns the rmn nodes observing the chain. Which chains a node observes is independent of the F of
+// each chain, so a node can count towards the observers of some chains only.
+func (tc rmnTestCase) observers(chainIdx int) []rmnNode {
+	var observers []rmnNode
+	for _, n := range tc.rmnNodes {
+		if slices.Contains(n.observedChainIdxs, chainIdx) {
+			observers = append(observers, n)
+		}
+	}
+	return observers
+}
+
+// reachesObservationQuorum returns whether the observers of the chain which are not force exited reach the F+1
+// observations the RMN needs to bless the merkle roots of the chain.
+func (tc rmnTestCase) reachesObservationQuorum(chainIdx int) bool {
+	f, ok := tc.homeChainConfig.f[chainIdx]
+	if !ok {
+		return false
+	}
+	live := 0
+	for _, n := range tc.observers(chainIdx) {
+		if !n.forceExit {
+			live++
+		}
+	}
+	return live >= f+1
+}
+
+// reachesSignerQuorum returns whether the signers which are not force exited reach the F+1 signatures the RMNRemote
+// of the chain requires on the blessed merkle roots.
+func (tc rmnTestCase) reachesSignerQuorum(chainIdx int) bool {
+	idx := slices.IndexFunc(tc.remoteChainsConfig, func(remoteCfg remoteChainConfig) bool {
+		return remoteCfg.chainIdx == chainIdx
+	})
+	if idx < 0 {
+		return false
+	}
+	live := 0
+	for _, n := range tc.rmnNodes {
+		if n.isSigner && !n.forceExit {
+			live++
+		}
+	}
+	return live >= tc.remoteChainsConfig[idx].f+1
+}
+
+// lanesExpectedToCommit returns the lanes with messages the test case expects a commit report for:
+// all of them if it waits for commit reports, and only the ones which are not cursed if it curses any.
+func (tc rmnTestCase) lanesExpectedToCommit() []lane {
+	if tc.passIfNoCommitAfter > 0 && len(tc.cursedSubjectsPerChain) == 0 {
+		return nil
+	}
+	var lanes []lane
+	for _, msg := range tc.messagesToSend {
+		l := lane{fromChainIdx: msg.fromChainIdx, toChainIdx: msg.toChainIdx}
+		cursedSubjects := tc.cursedSubjectsPerChain[l.toChainIdx]
+		if slices.Contains(cursedSubjects, globalCurse) || slices.Contains(cursedSubjects, l.fromChainIdx) {
+			continue
+		}
+		if !slices.Contains(lanes, l) {
+			lanes = append(lanes, l)
+		}
+	}
+	return lanes
+}
+
+func (tc rmnTestCase) addLanes(t *testing.T, onChainState changeset.CCIPOnChainState, envWithRMN changeset.DeployedEnv) {
+	for _, l := range tc.enabledLanes() {
+		from, to := tc.pf.chainSelectors[l.fromChainIdx], tc.pf.chainSelectors[l.toChainIdx]
+		t.Logf("Adding lane %s (%d -> %d)", l, from, to)
+		require.NoError(t, changeset.AddLaneWithDefaultPricesAndFeeQuoterConfig(envWithRMN.Env, onChainState, from, to, false))
+	}
+}
+
 func (tc rmnTestCase) setRmnRemoteConfig(
 	ctx context.Context,
 	t *testing.T,
@@ -550,25 +1047,63 @@ func (tc rmnTestCase) killMarkedRmnNodes(t *testing.T, rmnCluster devenv.RMNClus
 	}
 }
 
-func (tc rmnTestCase) disableOraclesIfThisIsACursingTestCase(ctx context.Context, t *testing.T, envWithRMN changeset.DeployedEnv) []string {
-	disabledNodes := make([]string, 0)
+// oracleIDs returns the JD ids of the oracles, i.e. the non-bootstrap nodes, sorted by node name.
+func oracleIDs(ctx context.Context, t *testing.T, envWithRMN changeset.DeployedEnv) []string {
+	listNodesResp, err := envWithRMN.Env.Offchain.ListNodes(ctx, &node.ListNodesRequest{})
+	require.NoError(t, err)
+
+	oracles := slices.DeleteFunc(slices.Clone(listNodesResp.Nodes), func(n *node.Node) bool {
+		return strings.HasPrefix(n.Name, "bootstrap")
+	})
+	slices.SortFunc(oracles, func(a, b *node.Node) int { return strings.Compare(a.Name, b.Name) })
+	ids := make([]string, 0, len(oracles))
+	for _, n := range oracles {
+		ids = append(ids, n.Id)
+	}
+	return ids
+}
 
-	if len(tc.cursedSubjectsPerChain) > 0 {
-		listNodesResp, err := envWithRMN.Env.Offchain.ListNodes(ctx, &node.ListNodesRequest{})
+// setOracleEnabled enables or disables the oracle with the given JD node id.
+func setOracleEnabled(ctx context.Context, t *testing.T, envWithRMN changeset.DeployedEnv, nodeID string, enabled bool) {
+	if enabled {
+		_, err := envWithRMN.Env.Offchain.EnableNode(ctx, &node.EnableNodeRequest{Id: nodeID})
 		require.NoError(t, err)
+		t.Logf("node %s enabled", nodeID)
+		return
+	}
+	_, err := envWithRMN.Env.Offchain.DisableNode(ctx, &node.DisableNodeRequest{Id: nodeID})
+	require.NoError(t, err)
+	t.Logf("node %s disabled", nodeID)
+}
 
-		for _, n := range listNodesResp.Nodes {
-			if strings.HasPrefix(n.Name, "bootstrap") {
-				continue
-			}
-			_, err := envWithRMN.Env.Offchain.DisableNode(ctx, &node.DisableNodeRequest{Id: n.Id})
-			require.NoError(t, err)
-			disabledNodes = append(disabledNodes, n.Id)
-			t.Logf("node %s disabled", n.Id)
+// disableOracles disables the oracles at the given indexes of oracleIDs for the rest of the test case,
+// and returns the ids of the oracles which are still enabled.
+func (tc rmnTestCase) disableOracles(ctx context.Context, t *testing.T, envWithRMN changeset.DeployedEnv) []string {
+	ids := oracleIDs(ctx, t, envWithRMN)
+	for _, i := range tc.disabledOracleIdxs {
+		require.Less(t, i, len(ids), "disabled oracle index %d is out of range, there are %d oracles", i, len(ids))
+	}
+	var enabled []string
+	for i, id := range ids {
+		if slices.Contains(tc.disabledOracleIdxs, i) {
+			setOracleEnabled(ctx, t, envWithRMN, id, false)
+			continue
 		}
+		enabled = append(enabled, id)
 	}
+	return enabled
+}
 
-	return disabledNodes
+// disableOraclesIfThisIsACursingTestCase disables the given oracles while the chains are being cursed, so that no
+// commit report is sent before the curses are in place. It returns the disabled oracles to enable afterwards.
+func (tc rmnTestCase) disableOraclesIfThisIsACursingTestCase(ctx context.Context, t *testing.T, envWithRMN changeset.DeployedEnv, nodeIDs []string) []string {
+	if len(tc.cursedSubjectsPerChain) == 0 {
+		return nil
+	}
+	for _, id := range nodeIDs {
+		setOracleEnabled(ctx, t, envWithRMN, id, false)
+	}
+	return nodeIDs
 }
 
 func (tc rmnTestCase) sendMessages(t *testing.T, onChainState changeset.CCIPOnChainState, envWithRMN changeset.DeployedEnv) (map[uint64]*uint64, map[changeset.SourceDestPair]uint64, map[changeset.SourceDestPair][]uint64) {
@@ -620,10 +1155,7 @@ func (tc rmnTestCase) callContractsToCurseChains(ctx context.Context, t *testing
 		}
 
 		for _, subjectDescription := range cursedSubjects {
-			subj := types.GlobalCurseSubject
-			if subjectDescription != globalCurse {
-				subj = chainSelectorToBytes16(tc.pf.chainSelectors[subjectDescription])
-			}
+			subj := tc.curseSubject(subjectDescription)
 			t.Logf("cursing subject %d (%d)", subj, subjectDescription)
 			txCurse, errCurse := chState.RMNRemote.Curse(chain.DeployerKey, subj)
 			_, errConfirm := deployment.ConfirmIfNoError(chain, txCurse, errCurse)
@@ -636,17 +1168,68 @@ func (tc rmnTestCase) callContractsToCurseChains(ctx context.Context, t *testing
 	}
 }
 
-func (tc rmnTestCase) enableOracles(ctx context.Context, t *testing.T, envWithRMN changeset.DeployedEnv, nodeIDs []string) {
-	for _, n := range nodeIDs {
-		_, err := envWithRMN.Env.Offchain.EnableNode(ctx, &node.EnableNodeRequest{Id: n})
+// uncurseChains lifts the curses of callContractsToCurseChains.
+func (tc rmnTestCase) uncurseChains(ctx context.Context, t *testing.T, onChainState changeset.CCIPOnChainState, envWithRMN changeset.DeployedEnv) {
+	for chainIdx, cursedSubjects := range tc.cursedSubjectsPerChain {
+		chainSel := tc.pf.chainSelectors[chainIdx]
+		chain, ok := envWithRMN.Env.Chains[chainSel]
+		require.True(t, ok)
+		for _, subjectDescription := range cursedSubjects {
+			subj := tc.curseSubject(subjectDescription)
+			t.Logf("uncursing subject %d (%d) on chain %d", subj, subjectDescription, chainIdx)
+			tx, err := onChainState.Chains[chainSel].RMNRemote.Uncurse(chain.DeployerKey, subj)
+			_, err = deployment.ConfirmIfNoError(chain, tx, err)
+			require.NoError(t, err)
+		}
+		cs, err := onChainState.Chains[chainSel].RMNRemote.GetCursedSubjects(&bind.CallOpts{Context: ctx})
 		require.NoError(t, err)
-		t.Logf("node %s enabled", n)
+		require.Empty(t, cs, "chain %d is still cursed", chainIdx)
 	}
 }
 
-func chainSelectorToBytes16(chainSel uint64) [16]byte {
-	var result [16]byte
-	// Convert the uint64 to bytes and place it in the last 8 bytes of the array
-	binary.BigEndian.PutUint64(result[8:], chainSel)
-	return result
+// assertCursedSubjects asserts that the subjects cursed by callContractsToCurseChains are still cursed on each
+// RMNRemote, and that the RMNRemote emitted a Cursed event for them.
+func (tc rmnTestCase) assertCursedSubjects(ctx context.Context, t *testing.T, onChainState changeset.CCIPOnChainState) {
+	for _, remoteCfg := range tc.remoteChainsConfig {
+		cursedSubjects, ok := tc.cursedSubjectsPerChain[remoteCfg.chainIdx]
+		if !ok {
+			continue // nothing cursed on this chain
+		}
+		chState, ok := onChainState.Chains[tc.pf.chainSelectors[remoteCfg.chainIdx]]
+		require.True(t, ok)
+
+		expected := make([][16]byte, 0, len(cursedSubjects))
+		for _, subjectDescription := range cursedSubjects {
+			expected = append(expected, tc.curseSubject(subjectDescription))
+		}
+
+		cs, err := chState.RMNRemote.GetCursedSubjects(&bind.CallOpts{Context: ctx})
+		require.NoError(t, err)
+		require.Subsetf(t, cs, expected, "subjects of chain %d are no longer cursed", remoteCfg.chainIdx)
+
+		it, err := chState.RMNRemote.FilterCursed(&bind.FilterOpts{Context: ctx})
+		require.NoError(t, err)
+		var emitted [][16]byte
+		for it.Next() {
+			emitted = append(emitted, it.Event.Subjects...)
+		}
+		require.NoError(t, it.Error())
+		require.NoError(t, it.Close())
+		require.Subsetf(t, emitted, expected, "no Cursed event emitted for the subjects of chain %d", remoteCfg.chainIdx)
+		t.Logf("✅ Subjects of chain %d are still cursed: %v", remoteCfg.chainIdx, cs)
+	}
+}
+
+// curseSubject returns the RMNRemote subject of a subject description of cursedSubjectsPerChain.
+func (tc rmnTestCase) curseSubject(subjectDescription int) [16]byte {
+	if subjectDescription == globalCurse {
+		return types.GlobalCurseSubject
+	}
+	return changeset.ChainSelectorToSubject(tc.pf.chainSelectors[subjectDescription])
+}
+
+func (tc rmnTestCase) enableOracles(ctx context.Context, t *testing.T, envWithRMN changeset.DeployedEnv, nodeIDs []string) {
+	for _, n := range nodeIDs {
+		setOracleEnabled(ctx, t, envWithRMN, n, true)
+	}
 }
@@ -192,6 +192,41 @@ func TestTokenTransfer(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("Send token to reverting contract", func(t *testing.T) {
+		receiver := state.Chains[destChain].Receiver
+		tx, err := receiver.SetRevert(ownerDestChain, true)
+		_, err = deployment.ConfirmIfNoError(e.Chains[destChain], tx, err)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			tx, err := receiver.SetRevert(ownerDestChain, false)
+			_, err = deployment.ConfirmIfNoError(e.Chains[destChain], tx, err)
+			require.NoError(t, err)
+		})
+
+		initialBalance := changeset.GetTokenBalance(ctx, t, destToken.Address(), receiver.Address(), e.Chains[destChain])
+
+		returnData := changeset.TransferAndWaitForFailure(
+			ctx,
+			t,
+			e,
+			state,
+			sourceChain,
+			destChain,
+			[]router.ClientEVMTokenAmount{
+				{
+					Token:  srcToken.Address(),
+					Amount: oneE18,
+				},
+			},
+			receiver.Address(),
+			[]byte("hello reverting receiver"),
+		)
+		require.NotEmpty(t, returnData, "expected the receiver revert reason to be surfaced")
+
+		// tokens are not released to the receiver when execution fails
+		require.Equal(t, initialBalance, changeset.GetTokenBalance(ctx, t, destToken.Address(), receiver.Address(), e.Chains[destChain]))
+	})
 }
 
 func createAndFundSelfServeActor(
@@ -12,12 +12,10 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
-	"github.com/smartcontractkit/chainlink-testing-framework/lib/utils/testcontext"
 	"github.com/smartcontractkit/chainlink/deployment"
 	"github.com/smartcontractkit/chainlink/deployment/ccip/changeset"
 	testsetups "github.com/smartcontractkit/chainlink/integration-tests/testsetups/ccip"
 	"github.com/smartcontractkit/chainlink/v2/core/chains/evm/utils"
-	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/onramp"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/ccip/generated/router"
 	"github.com/smartcontractkit/chainlink/v2/core/gethwrappers/shared/generated/burn_mint_erc677"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
@@ -190,6 +188,13 @@ func TestUSDCTokenTransfer(t *testing.T) {
 				initialBalances[token] = initialBalance
 			}
 
+			srcPool := state.Chains[tt.sourceChain].USDCTokenPool.Address()
+			destPool := state.Chains[tt.destChain].USDCTokenPool.Address()
+			srcPoolBefore, err := changeset.GetPoolLiquidity(ctx, e.Chains[tt.sourceChain], srcPool)
+			require.NoError(t, err)
+			destPoolBefore, err := changeset.GetPoolLiquidity(ctx, e.Chains[tt.destChain], destPool)
+			require.NoError(t, err)
+
 			changeset.TransferAndWaitForSuccess(
 				ctx,
 				t,
@@ -207,48 +212,42 @@ func TestUSDCTokenTransfer(t *testing.T) {
 				expected := new(big.Int).Add(initialBalances[token], balance)
 				changeset.WaitForTheTokenBalance(ctx, t, token, tt.receiver, e.Chains[tt.destChain], expected)
 			}
+
+			// the source pool burns and the dest pool mints exactly the USDC amount transferred
+			usdcAmount := big.NewInt(0)
+			for _, token := range tt.tokens {
+				if token.Token == srcPoolBefore.Token {
+					usdcAmount.Add(usdcAmount, token.Amount)
+				}
+			}
+			srcPoolAfter, err := changeset.GetPoolLiquidity(ctx, e.Chains[tt.sourceChain], srcPool)
+			require.NoError(t, err)
+			destPoolAfter, err := changeset.GetPoolLiquidity(ctx, e.Chains[tt.destChain], destPool)
+			require.NoError(t, err)
+			changeset.RequirePoolLiquidityMoved(t, srcPoolBefore, srcPoolAfter, destPoolBefore, destPoolAfter, usdcAmount)
 		})
 	}
 
 	t.Run("multi-source USDC transfer targeting the same dest receiver", func(t *testing.T) {
-		sendSingleTokenTransfer := func(source, dest uint64, token common.Address, receiver common.Address) (*onramp.OnRampCCIPMessageSent, changeset.SourceDestPair) {
-			msg := changeset.TestSendRequest(t, e, state, source, dest, false, router.ClientEVM2AnyMessage{
-				Receiver:     common.LeftPadBytes(receiver.Bytes(), 32),
-				Data:         []byte{},
-				TokenAmounts: []router.ClientEVMTokenAmount{{Token: token, Amount: tinyOneCoin}},
-				FeeToken:     common.HexToAddress("0x0"),
-				ExtraArgs:    nil,
-			})
-			return msg, changeset.SourceDestPair{
-				SourceChainSelector: source,
-				DestChainSelector:   dest,
-			}
-		}
-
 		receiver := utils.RandomAddress()
 
-		startBlocks := make(map[uint64]*uint64)
-		expectedSeqNum := make(map[changeset.SourceDestPair]uint64)
-		expectedSeqNumExec := make(map[changeset.SourceDestPair][]uint64)
-
-		latesthdr, err := e.Chains[chainC].Client.HeaderByNumber(testcontext.Get(t), nil)
-		require.NoError(t, err)
-		block := latesthdr.Number.Uint64()
-		startBlocks[chainC] = &block
-
-		message1, message1ID := sendSingleTokenTransfer(chainA, chainC, aChainUSDC.Address(), receiver)
-		expectedSeqNum[message1ID] = message1.SequenceNumber
-		expectedSeqNumExec[message1ID] = []uint64{message1.SequenceNumber}
-
-		message2, message2ID := sendSingleTokenTransfer(chainB, chainC, bChainUSDC.Address(), receiver)
-		expectedSeqNum[message2ID] = message2.SequenceNumber
-		expectedSeqNumExec[message2ID] = []uint64{message2.SequenceNumber}
-
-		changeset.ConfirmCommitForAllWithExpectedSeqNums(t, e, state, expectedSeqNum, startBlocks)
-		states := changeset.ConfirmExecWithSeqNrsForAll(t, e, state, expectedSeqNumExec, startBlocks)
-
-		require.Equal(t, changeset.EXECUTION_STATE_SUCCESS, states[message1ID][message1.SequenceNumber])
-		require.Equal(t, changeset.EXECUTION_STATE_SUCCESS, states[message2ID][message2.SequenceNumber])
+		states := changeset.SendAndConfirmMultiSource(ctx, t, e, state, []changeset.TransferSpec{
+			{
+				SourceChain: chainA,
+				DestChain:   chainC,
+				Tokens:      []router.ClientEVMTokenAmount{{Token: aChainUSDC.Address(), Amount: tinyOneCoin}},
+				Receiver:    receiver,
+				Data:        []byte{},
+			},
+			{
+				SourceChain: chainB,
+				DestChain:   chainC,
+				Tokens:      []router.ClientEVMTokenAmount{{Token: bChainUSDC.Address(), Amount: tinyOneCoin}},
+				Receiver:    receiver,
+				Data:        []byte{},
+			},
+		})
+		require.Equal(t, []int{changeset.EXECUTION_STATE_SUCCESS, changeset.EXECUTION_STATE_SUCCESS}, states)
 
 		// We sent 1 coin from each source chain, so we should have 2 coins on the destination chain
 		// Receiver is randomly generated so we don't need to get the initial balance first